@@ -0,0 +1,122 @@
+package v1
+
+import (
+	"fmt"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// OperationStatusCondition ...
+// +k8s:openapi-gen=true
+type OperationStatusCondition struct {
+	LastTransitionTime *metav1.Time                 `json:"lastTransitionTime,omitempty"`
+	LastUpdateTime     metav1.Time                  `json:"lastUpdateTime,omitempty"`
+	Reason             string                       `json:"reason,omitempty"`
+	Message            string                       `json:"message,omitempty"`
+	Status             corev1.ConditionStatus       `json:"status,omitempty"`
+	Type               OperationStatusConditionType `json:"type,omitempty"`
+}
+
+// OperationStatusConditionType ...
+type OperationStatusConditionType string
+
+const (
+	// OperationStatusConditionTypeStarted indicates whether operation has been started
+	OperationStatusConditionTypeStarted OperationStatusConditionType = "Started"
+	// OperationStatusConditionTypeCompleted indicates whether operation has been completed
+	OperationStatusConditionTypeCompleted OperationStatusConditionType = "Completed"
+)
+
+const (
+	// OperationStatusReasonError indicates that the command failed or could not be run
+	OperationStatusReasonError = "Error"
+	// OperationStatusReasonTimedOut indicates that the operation did not complete within spec.timeout
+	OperationStatusReasonTimedOut = "TimedOut"
+	// OperationStatusReasonCancelled indicates that the operation was cancelled through spec.cancel
+	OperationStatusReasonCancelled = "Cancelled"
+)
+
+const (
+	// OperationOutputTailLimit is the number of bytes of each output stream of the command kept in status
+	OperationOutputTailLimit = 4 * 1024
+	// OperationPodOutputTailLimit is the number of bytes of each output stream of the command kept in the status of each pod
+	OperationPodOutputTailLimit = 1024
+	// OperationOutputStoreLimit is the number of bytes of each output stream of the command kept in the
+	// output ConfigMap or Secret, which keeps both streams below the size limit of the resource
+	OperationOutputStoreLimit = 512 * 1024
+)
+
+// Validate checks that the operation targets pods in exactly one way
+func (s *RuntimeOperationSpec) Validate() error {
+	targets := 0
+	for _, set := range []bool{s.PodName != "", s.ComponentName != "", s.Selector != nil} {
+		if set {
+			targets++
+		}
+	}
+	if targets != 1 {
+		return fmt.Errorf("exactly one of the fields spec.podName, spec.componentName and spec.selector must be set")
+	}
+	return nil
+}
+
+// GetConcurrency returns the maximum number of pods to run the command on at the same time
+func (s *RuntimeOperationSpec) GetConcurrency() int {
+	if s.Concurrency == nil || *s.Concurrency < 1 {
+		return 1
+	}
+	return int(*s.Concurrency)
+}
+
+// GetRetries returns the number of times to retry the command on a pod where it cannot be run
+func (s *RuntimeOperationSpec) GetRetries() int {
+	if s.Retries == nil || *s.Retries < 0 {
+		return 0
+	}
+	return int(*s.Retries)
+}
+
+// IsCancelled returns whether the operation is requested to be cancelled
+func (s *RuntimeOperationSpec) IsCancelled() bool {
+	return s.Cancel != nil && *s.Cancel
+}
+
+// GetTTLSecondsAfterFinished returns the number of seconds to keep the operation after it succeeded or failed,
+// or nil if it is not set in the spec
+func (s *RuntimeOperationSpec) GetTTLSecondsAfterFinished(succeeded bool) *int32 {
+	if !succeeded && s.TTLSecondsAfterFailure != nil {
+		return s.TTLSecondsAfterFailure
+	}
+	return s.TTLSecondsAfterFinished
+}
+
+// GetOperationCondition returns condition of specific type
+func GetOperationCondition(c []OperationStatusCondition, t OperationStatusConditionType) *OperationStatusCondition {
+	for i := range c {
+		if c[i].Type == t {
+			return &c[i]
+		}
+	}
+	return nil
+}
+
+// SetOperationCondition set condition of specific type or appends if not present
+func SetOperationCondition(c []OperationStatusCondition, oc OperationStatusCondition) []OperationStatusCondition {
+	condition := GetOperationCondition(c, oc.Type)
+
+	if condition != nil {
+		if condition.Status != oc.Status {
+			condition.LastTransitionTime = &metav1.Time{Time: time.Now()}
+		}
+		condition.Status = oc.Status
+		condition.LastUpdateTime = metav1.Time{Time: time.Now()}
+		condition.Reason = oc.Reason
+		condition.Message = oc.Message
+		return c
+	}
+	oc.LastUpdateTime = metav1.Time{Time: time.Now()}
+	c = append(c, oc)
+	return c
+}
@@ -0,0 +1,2164 @@
+/*
+
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1
+
+import (
+	"time"
+
+	"github.com/application-stacks/runtime-component-operator/common"
+	routev1 "github.com/openshift/api/route/v1"
+	prometheusv1 "github.com/prometheus-operator/prometheus-operator/pkg/apis/monitoring/v1"
+	appsv1 "k8s.io/api/apps/v1"
+	autoscalingv2beta2 "k8s.io/api/autoscaling/v2beta2"
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+// NOTE: json tags are required.  Any new fields you add must have json tags for the fields to be serialized.
+
+// Defines the desired state of RuntimeComponent.
+type RuntimeComponentSpec struct {
+
+	// Application image to deploy.
+	// +operator-sdk:csv:customresourcedefinitions:order=1,type=spec,displayName="Application Image",xDescriptors="urn:alm:descriptor:com.tectonic.ui:text"
+	ApplicationImage string `json:"applicationImage"`
+
+	// Name of the application. Defaults to the name of this custom resource.
+	// +operator-sdk:csv:customresourcedefinitions:order=2,type=spec,displayName="Application Name",xDescriptors="urn:alm:descriptor:com.tectonic.ui:text"
+	ApplicationName string `json:"applicationName,omitempty"`
+
+	// Version of the application.
+	// +operator-sdk:csv:customresourcedefinitions:order=3,type=spec,displayName="Application Version",xDescriptors="urn:alm:descriptor:com.tectonic.ui:text"
+	ApplicationVersion string `json:"applicationVersion,omitempty"`
+
+	// Policy for pulling container images. Defaults to IfNotPresent.
+	// +operator-sdk:csv:customresourcedefinitions:order=4,type=spec,displayName="Pull Policy",xDescriptors="urn:alm:descriptor:com.tectonic.ui:imagePullPolicy"
+	PullPolicy *corev1.PullPolicy `json:"pullPolicy,omitempty"`
+
+	// Name of the Secret to use to pull images from the specified repository. It is not required if the cluster is configured with a global image pull secret.
+	// +operator-sdk:csv:customresourcedefinitions:order=5,type=spec,displayName="Pull Secret",xDescriptors="urn:alm:descriptor:io.kubernetes:Secret"
+	PullSecret *string `json:"pullSecret,omitempty"`
+
+	// Name of the service account to use for deploying the application. A service account is automatically created if it's not specified.
+	// +operator-sdk:csv:customresourcedefinitions:order=6,type=spec,displayName="Service Account Name",xDescriptors="urn:alm:descriptor:com.tectonic.ui:text"
+	ServiceAccountName *string `json:"serviceAccountName,omitempty"`
+
+	// Create Knative resources and use Knative serving.
+	// +operator-sdk:csv:customresourcedefinitions:order=7,type=spec,displayName="Create Knative Service",xDescriptors="urn:alm:descriptor:com.tectonic.ui:booleanSwitch"
+	CreateKnativeService *bool `json:"createKnativeService,omitempty"`
+
+	// Autoscaling, resources, revisions and traffic of the Knative service. Requires .spec.createKnativeService.
+	// +operator-sdk:csv:customresourcedefinitions:order=7,type=spec,displayName="Knative"
+	Knative *RuntimeComponentKnative `json:"knative,omitempty"`
+
+	// Expose the application externally via a Route, a Knative Route or an Ingress resource.
+	// +operator-sdk:csv:customresourcedefinitions:order=8,type=spec,displayName="Expose",xDescriptors="urn:alm:descriptor:com.tectonic.ui:booleanSwitch"
+	Expose *bool `json:"expose,omitempty"`
+
+	// Enable management of TLS certificates. Defaults to true.
+	// +operator-sdk:csv:customresourcedefinitions:order=8,type=spec,displayName="Manage TLS",xDescriptors="urn:alm:descriptor:com.tectonic.ui:booleanSwitch"
+	ManageTLS *bool `json:"manageTLS,omitempty"`
+
+	// Number of pods to create. Not applicable when .spec.autoscaling, .spec.eventDrivenAutoscaling or .spec.createKnativeService is specified.
+	// +operator-sdk:csv:customresourcedefinitions:order=9,type=spec,displayName="Replicas",xDescriptors="urn:alm:descriptor:com.tectonic.ui:podCount"
+	Replicas *int32 `json:"replicas,omitempty"`
+
+	// +operator-sdk:csv:customresourcedefinitions:order=10,type=spec,displayName="Auto Scaling"
+	Autoscaling *RuntimeComponentAutoScaling `json:"autoscaling,omitempty"`
+
+	// Event-driven autoscaling with a KEDA ScaledObject. Cannot be used together with .spec.autoscaling.
+	// +operator-sdk:csv:customresourcedefinitions:order=10,type=spec,displayName="Event Driven Auto Scaling"
+	EventDrivenAutoscaling *RuntimeComponentEventDrivenAutoScaling `json:"eventDrivenAutoscaling,omitempty"`
+
+	// Vertical autoscaling of the resources of the application container with a VerticalPodAutoscaler.
+	// +operator-sdk:csv:customresourcedefinitions:order=10,type=spec,displayName="Vertical Auto Scaling"
+	VerticalAutoscaling *RuntimeComponentVerticalAutoScaling `json:"verticalAutoscaling,omitempty"`
+
+	// +operator-sdk:csv:customresourcedefinitions:order=10,type=spec,displayName="Disruption Budget"
+	DisruptionBudget *RuntimeComponentDisruptionBudget `json:"disruptionBudget,omitempty"`
+
+	// Roll back the Deployment or StatefulSet to the last healthy application image when a new image fails to roll out.
+	// +operator-sdk:csv:customresourcedefinitions:order=10,type=spec,displayName="Auto Rollback"
+	AutoRollback *RuntimeComponentAutoRollback `json:"autoRollback,omitempty"`
+
+	// Resource requests and limits for the application container.
+	// +operator-sdk:csv:customresourcedefinitions:order=11,type=spec,displayName="Resource Requirements",xDescriptors="urn:alm:descriptor:com.tectonic.ui:resourceRequirements"
+	Resources *corev1.ResourceRequirements `json:"resources,omitempty"`
+
+	// +operator-sdk:csv:customresourcedefinitions:order=12,type=spec,displayName="Probes"
+	Probes *RuntimeComponentProbes `json:"probes,omitempty"`
+
+	// +operator-sdk:csv:customresourcedefinitions:order=13,type=spec,displayName="Deployment"
+	Deployment *RuntimeComponentDeployment `json:"deployment,omitempty"`
+
+	// +operator-sdk:csv:customresourcedefinitions:order=14,type=spec,displayName="StatefulSet"
+	StatefulSet *RuntimeComponentStatefulSet `json:"statefulSet,omitempty"`
+
+	// +operator-sdk:csv:customresourcedefinitions:order=15,type=spec,displayName="Service"
+	Service *RuntimeComponentService `json:"service,omitempty"`
+
+	// +operator-sdk:csv:customresourcedefinitions:order=16,type=spec,displayName="Route"
+	Route *RuntimeComponentRoute `json:"route,omitempty"`
+
+	// Expose the application with a Gateway API HTTPRoute instead of a Route or an Ingress when .spec.expose is true.
+	// +operator-sdk:csv:customresourcedefinitions:order=16,type=spec,displayName="Gateway"
+	Gateway *RuntimeComponentGateway `json:"gateway,omitempty"`
+
+	// +operator-sdk:csv:customresourcedefinitions:order=17,type=spec,displayName="Monitoring"
+	Monitoring *RuntimeComponentMonitoring `json:"monitoring,omitempty"`
+
+	// An array of environment variables for the application container.
+	// +listType=map
+	// +listMapKey=name
+	// +operator-sdk:csv:customresourcedefinitions:order=18,type=spec,displayName="Environment Variables"
+	Env []corev1.EnvVar `json:"env,omitempty"`
+
+	// List of sources to populate environment variables in the application container.
+	// +listType=atomic
+	// +operator-sdk:csv:customresourcedefinitions:order=19,type=spec,displayName="Environment Variables from Sources"
+	EnvFrom []corev1.EnvFromSource `json:"envFrom,omitempty"`
+
+	// Represents a volume with data that is accessible to the application container.
+	// +listType=map
+	// +listMapKey=name
+	// +operator-sdk:csv:customresourcedefinitions:order=20,type=spec,displayName="Volumes"
+	Volumes []corev1.Volume `json:"volumes,omitempty"`
+
+	// Represents where to mount the volumes into the application container.
+	// +listType=atomic
+	// +operator-sdk:csv:customresourcedefinitions:order=21,type=spec,displayName="Volume Mounts"
+	VolumeMounts []corev1.VolumeMount `json:"volumeMounts,omitempty"`
+
+	// List of containers to run before other containers in a pod.
+	// +listType=map
+	// +listMapKey=name
+	// +operator-sdk:csv:customresourcedefinitions:order=22,type=spec,displayName="Init Containers"
+	InitContainers []corev1.Container `json:"initContainers,omitempty"`
+
+	// List of sidecar containers. These are additional containers to be added to the pods.
+	// +listType=map
+	// +listMapKey=name
+	// +operator-sdk:csv:customresourcedefinitions:order=23,type=spec,displayName="Sidecar Containers"
+	SidecarContainers []corev1.Container `json:"sidecarContainers,omitempty"`
+
+	// +operator-sdk:csv:customresourcedefinitions:order=24,type=spec,displayName="Affinity"
+	Affinity *RuntimeComponentAffinity `json:"affinity,omitempty"`
+
+	// +operator-sdk:csv:customresourcedefinitions:order=24,type=spec,displayName="Topology Spread Constraints"
+	TopologySpreadConstraints *RuntimeComponentTopologySpreadConstraints `json:"topologySpreadConstraints,omitempty"`
+
+	// Security context for the application container.
+	// +operator-sdk:csv:customresourcedefinitions:order=25,type=spec,displayName="Security Context"
+	SecurityContext *corev1.SecurityContext `json:"securityContext,omitempty"`
+
+	// +operator-sdk:csv:customresourcedefinitions:order=26,type=spec,displayName="Network Policy"
+	NetworkPolicy *RuntimeComponentNetworkPolicy `json:"networkPolicy,omitempty"`
+
+	// Mount a trust bundle of CA certificates in the application container at /etc/x509/trust-bundle, as a PEM file and a Java trust store.
+	// +operator-sdk:csv:customresourcedefinitions:order=27,type=spec,displayName="Trust Bundle"
+	TrustBundle *RuntimeComponentTrustBundle `json:"trustBundle,omitempty"`
+
+	// Issue a client certificate with a SPIFFE URI SAN to the component and restrict the components that may call it with mutual TLS.
+	// +operator-sdk:csv:customresourcedefinitions:order=28,type=spec,displayName="Mutual TLS"
+	MTLS *RuntimeComponentMTLS `json:"mtls,omitempty"`
+}
+
+// Configures mutual TLS between components.
+type RuntimeComponentMTLS struct {
+	// Components that may call this component. Their client certificates are trusted by the server, their URI SANs are allowed
+	// and their pods are allowed by the network policy.
+	// +listType=map
+	// +listMapKey=name
+	// +listMapKey=namespace
+	// +operator-sdk:csv:customresourcedefinitions:order=1,type=spec,displayName="Allowed Callers"
+	AllowedCallers []RuntimeComponentMTLSCaller `json:"allowedCallers,omitempty"`
+}
+
+// Identifies a component that may call this component.
+type RuntimeComponentMTLSCaller struct {
+	// Name of the calling component.
+	// +operator-sdk:csv:customresourcedefinitions:order=1,type=spec,displayName="Name",xDescriptors="urn:alm:descriptor:com.tectonic.ui:text"
+	Name string `json:"name"`
+
+	// Namespace of the calling component. Defaults to the namespace of this component.
+	// +operator-sdk:csv:customresourcedefinitions:order=2,type=spec,displayName="Namespace",xDescriptors="urn:alm:descriptor:com.tectonic.ui:text"
+	Namespace string `json:"namespace,omitempty"`
+}
+
+// Selects the trust bundle mounted in the application container.
+type RuntimeComponentTrustBundle struct {
+	// Name of the ConfigMap that contains the PEM certificates of the trust bundle. Defaults to rco-trust-bundle, which
+	// the operator publishes in the namespace with the CA certificates of the service certificates that it issues.
+	// +operator-sdk:csv:customresourcedefinitions:order=1,type=spec,displayName="ConfigMap Name",xDescriptors="urn:alm:descriptor:io.kubernetes:ConfigMap"
+	ConfigMapName string `json:"configMapName,omitempty"`
+
+	// Key of the PEM certificates in the ConfigMap. Defaults to ca-bundle.crt.
+	// +operator-sdk:csv:customresourcedefinitions:order=2,type=spec,displayName="Key",xDescriptors="urn:alm:descriptor:com.tectonic.ui:text"
+	Key string `json:"key,omitempty"`
+}
+
+// Define health checks on application container to determine whether it is alive or ready to receive traffic
+type RuntimeComponentProbes struct {
+	// Periodic probe of container liveness. Container will be restarted if the probe fails.
+	// +operator-sdk:csv:customresourcedefinitions:order=3,type=spec,displayName="Liveness Probe"
+	Liveness *corev1.Probe `json:"liveness,omitempty"`
+
+	// Periodic probe of container service readiness. Container will be removed from service endpoints if the probe fails.
+	// +operator-sdk:csv:customresourcedefinitions:order=2,type=spec,displayName="Readiness Probe"
+	Readiness *corev1.Probe `json:"readiness,omitempty"`
+
+	// Probe to determine successful initialization. If specified, other probes are not executed until this completes successfully.
+	// +operator-sdk:csv:customresourcedefinitions:order=1,type=spec,displayName="Startup Probe"
+	Startup *corev1.Probe `json:"startup,omitempty"`
+}
+
+// Configure pods to run on particular Nodes.
+type RuntimeComponentAffinity struct {
+	// Controls which nodes the pod are scheduled to run on, based on labels on the node.
+	// +operator-sdk:csv:customresourcedefinitions:order=33,type=spec,displayName="Node Affinity",xDescriptors="urn:alm:descriptor:com.tectonic.ui:nodeAffinity"
+	NodeAffinity *corev1.NodeAffinity `json:"nodeAffinity,omitempty"`
+
+	// Controls the nodes the pod are scheduled to run on, based on labels on the pods that are already running on the node.
+	// +operator-sdk:csv:customresourcedefinitions:order=34,type=spec,displayName="Pod Affinity",xDescriptors="urn:alm:descriptor:com.tectonic.ui:podAffinity"
+	PodAffinity *corev1.PodAffinity `json:"podAffinity,omitempty"`
+
+	// Enables the ability to prevent running a pod on the same node as another pod.
+	// +operator-sdk:csv:customresourcedefinitions:order=35,type=spec,displayName="Pod Anti Affinity",xDescriptors="urn:alm:descriptor:com.tectonic.ui:podAntiAffinity"
+	PodAntiAffinity *corev1.PodAntiAffinity `json:"podAntiAffinity,omitempty"`
+
+	// A YAML object that contains a set of required labels and their values.
+	// +operator-sdk:csv:customresourcedefinitions:order=36,type=spec,displayName="Node Affinity Labels",xDescriptors="urn:alm:descriptor:com.tectonic.ui:text"
+	NodeAffinityLabels map[string]string `json:"nodeAffinityLabels,omitempty"`
+
+	// An array of architectures to be considered for deployment. Their position in the array indicates preference.
+	// +listType=set
+	Architecture []string `json:"architecture,omitempty"`
+}
+
+// Defines how the pods are spread across the topology domains of the cluster, such as zones and hosts.
+type RuntimeComponentTopologySpreadConstraints struct {
+	// Topology spread constraints of the pods. A constraint without a labelSelector selects the pods of the application.
+	// Replaces the operator default of the same topologyKey.
+	// +listType=map
+	// +listMapKey=topologyKey
+	// +listMapKey=whenUnsatisfiable
+	// +operator-sdk:csv:customresourcedefinitions:order=1,type=spec,displayName="Constraints"
+	Constraints []corev1.TopologySpreadConstraint `json:"constraints,omitempty"`
+
+	// Disable the operator defaults, which spread the pods across zones and hosts when possible. Defaults to false.
+	// +operator-sdk:csv:customresourcedefinitions:order=2,type=spec,displayName="Disable Operator Defaults",xDescriptors="urn:alm:descriptor:com.tectonic.ui:booleanSwitch"
+	DisableOperatorDefaults *bool `json:"disableOperatorDefaults,omitempty"`
+}
+
+// Configures the desired resource consumption of pods.
+type RuntimeComponentAutoScaling struct {
+	// Required field for autoscaling. Upper limit for the number of pods that can be set by the autoscaler. Parameter .spec.resources.requests.cpu must also be specified.
+	// +kubebuilder:validation:Minimum=1
+	// +operator-sdk:csv:customresourcedefinitions:order=1,type=spec,displayName="Max Replicas",xDescriptors="urn:alm:descriptor:com.tectonic.ui:number"
+	MaxReplicas int32 `json:"maxReplicas,omitempty"`
+
+	// Lower limit for the number of pods that can be set by the autoscaler.
+	// +operator-sdk:csv:customresourcedefinitions:order=2,type=spec,displayName="Min Replicas",xDescriptors="urn:alm:descriptor:com.tectonic.ui:number"
+	MinReplicas *int32 `json:"minReplicas,omitempty"`
+
+	// Target average CPU utilization, represented as a percentage of requested CPU, over all the pods.
+	// +operator-sdk:csv:customresourcedefinitions:order=3,type=spec,displayName="Target CPU Utilization Percentage",xDescriptors="urn:alm:descriptor:com.tectonic.ui:number"
+	TargetCPUUtilizationPercentage *int32 `json:"targetCPUUtilizationPercentage,omitempty"`
+
+	// Metrics used to calculate the desired number of pods, such as memory utilization, request rate from a custom metrics API, or
+	// metrics of other objects or outside of the cluster. Added to targetCPUUtilizationPercentage when both are specified. Requires autoscaling/v2.
+	// +listType=atomic
+	// +operator-sdk:csv:customresourcedefinitions:order=4,type=spec,displayName="Metrics"
+	Metrics []autoscalingv2beta2.MetricSpec `json:"metrics,omitempty"`
+
+	// Scaling policies for scaling up and scaling down, such as the stabilization window and the number of pods added or removed over a period. Requires autoscaling/v2.
+	// +operator-sdk:csv:customresourcedefinitions:order=5,type=spec,displayName="Behavior"
+	Behavior *autoscalingv2beta2.HorizontalPodAutoscalerBehavior `json:"behavior,omitempty"`
+}
+
+// Configures the event-driven autoscaling of pods, which requires KEDA.
+type RuntimeComponentEventDrivenAutoScaling struct {
+	// Lower limit for the number of pods. Set to 0 to scale the application to zero when no trigger is active. Defaults to 0.
+	// +kubebuilder:validation:Minimum=0
+	// +operator-sdk:csv:customresourcedefinitions:order=1,type=spec,displayName="Min Replicas",xDescriptors="urn:alm:descriptor:com.tectonic.ui:number"
+	MinReplicas *int32 `json:"minReplicas,omitempty"`
+
+	// Upper limit for the number of pods. Defaults to 100.
+	// +kubebuilder:validation:Minimum=1
+	// +operator-sdk:csv:customresourcedefinitions:order=2,type=spec,displayName="Max Replicas",xDescriptors="urn:alm:descriptor:com.tectonic.ui:number"
+	MaxReplicas *int32 `json:"maxReplicas,omitempty"`
+
+	// Interval in seconds to check each trigger. Defaults to 30.
+	// +kubebuilder:validation:Minimum=1
+	// +operator-sdk:csv:customresourcedefinitions:order=3,type=spec,displayName="Polling Interval",xDescriptors="urn:alm:descriptor:com.tectonic.ui:number"
+	PollingInterval *int32 `json:"pollingInterval,omitempty"`
+
+	// Period in seconds to wait after the last trigger was active before scaling to minReplicas when it is 0. Defaults to 300.
+	// +kubebuilder:validation:Minimum=0
+	// +operator-sdk:csv:customresourcedefinitions:order=4,type=spec,displayName="Cooldown Period",xDescriptors="urn:alm:descriptor:com.tectonic.ui:number"
+	CooldownPeriod *int32 `json:"cooldownPeriod,omitempty"`
+
+	// Triggers that activate the scaling, such as the lag of a Kafka consumer group or the depth of a RabbitMQ queue.
+	// +kubebuilder:validation:MinItems=1
+	// +listType=atomic
+	// +operator-sdk:csv:customresourcedefinitions:order=5,type=spec,displayName="Triggers"
+	Triggers []RuntimeComponentScaleTrigger `json:"triggers"`
+}
+
+// Defines a KEDA trigger.
+type RuntimeComponentScaleTrigger struct {
+	// Type of the KEDA scaler, such as kafka or rabbitmq.
+	Type string `json:"type"`
+
+	// Name of the trigger.
+	Name string `json:"name,omitempty"`
+
+	// Configuration of the scaler, such as the topic and the lag threshold of a kafka trigger.
+	Metadata map[string]string `json:"metadata"`
+
+	// Name of the TriggerAuthentication in the namespace of the application, or of the ClusterTriggerAuthentication, holding the credentials of the scaler.
+	AuthenticationRef *RuntimeComponentScaleTriggerAuthenticationRef `json:"authenticationRef,omitempty"`
+}
+
+// References the credentials of a KEDA trigger.
+type RuntimeComponentScaleTriggerAuthenticationRef struct {
+	Name string `json:"name"`
+
+	// Kind of the authentication. Defaults to TriggerAuthentication.
+	// +kubebuilder:validation:Enum=TriggerAuthentication;ClusterTriggerAuthentication
+	Kind string `json:"kind,omitempty"`
+}
+
+// Configures the vertical autoscaling of the resources of the application container, which requires the VerticalPodAutoscaler.
+type RuntimeComponentVerticalAutoScaling struct {
+	// How the recommended resources are applied: Off only computes them, Initial sets them when pods are created, Recreate and Auto also evict pods to update them. Defaults to Auto.
+	// +kubebuilder:validation:Enum=Off;Initial;Recreate;Auto
+	// +operator-sdk:csv:customresourcedefinitions:order=1,type=spec,displayName="Update Mode"
+	UpdateMode *string `json:"updateMode,omitempty"`
+
+	// Lower limit of the recommended resources.
+	// +operator-sdk:csv:customresourcedefinitions:order=2,type=spec,displayName="Min Allowed"
+	MinAllowed corev1.ResourceList `json:"minAllowed,omitempty"`
+
+	// Upper limit of the recommended resources.
+	// +operator-sdk:csv:customresourcedefinitions:order=3,type=spec,displayName="Max Allowed"
+	MaxAllowed corev1.ResourceList `json:"maxAllowed,omitempty"`
+
+	// Resources to recommend, such as cpu and memory. Defaults to cpu and memory.
+	// +listType=set
+	// +operator-sdk:csv:customresourcedefinitions:order=4,type=spec,displayName="Controlled Resources"
+	ControlledResources []corev1.ResourceName `json:"controlledResources,omitempty"`
+
+	// Whether to update the requests only, or the requests and the limits in proportion. Defaults to RequestsAndLimits.
+	// +kubebuilder:validation:Enum=RequestsAndLimits;RequestsOnly
+	// +operator-sdk:csv:customresourcedefinitions:order=5,type=spec,displayName="Controlled Values"
+	ControlledValues *string `json:"controlledValues,omitempty"`
+}
+
+// Configures the PodDisruptionBudget that limits the number of pods taken down at once by voluntary disruptions, such as node drains.
+type RuntimeComponentDisruptionBudget struct {
+	// Create a PodDisruptionBudget. Defaults to true when more than one pod is requested by .spec.replicas or by the minimum replicas of the autoscaling, or when minAvailable or maxUnavailable is set.
+	// +operator-sdk:csv:customresourcedefinitions:order=1,type=spec,displayName="Enabled",xDescriptors="urn:alm:descriptor:com.tectonic.ui:booleanSwitch"
+	Enabled *bool `json:"enabled,omitempty"`
+
+	// Minimum number or percentage of pods that must stay available. Cannot be set together with maxUnavailable.
+	// +operator-sdk:csv:customresourcedefinitions:order=2,type=spec,displayName="Min Available"
+	MinAvailable *intstr.IntOrString `json:"minAvailable,omitempty"`
+
+	// Maximum number or percentage of pods that can be unavailable. Defaults to 1 when minAvailable is not set.
+	// +operator-sdk:csv:customresourcedefinitions:order=3,type=spec,displayName="Max Unavailable"
+	MaxUnavailable *intstr.IntOrString `json:"maxUnavailable,omitempty"`
+}
+
+// Configures the automatic rollback of failed rollouts.
+type RuntimeComponentAutoRollback struct {
+	// Number of seconds for the pods of a new application image to become ready before the image is rolled back. Defaults to 600.
+	// +kubebuilder:validation:Minimum=1
+	// +operator-sdk:csv:customresourcedefinitions:order=1,type=spec,displayName="Progress Deadline Seconds",xDescriptors="urn:alm:descriptor:com.tectonic.ui:number"
+	ProgressDeadlineSeconds *int32 `json:"progressDeadlineSeconds,omitempty"`
+
+	// Number of restarts of the application containers of a new image after which the image is rolled back, before the progress deadline.
+	// +kubebuilder:validation:Minimum=1
+	// +operator-sdk:csv:customresourcedefinitions:order=2,type=spec,displayName="Max Restarts",xDescriptors="urn:alm:descriptor:com.tectonic.ui:number"
+	MaxRestarts *int32 `json:"maxRestarts,omitempty"`
+}
+
+// Configures parameters for the network service of pods.
+type RuntimeComponentService struct {
+	// The port exposed by the container.
+	// +kubebuilder:validation:Maximum=65535
+	// +kubebuilder:validation:Minimum=1
+	// +operator-sdk:csv:customresourcedefinitions:order=9,type=spec,displayName="Service Port",xDescriptors="urn:alm:descriptor:com.tectonic.ui:number"
+	Port int32 `json:"port,omitempty"`
+
+	// +operator-sdk:csv:customresourcedefinitions:order=10,type=spec,displayName="Service Type",xDescriptors="urn:alm:descriptor:com.tectonic.ui:text"
+	Type *corev1.ServiceType `json:"type,omitempty"`
+
+	// Node proxies this port into your service.
+	// +kubebuilder:validation:Maximum=32767
+	// +kubebuilder:validation:Minimum=30000
+	// +operator-sdk:csv:customresourcedefinitions:order=11,type=spec,displayName="Node Port",xDescriptors="urn:alm:descriptor:com.tectonic.ui:number"
+	NodePort *int32 `json:"nodePort,omitempty"`
+
+	// The name for the port exposed by the container.
+	// +operator-sdk:csv:customresourcedefinitions:order=12,type=spec,displayName="Port Name",xDescriptors="urn:alm:descriptor:com.tectonic.ui:text"
+	PortName string `json:"portName,omitempty"`
+
+	// Annotations to be added to the service.
+	// +operator-sdk:csv:customresourcedefinitions:order=13,type=spec,displayName="Service Annotations",xDescriptors="urn:alm:descriptor:com.tectonic.ui:text"
+	Annotations map[string]string `json:"annotations,omitempty"`
+
+	// The port that the operator assigns to containers inside pods. Defaults to the value of spec.service.port.
+	// +kubebuilder:validation:Maximum=65535
+	// +kubebuilder:validation:Minimum=1
+	// +operator-sdk:csv:customresourcedefinitions:order=14,type=spec,displayName="Target Port",xDescriptors="urn:alm:descriptor:com.tectonic.ui:number"
+	TargetPort *int32 `json:"targetPort,omitempty"`
+
+	// A name of a secret that already contains TLS key, certificate and CA to be mounted in the pod. The following keys are valid in the secret: ca.crt, tls.crt, and tls.key.
+	// +k8s:openapi-gen=true
+	// +operator-sdk:csv:customresourcedefinitions:order=15,type=spec,displayName="Certificate Secret Reference",xDescriptors="urn:alm:descriptor:com.tectonic.ui:text"
+	CertificateSecretRef *string `json:"certificateSecretRef,omitempty"`
+
+	// An array consisting of service ports.
+	// +operator-sdk:csv:customresourcedefinitions:order=16,type=spec
+	Ports []corev1.ServicePort `json:"ports,omitempty"`
+
+	// Expose the application as a bindable service. Defaults to false.
+	// +operator-sdk:csv:customresourcedefinitions:order=17,type=spec,displayName="Bindable",xDescriptors="urn:alm:descriptor:com.tectonic.ui:booleanSwitch"
+	Bindable *bool `json:"bindable,omitempty"`
+
+	// Configures the service certificate that the operator issues with cert-manager in the <name>-svc-tls-cm secret.
+	// +operator-sdk:csv:customresourcedefinitions:order=18,type=spec,displayName="Certificate"
+	Certificate *RuntimeComponentServiceCertificate `json:"certificate,omitempty"`
+}
+
+// Configures the service certificate issued with cert-manager.
+type RuntimeComponentServiceCertificate struct {
+	// The cert-manager issuer that signs the certificate. Defaults to the issuer of the operator configuration, otherwise
+	// to the CA issuer that the operator creates in the namespace.
+	// +operator-sdk:csv:customresourcedefinitions:order=1,type=spec,displayName="Issuer"
+	IssuerRef *RuntimeComponentIssuerRef `json:"issuerRef,omitempty"`
+
+	// Additional DNS names of the certificate, added to the DNS names of the Service.
+	// +listType=set
+	// +operator-sdk:csv:customresourcedefinitions:order=2,type=spec,displayName="Additional DNS Names"
+	AdditionalDNSNames []string `json:"additionalDNSNames,omitempty"`
+
+	// +operator-sdk:csv:customresourcedefinitions:order=3,type=spec,displayName="Private Key"
+	PrivateKey *RuntimeComponentPrivateKey `json:"privateKey,omitempty"`
+
+	// How long before the expiry of the certificate cert-manager renews it. Defaults to the cert-manager default, which is a third of the duration.
+	// +operator-sdk:csv:customresourcedefinitions:order=4,type=spec,displayName="Renew Before",xDescriptors="urn:alm:descriptor:com.tectonic.ui:text"
+	RenewBefore *metav1.Duration `json:"renewBefore,omitempty"`
+}
+
+// Defines the private key of a certificate.
+type RuntimeComponentPrivateKey struct {
+	// Algorithm of the private key. Can be one of RSA, ECDSA and Ed25519. Defaults to RSA.
+	// +kubebuilder:validation:Enum=RSA;ECDSA;Ed25519
+	// +operator-sdk:csv:customresourcedefinitions:order=1,type=spec,displayName="Algorithm",xDescriptors="urn:alm:descriptor:com.tectonic.ui:select:RSA,urn:alm:descriptor:com.tectonic.ui:select:ECDSA,urn:alm:descriptor:com.tectonic.ui:select:Ed25519"
+	Algorithm string `json:"algorithm,omitempty"`
+
+	// Size of the private key in bits. Can be one of 2048, 4096 and 8192 for RSA, which defaults to 2048, and one of 256, 384 and 521 for ECDSA,
+	// which defaults to 256. Ignored for Ed25519.
+	// +operator-sdk:csv:customresourcedefinitions:order=2,type=spec,displayName="Size",xDescriptors="urn:alm:descriptor:com.tectonic.ui:number"
+	Size int `json:"size,omitempty"`
+}
+
+// Defines the network policy
+type RuntimeComponentNetworkPolicy struct {
+	// Disable the creation of the network policy. Defaults to false.
+	// +operator-sdk:csv:customresourcedefinitions:order=46,type=spec,displayName="Disable",xDescriptors="urn:alm:descriptor:com.tectonic.ui:booleanSwitch"
+	Disable *bool `json:"disable,omitempty"`
+
+	// Specify the labels of namespaces that incoming traffic is allowed from.
+	// +operator-sdk:csv:customresourcedefinitions:order=47,type=spec,displayName="Namespace Labels",xDescriptors="urn:alm:descriptor:com.tectonic.ui:text"
+	NamespaceLabels *map[string]string `json:"namespaceLabels,omitempty"`
+
+	// Specify the labels of pod(s) that incoming traffic is allowed from.
+	// +operator-sdk:csv:customresourcedefinitions:order=48,type=spec,displayName="From Labels",xDescriptors="urn:alm:descriptor:com.tectonic.ui:text"
+	FromLabels *map[string]string `json:"fromLabels,omitempty"`
+}
+
+// Defines the desired state and cycle of applications.
+type RuntimeComponentDeployment struct {
+
+	// Specifies the strategy to replace old deployment pods with new pods.
+	// +operator-sdk:csv:customresourcedefinitions:order=21,type=spec,displayName="Deployment Update Strategy",xDescriptors="urn:alm:descriptor:com.tectonic.ui:updateStrategy"
+	UpdateStrategy *appsv1.DeploymentStrategy `json:"updateStrategy,omitempty"`
+
+	// Annotations to be added only to the Deployment and resources owned by the Deployment.
+	Annotations map[string]string `json:"annotations,omitempty"`
+
+	// Roll out a new application image to a canary Deployment first, which shares the Service with the Deployment
+	// of the current image, and promote it after the steps of the rollout.
+	// +operator-sdk:csv:customresourcedefinitions:order=22,type=spec,displayName="Canary"
+	Canary *RuntimeComponentCanary `json:"canary,omitempty"`
+
+	// Strategy to roll out changes of the pods. Rolling replaces the pods of the Deployment according to the update strategy.
+	// BlueGreen keeps a blue and a green Deployment, and switches the traffic to the Deployment of the new pods once they are ready. Defaults to Rolling.
+	// +kubebuilder:validation:Enum=Rolling;BlueGreen
+	// +operator-sdk:csv:customresourcedefinitions:order=23,type=spec,displayName="Deployment Strategy",xDescriptors="urn:alm:descriptor:com.tectonic.ui:select:Rolling,urn:alm:descriptor:com.tectonic.ui:select:BlueGreen"
+	Strategy string `json:"strategy,omitempty"`
+
+	// Configures the BlueGreen strategy.
+	// +operator-sdk:csv:customresourcedefinitions:order=24,type=spec,displayName="Blue/Green"
+	BlueGreen *RuntimeComponentBlueGreen `json:"blueGreen,omitempty"`
+}
+
+// Configures blue/green rollouts.
+type RuntimeComponentBlueGreen struct {
+	// Switch the traffic to the new pods as soon as they are ready. When false, the new pods are promoted once the
+	// rc.app.stacks/promote annotation of the RuntimeComponent is set to the preview revision reported in the status. Defaults to true.
+	// +operator-sdk:csv:customresourcedefinitions:order=1,type=spec,displayName="Auto Promote",xDescriptors="urn:alm:descriptor:com.tectonic.ui:booleanSwitch"
+	AutoPromote *bool `json:"autoPromote,omitempty"`
+
+	// Number of seconds the pods of the previous revision are kept after a promotion, during which the
+	// rc.app.stacks/rollback annotation switches the traffic back to them. Defaults to 600.
+	// +kubebuilder:validation:Minimum=0
+	// +operator-sdk:csv:customresourcedefinitions:order=2,type=spec,displayName="Rollback Window Seconds",xDescriptors="urn:alm:descriptor:com.tectonic.ui:number"
+	RollbackWindowSeconds *int32 `json:"rollbackWindowSeconds,omitempty"`
+}
+
+// Configures the canary rollout of new application images.
+type RuntimeComponentCanary struct {
+	// Steps of the rollout. The canary pods receive the traffic weight of each step for its pause duration, then the next step starts.
+	// The new image is promoted after the last step.
+	// +kubebuilder:validation:MinItems=1
+	// +listType=atomic
+	// +operator-sdk:csv:customresourcedefinitions:order=1,type=spec,displayName="Steps"
+	Steps []RuntimeComponentCanaryStep `json:"steps"`
+
+	// Number of seconds for the canary pods to become ready, and for the analysis to succeed, at each step before the rollout is rolled back. Defaults to 600.
+	// +kubebuilder:validation:Minimum=1
+	// +operator-sdk:csv:customresourcedefinitions:order=2,type=spec,displayName="Progress Deadline Seconds",xDescriptors="urn:alm:descriptor:com.tectonic.ui:number"
+	ProgressDeadlineSeconds *int32 `json:"progressDeadlineSeconds,omitempty"`
+
+	// Prometheus query that must succeed at the end of each step.
+	// +operator-sdk:csv:customresourcedefinitions:order=3,type=spec,displayName="Analysis"
+	Analysis *RuntimeComponentCanaryAnalysis `json:"analysis,omitempty"`
+}
+
+// Defines a step of a canary rollout.
+type RuntimeComponentCanaryStep struct {
+	// Percentage of the traffic sent to the canary pods, set by the ratio of canary pods to the pods of the current image.
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=99
+	Weight int32 `json:"weight"`
+
+	// Duration of the step, such as 5m. The next step starts once the duration has elapsed and the canary pods are ready. Defaults to 0.
+	Pause *metav1.Duration `json:"pause,omitempty"`
+}
+
+// Defines the Prometheus query that analyzes a canary rollout.
+type RuntimeComponentCanaryAnalysis struct {
+	// URL of the Prometheus server, such as http://prometheus-operated.monitoring.svc:9090.
+	PrometheusURL string `json:"prometheusURL"`
+
+	// PromQL query that returns a single value, such as the rate of errors of the canary pods.
+	Query string `json:"query"`
+
+	// The analysis fails when the value returned by the query is greater than this threshold.
+	// +kubebuilder:validation:Pattern=`^-?[0-9]+(\.[0-9]+)?$`
+	MaxValue string `json:"maxValue"`
+}
+
+// Defines the desired state and cycle of stateful applications.
+type RuntimeComponentStatefulSet struct {
+
+	// Specifies the strategy to replace old statefulSet pods with new pods.
+	// +operator-sdk:csv:customresourcedefinitions:order=23,type=spec,displayName="StatefulSet Update Strategy",xDescriptors="urn:alm:descriptor:com.tectonic.ui:text"
+	UpdateStrategy *appsv1.StatefulSetUpdateStrategy `json:"updateStrategy,omitempty"`
+
+	// +operator-sdk:csv:customresourcedefinitions:order=24,type=spec,displayName="Storage"
+	Storage *RuntimeComponentStorage `json:"storage,omitempty"`
+
+	// Annotations to be added only to the StatefulSet and resources owned by the StatefulSet.
+	Annotations map[string]string `json:"annotations,omitempty"`
+}
+
+// Defines settings of persisted storage for StatefulSets.
+type RuntimeComponentStorage struct {
+
+	// A convenient field to set the size of the persisted storage.
+	// +kubebuilder:validation:Pattern=^([+-]?[0-9.]+)([eEinumkKMGTP]*[-+]?[0-9]*)$
+	// +operator-sdk:csv:customresourcedefinitions:order=25,type=spec,displayName="Storage Size",xDescriptors="urn:alm:descriptor:com.tectonic.ui:text"
+	Size string `json:"size,omitempty"`
+
+	// A convenient field to request the storage class of the persisted storage. The name can not be specified or updated after the storage is created.
+	// +kubebuilder:validation:Pattern=.+
+	// +operator-sdk:csv:customresourcedefinitions:order=26,type=spec,displayName="Storage Class Name",xDescriptors="urn:alm:descriptor:com.tectonic.ui:text"
+	ClassName string `json:"className,omitempty"`
+
+	// The directory inside the container where this persisted storage will be bound to.
+	// +operator-sdk:csv:customresourcedefinitions:order=27,type=spec,displayName="Storage Mount Path",xDescriptors="urn:alm:descriptor:com.tectonic.ui:text"
+	MountPath string `json:"mountPath,omitempty"`
+
+	// A YAML object that represents a volumeClaimTemplate component of a StatefulSet.
+	// +operator-sdk:csv:customresourcedefinitions:order=28,type=spec,displayName="Storage Volume Claim Template",xDescriptors="urn:alm:descriptor:com.tectonic.ui:PersistentVolumeClaim"
+	VolumeClaimTemplate *corev1.PersistentVolumeClaim `json:"volumeClaimTemplate,omitempty"`
+}
+
+// Specifies parameters for Service Monitor.
+type RuntimeComponentMonitoring struct {
+
+	// Labels to set on ServiceMonitor.
+	// +operator-sdk:csv:customresourcedefinitions:order=30,type=spec,displayName="Monitoring Labels",xDescriptors="urn:alm:descriptor:com.tectonic.ui:text"
+	Labels map[string]string `json:"labels,omitempty"`
+
+	// A YAML snippet representing an array of Endpoint component from ServiceMonitor.
+	// +listType=atomic
+	// +operator-sdk:csv:customresourcedefinitions:order=31,type=spec,displayName="Monitoring Endpoints",xDescriptors="urn:alm:descriptor:com.tectonic.ui:endpointList"
+	Endpoints []prometheusv1.Endpoint `json:"endpoints,omitempty"`
+}
+
+// Configures the ingress resource.
+// +k8s:openapi-gen=true
+type RuntimeComponentRoute struct {
+
+	// Annotations to be added to the Route.
+	// +operator-sdk:csv:customresourcedefinitions:order=38,type=spec,displayName="Route Annotations",xDescriptors="urn:alm:descriptor:com.tectonic.ui:text"
+	Annotations map[string]string `json:"annotations,omitempty"`
+
+	// Hostname to be used for the Route.
+	// +operator-sdk:csv:customresourcedefinitions:order=39,type=spec,displayName="Route Host",xDescriptors="urn:alm:descriptor:com.tectonic.ui:text"
+	Host string `json:"host,omitempty"`
+
+	// Path to be used for Route.
+	// +operator-sdk:csv:customresourcedefinitions:order=40,type=spec,displayName="Route Path",xDescriptors="urn:alm:descriptor:com.tectonic.ui:text"
+	Path string `json:"path,omitempty"`
+
+	// Path type to be used for Ingress.
+	PathType networkingv1.PathType `json:"pathType,omitempty"`
+
+	// A name of a secret that already contains TLS key, certificate and CA to be used in the route. It can also contain destination CA certificate. The following keys are valid in the secret: ca.crt, destCA.crt, tls.crt, and tls.key.
+	// +operator-sdk:csv:customresourcedefinitions:order=41,type=spec,displayName="Certificate Secret Reference",xDescriptors="urn:alm:descriptor:com.tectonic.ui:text"
+	CertificateSecretRef *string `json:"certificateSecretRef,omitempty"`
+
+	// TLS termination policy. Can be one of edge, reencrypt and passthrough.
+	// +operator-sdk:csv:customresourcedefinitions:order=42,type=spec,displayName="Termination",xDescriptors="urn:alm:descriptor:com.tectonic.ui:text"
+	Termination *routev1.TLSTerminationType `json:"termination,omitempty"`
+
+	// HTTP traffic policy with TLS enabled. Can be one of Allow, Redirect and None.
+	// +operator-sdk:csv:customresourcedefinitions:order=43,type=spec,displayName="Insecure Edge Termination Policy",xDescriptors="urn:alm:descriptor:com.tectonic.ui:text"
+	InsecureEdgeTerminationPolicy *routev1.InsecureEdgeTerminationPolicyType `json:"insecureEdgeTerminationPolicy,omitempty"`
+
+	// Name of the IngressClass of the Ingress. Defaults to the default IngressClass of the cluster.
+	// +operator-sdk:csv:customresourcedefinitions:order=44,type=spec,displayName="Ingress Class Name",xDescriptors="urn:alm:descriptor:com.tectonic.ui:text"
+	IngressClassName *string `json:"ingressClassName,omitempty"`
+
+	// Additional hostnames of the Ingress, which serve the same paths as the host.
+	// +listType=set
+	// +operator-sdk:csv:customresourcedefinitions:order=45,type=spec,displayName="Additional Hosts"
+	AdditionalHosts []string `json:"additionalHosts,omitempty"`
+
+	// Additional paths of the Ingress, which are served on every host.
+	// +listType=atomic
+	// +operator-sdk:csv:customresourcedefinitions:order=46,type=spec,displayName="Additional Paths"
+	AdditionalPaths []RuntimeComponentIngressPath `json:"additionalPaths,omitempty"`
+
+	// Request a certificate for the hosts of the Ingress from a cert-manager Issuer or ClusterIssuer, such as an ACME issuer.
+	// The certificate is stored in the <name>-ingress-tls secret, which is set in the TLS configuration of the Ingress. Cannot be set together with certificateSecretRef.
+	// +operator-sdk:csv:customresourcedefinitions:order=47,type=spec,displayName="Certificate Issuer"
+	CertificateIssuerRef *RuntimeComponentIssuerRef `json:"certificateIssuerRef,omitempty"`
+}
+
+// Defines an additional path of the Ingress.
+type RuntimeComponentIngressPath struct {
+	// Path of the requests sent to the application.
+	Path string `json:"path"`
+
+	// Path type of the path. Defaults to ImplementationSpecific.
+	PathType networkingv1.PathType `json:"pathType,omitempty"`
+}
+
+// References a cert-manager issuer.
+type RuntimeComponentIssuerRef struct {
+	// Name of the issuer.
+	Name string `json:"name"`
+
+	// Kind of the issuer. Can be one of Issuer and ClusterIssuer. Defaults to ClusterIssuer.
+	// +kubebuilder:validation:Enum=Issuer;ClusterIssuer
+	Kind string `json:"kind,omitempty"`
+}
+
+// Configures the Gateway API HTTPRoute of the application.
+type RuntimeComponentGateway struct {
+	// Gateways that the HTTPRoute attaches to. TLS is terminated by the HTTPS listeners of the Gateways.
+	// +kubebuilder:validation:MinItems=1
+	// +listType=atomic
+	// +operator-sdk:csv:customresourcedefinitions:order=1,type=spec,displayName="Parent Gateways"
+	ParentRefs []RuntimeComponentGatewayParentRef `json:"parentRefs"`
+
+	// Hostnames that match the requests sent to the application. Defaults to <name>-<namespace>.<defaultHostName> when the default hostname is set in the operator configuration, otherwise to the hostnames of the listeners of the Gateways.
+	// +listType=atomic
+	// +operator-sdk:csv:customresourcedefinitions:order=2,type=spec,displayName="Hostnames"
+	Hostnames []string `json:"hostnames,omitempty"`
+
+	// Path that matches the requests sent to the application. Defaults to /.
+	// +operator-sdk:csv:customresourcedefinitions:order=3,type=spec,displayName="Path",xDescriptors="urn:alm:descriptor:com.tectonic.ui:text"
+	Path string `json:"path,omitempty"`
+
+	// How the path is matched. Can be one of PathPrefix, Exact and RegularExpression. Defaults to PathPrefix.
+	// +kubebuilder:validation:Enum=PathPrefix;Exact;RegularExpression
+	// +operator-sdk:csv:customresourcedefinitions:order=4,type=spec,displayName="Path Match Type",xDescriptors="urn:alm:descriptor:com.tectonic.ui:select:PathPrefix,urn:alm:descriptor:com.tectonic.ui:select:Exact,urn:alm:descriptor:com.tectonic.ui:select:RegularExpression"
+	PathMatchType string `json:"pathMatchType,omitempty"`
+
+	// Annotations to be added to the HTTPRoute.
+	// +operator-sdk:csv:customresourcedefinitions:order=5,type=spec,displayName="Annotations",xDescriptors="urn:alm:descriptor:com.tectonic.ui:text"
+	Annotations map[string]string `json:"annotations,omitempty"`
+}
+
+// Defines a Gateway that the HTTPRoute attaches to.
+type RuntimeComponentGatewayParentRef struct {
+	// Name of the Gateway.
+	Name string `json:"name"`
+
+	// Namespace of the Gateway. Defaults to the namespace of the application.
+	Namespace string `json:"namespace,omitempty"`
+
+	// Name of the listener of the Gateway. Defaults to every listener that accepts the HTTPRoute.
+	SectionName string `json:"sectionName,omitempty"`
+}
+
+// Configures the Knative service of the application.
+type RuntimeComponentKnative struct {
+	// Lower limit for the number of pods of a revision. Set to 0 to scale to zero. Defaults to .spec.autoscaling.minReplicas.
+	// +kubebuilder:validation:Minimum=0
+	// +operator-sdk:csv:customresourcedefinitions:order=1,type=spec,displayName="Min Scale",xDescriptors="urn:alm:descriptor:com.tectonic.ui:number"
+	MinScale *int32 `json:"minScale,omitempty"`
+
+	// Upper limit for the number of pods of a revision. Defaults to .spec.autoscaling.maxReplicas.
+	// +kubebuilder:validation:Minimum=0
+	// +operator-sdk:csv:customresourcedefinitions:order=2,type=spec,displayName="Max Scale",xDescriptors="urn:alm:descriptor:com.tectonic.ui:number"
+	MaxScale *int32 `json:"maxScale,omitempty"`
+
+	// Maximum number of requests that a pod handles at the same time. 0 allows an unlimited number of requests.
+	// +kubebuilder:validation:Minimum=0
+	// +operator-sdk:csv:customresourcedefinitions:order=3,type=spec,displayName="Container Concurrency",xDescriptors="urn:alm:descriptor:com.tectonic.ui:number"
+	ContainerConcurrency *int64 `json:"containerConcurrency,omitempty"`
+
+	// Percentage of the concurrency target that the autoscaler tries to reach before adding pods.
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=100
+	// +operator-sdk:csv:customresourcedefinitions:order=4,type=spec,displayName="Target Utilization Percentage",xDescriptors="urn:alm:descriptor:com.tectonic.ui:number"
+	TargetUtilizationPercentage *int32 `json:"targetUtilizationPercentage,omitempty"`
+
+	// Minimum time that the last pod is kept after the traffic stops, before the revision scales to zero.
+	// +operator-sdk:csv:customresourcedefinitions:order=5,type=spec,displayName="Scale To Zero Pod Retention Period",xDescriptors="urn:alm:descriptor:com.tectonic.ui:text"
+	ScaleToZeroPodRetentionPeriod *metav1.Duration `json:"scaleToZeroPodRetentionPeriod,omitempty"`
+
+	// Resource requests and limits for the application container of the Knative service. Defaults to .spec.resources.
+	// +operator-sdk:csv:customresourcedefinitions:order=6,type=spec,displayName="Resource Requirements",xDescriptors="urn:alm:descriptor:com.tectonic.ui:resourceRequirements"
+	Resources *corev1.ResourceRequirements `json:"resources,omitempty"`
+
+	// Name of the revision created for the current configuration, which is prefixed with the name of the application.
+	// Must be changed whenever the configuration changes. Defaults to a name generated by Knative.
+	// +kubebuilder:validation:Pattern=`^[a-z0-9]([-a-z0-9]*[a-z0-9])?$`
+	// +operator-sdk:csv:customresourcedefinitions:order=7,type=spec,displayName="Revision Name",xDescriptors="urn:alm:descriptor:com.tectonic.ui:text"
+	RevisionName string `json:"revisionName,omitempty"`
+
+	// Split of the traffic between the revisions. The percentages must add up to 100. Defaults to all the traffic to the latest ready revision.
+	// +listType=atomic
+	// +operator-sdk:csv:customresourcedefinitions:order=8,type=spec,displayName="Traffic"
+	Traffic []RuntimeComponentKnativeTraffic `json:"traffic,omitempty"`
+}
+
+// Defines the traffic of a revision of the Knative service.
+type RuntimeComponentKnativeTraffic struct {
+	// Name of the revision, without the prefix of the application name. Defaults to the latest ready revision.
+	RevisionName string `json:"revisionName,omitempty"`
+
+	// Percentage of the traffic sent to the revision.
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=100
+	Percent *int64 `json:"percent,omitempty"`
+
+	// Tag of the revision, which gets its own URL. The URLs of the tags are reported in .status.endpoints.
+	// +kubebuilder:validation:Pattern=`^[a-z0-9]([-a-z0-9]*[a-z0-9])?$`
+	Tag string `json:"tag,omitempty"`
+}
+
+// Defines the observed state of RuntimeComponent.
+type RuntimeComponentStatus struct {
+	// +listType=atomic
+
+	// +operator-sdk:csv:customresourcedefinitions:type=status,displayName="Status Conditions",xDescriptors="urn:alm:descriptor:io.kubernetes.conditions"
+	Conditions     []StatusCondition `json:"conditions,omitempty"`
+	Endpoints      []StatusEndpoint  `json:"endpoints,omitempty"`
+	ImageReference string            `json:"imageReference,omitempty"`
+
+	// +operator-sdk:csv:customresourcedefinitions:type=status,displayName="Service Binding"
+	Binding *corev1.LocalObjectReference `json:"binding,omitempty"`
+
+	References common.StatusReferences `json:"references,omitempty"`
+
+	// +operator-sdk:csv:customresourcedefinitions:type=status,displayName="Canary"
+	Canary *RuntimeComponentCanaryStatus `json:"canary,omitempty"`
+
+	// +operator-sdk:csv:customresourcedefinitions:type=status,displayName="Blue/Green"
+	BlueGreen *RuntimeComponentBlueGreenStatus `json:"blueGreen,omitempty"`
+
+	// +operator-sdk:csv:customresourcedefinitions:type=status,displayName="Rollout"
+	Rollout *RuntimeComponentRolloutStatus `json:"rollout,omitempty"`
+}
+
+// Reports the progress of a canary rollout.
+type RuntimeComponentCanaryStatus struct {
+	// Image rolled out to the canary pods.
+	Image string `json:"image,omitempty"`
+
+	// Index of the current step.
+	CurrentStep int32 `json:"currentStep,omitempty"`
+
+	// Time at which the current step started.
+	StepStartTime *metav1.Time `json:"stepStartTime,omitempty"`
+
+	// Image of the last rollout that was rolled back. It is not rolled out again until the application image changes.
+	RolledBackImage string `json:"rolledBackImage,omitempty"`
+}
+
+// Reports the Deployments of the BlueGreen strategy.
+type RuntimeComponentBlueGreenStatus struct {
+	// Color of the Deployment that receives the traffic of the Service, either blue or green.
+	ActiveColor string `json:"activeColor,omitempty"`
+
+	// Revision of the pods of the active Deployment.
+	ActiveRevision string `json:"activeRevision,omitempty"`
+
+	// Revision of the pods of the other Deployment, which receives the traffic of the preview Service until it is promoted.
+	PreviewRevision string `json:"previewRevision,omitempty"`
+
+	// Revision of the pods that were active before the last promotion, which are kept until the end of the rollback window.
+	PreviousRevision string `json:"previousRevision,omitempty"`
+
+	// Time at which the pods of the previous revision are deleted.
+	RollbackDeadline *metav1.Time `json:"rollbackDeadline,omitempty"`
+
+	// Revision that was rolled back. It is not previewed again until the pods change.
+	RolledBackRevision string `json:"rolledBackRevision,omitempty"`
+}
+
+// Reports the rollouts of the Deployment or StatefulSet.
+type RuntimeComponentRolloutStatus struct {
+	// Application image of the last pods that were all ready.
+	LastHealthyImage string `json:"lastHealthyImage,omitempty"`
+
+	// Hash of the pod template of the last pods that were all ready.
+	LastHealthyPodTemplateHash string `json:"lastHealthyPodTemplateHash,omitempty"`
+
+	// Time at which the last healthy pods were ready.
+	LastHealthyTime *metav1.Time `json:"lastHealthyTime,omitempty"`
+
+	// Hash of the pod template of the rollout in progress.
+	PodTemplateHash string `json:"podTemplateHash,omitempty"`
+
+	// Time at which the rollout in progress started.
+	StartTime *metav1.Time `json:"startTime,omitempty"`
+
+	// Application image that was rolled back. The last healthy image is kept until the application image changes.
+	RolledBackImage string `json:"rolledBackImage,omitempty"`
+}
+
+// Defines possible status conditions.
+type StatusCondition struct {
+	LastTransitionTime *metav1.Time           `json:"lastTransitionTime,omitempty"`
+	Reason             string                 `json:"reason,omitempty"`
+	Message            string                 `json:"message,omitempty"`
+	Status             corev1.ConditionStatus `json:"status,omitempty"`
+	Type               StatusConditionType    `json:"type,omitempty"`
+}
+
+// Defines the type of status condition.
+type StatusConditionType string
+
+// Reports endpoint information.
+type StatusEndpoint struct {
+	Name  string              `json:"name,omitempty"`
+	Scope StatusEndpointScope `json:"scope,omitempty"`
+	Type  string              `json:"type,omitempty"`
+	URI   string              `json:"uri,omitempty"`
+}
+
+// Defines the scope of endpoint information in status.
+type StatusEndpointScope string
+
+const (
+	// Status Condition Types
+	StatusConditionTypeReconciled     StatusConditionType = "Reconciled"
+	StatusConditionTypeResourcesReady StatusConditionType = "ResourcesReady"
+	StatusConditionTypeReady          StatusConditionType = "Ready"
+
+	StatusConditionTypeCanaryProgressing    StatusConditionType = "CanaryProgressing"
+	StatusConditionTypeBlueGreenProgressing StatusConditionType = "BlueGreenProgressing"
+	StatusConditionTypeRolledBack           StatusConditionType = "RolledBack"
+	StatusConditionTypeCertificatesReady    StatusConditionType = "CertificatesReady"
+
+	// Status Endpoint Scopes
+	StatusEndpointScopeExternal StatusEndpointScope = "External"
+	StatusEndpointScopeInternal StatusEndpointScope = "Internal"
+)
+
+// +kubebuilder:resource:path=runtimecomponents,scope=Namespaced,shortName=comp;comps
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:storageversion
+// +kubebuilder:printcolumn:name="Image",type="string",JSONPath=".spec.applicationImage",priority=0,description="Absolute name of the deployed image containing registry and tag"
+// +kubebuilder:printcolumn:name="Exposed",type="boolean",JSONPath=".spec.expose",priority=0,description="Specifies whether deployment is exposed externally via default Route"
+// +kubebuilder:printcolumn:name="Reconciled",type="string",JSONPath=".status.conditions[?(@.type=='Reconciled')].status",priority=0,description="Status of the reconcile condition"
+// +kubebuilder:printcolumn:name="ReconciledReason",type="string",JSONPath=".status.conditions[?(@.type=='Reconciled')].reason",priority=1,description="Reason for the failure of reconcile condition"
+// +kubebuilder:printcolumn:name="ReconciledMessage",type="string",JSONPath=".status.conditions[?(@.type=='Reconciled')].message",priority=1,description="Failure message from reconcile condition"
+// +kubebuilder:printcolumn:name="ResourcesReady",type="string",JSONPath=".status.conditions[?(@.type=='ResourcesReady')].status",priority=0,description="Status of the resource ready condition"
+// +kubebuilder:printcolumn:name="ResourcesReadyReason",type="string",JSONPath=".status.conditions[?(@.type=='ResourcesReady')].reason",priority=1,description="Reason for the failure of resource ready condition"
+// +kubebuilder:printcolumn:name="ResourcesReadyMessage",type="string",JSONPath=".status.conditions[?(@.type=='ResourcesReady')].message",priority=1,description="Failure message from resource ready condition"
+// +kubebuilder:printcolumn:name="Ready",type="string",JSONPath=".status.conditions[?(@.type=='Ready')].status",priority=0,description="Status of the component ready condition"
+// +kubebuilder:printcolumn:name="ReadyReason",type="string",JSONPath=".status.conditions[?(@.type=='Ready')].reason",priority=1,description="Reason for the failure of component ready condition"
+// +kubebuilder:printcolumn:name="ReadyMessage",type="string",JSONPath=".status.conditions[?(@.type=='Ready')].message",priority=1,description="Failure message from component ready condition"
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp",priority=0,description="Age of the resource"
+// +operator-sdk:csv:customresourcedefinitions:displayName="RuntimeComponent",resources={{Deployment,v1},{Service,v1},{StatefulSet,v1},{Route,v1},{HorizontalPodAutoscaler,v2},{ServiceAccount,v1},{Secret,v1},{NetworkPolicy,v1}}
+
+// Represents the deployment of a runtime component
+type RuntimeComponent struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   RuntimeComponentSpec   `json:"spec,omitempty"`
+	Status RuntimeComponentStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// RuntimeComponentList contains a list of RuntimeComponent.
+type RuntimeComponentList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []RuntimeComponent `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&RuntimeComponent{}, &RuntimeComponentList{})
+}
+
+// GetApplicationImage returns application image
+func (cr *RuntimeComponent) GetApplicationImage() string {
+	return cr.Spec.ApplicationImage
+}
+
+// GetPullPolicy returns image pull policy
+func (cr *RuntimeComponent) GetPullPolicy() *corev1.PullPolicy {
+	return cr.Spec.PullPolicy
+}
+
+// GetPullSecret returns secret name for docker registry credentials
+func (cr *RuntimeComponent) GetPullSecret() *string {
+	return cr.Spec.PullSecret
+}
+
+// GetServiceAccountName returns service account name
+func (cr *RuntimeComponent) GetServiceAccountName() *string {
+	return cr.Spec.ServiceAccountName
+}
+
+// GetReplicas returns number of replicas
+func (cr *RuntimeComponent) GetReplicas() *int32 {
+	return cr.Spec.Replicas
+}
+
+func (cr *RuntimeComponent) GetProbes() common.BaseComponentProbes {
+	if cr.Spec.Probes == nil {
+		return nil
+	}
+	return cr.Spec.Probes
+}
+
+// GetLivenessProbe returns liveness probe
+func (p *RuntimeComponentProbes) GetLivenessProbe() *corev1.Probe {
+	return p.Liveness
+}
+
+// GetReadinessProbe returns readiness probe
+func (p *RuntimeComponentProbes) GetReadinessProbe() *corev1.Probe {
+	return p.Readiness
+}
+
+// GetStartupProbe returns startup probe
+func (p *RuntimeComponentProbes) GetStartupProbe() *corev1.Probe {
+	return p.Startup
+}
+
+// GetDefaultLivenessProbe returns default values for liveness probe
+func (p *RuntimeComponentProbes) GetDefaultLivenessProbe(ba common.BaseComponent) *corev1.Probe {
+	return common.GetDefaultMicroProfileLivenessProbe(ba)
+}
+
+// GetDefaultReadinessProbe returns default values for readiness probe
+func (p *RuntimeComponentProbes) GetDefaultReadinessProbe(ba common.BaseComponent) *corev1.Probe {
+	return common.GetDefaultMicroProfileReadinessProbe(ba)
+}
+
+// GetDefaultStartupProbe returns default values for startup probe
+func (p *RuntimeComponentProbes) GetDefaultStartupProbe(ba common.BaseComponent) *corev1.Probe {
+	return common.GetDefaultMicroProfileStartupProbe(ba)
+}
+
+// GetVolumes returns volumes slice
+func (cr *RuntimeComponent) GetVolumes() []corev1.Volume {
+	return cr.Spec.Volumes
+}
+
+// GetVolumeMounts returns volume mounts slice
+func (cr *RuntimeComponent) GetVolumeMounts() []corev1.VolumeMount {
+	return cr.Spec.VolumeMounts
+}
+
+// GetResourceConstraints returns resource constraints
+func (cr *RuntimeComponent) GetResourceConstraints() *corev1.ResourceRequirements {
+	return cr.Spec.Resources
+}
+
+// GetExpose returns expose flag
+func (cr *RuntimeComponent) GetExpose() *bool {
+	return cr.Spec.Expose
+}
+
+// GetEnv returns slice of environment variables
+func (cr *RuntimeComponent) GetEnv() []corev1.EnvVar {
+	return cr.Spec.Env
+}
+
+// GetEnvFrom returns slice of environment variables from source
+func (cr *RuntimeComponent) GetEnvFrom() []corev1.EnvFromSource {
+	return cr.Spec.EnvFrom
+}
+
+// GetCreateKnativeService returns flag that toggles Knative service
+func (cr *RuntimeComponent) GetCreateKnativeService() *bool {
+	return cr.Spec.CreateKnativeService
+}
+
+// GetAutoscaling returns autoscaling settings
+func (cr *RuntimeComponent) GetAutoscaling() common.BaseComponentAutoscaling {
+	if cr.Spec.Autoscaling == nil {
+		return nil
+	}
+	return cr.Spec.Autoscaling
+}
+
+// GetEventDrivenAutoscaling returns event-driven autoscaling settings
+func (cr *RuntimeComponent) GetEventDrivenAutoscaling() common.BaseComponentEventDrivenAutoscaling {
+	if cr.Spec.EventDrivenAutoscaling == nil {
+		return nil
+	}
+	return cr.Spec.EventDrivenAutoscaling
+}
+
+// GetVerticalAutoscaling returns vertical autoscaling settings
+func (cr *RuntimeComponent) GetVerticalAutoscaling() common.BaseComponentVerticalAutoscaling {
+	if cr.Spec.VerticalAutoscaling == nil {
+		return nil
+	}
+	return cr.Spec.VerticalAutoscaling
+}
+
+// GetDisruptionBudget returns disruption budget settings
+func (cr *RuntimeComponent) GetDisruptionBudget() common.BaseComponentDisruptionBudget {
+	if cr.Spec.DisruptionBudget == nil {
+		return nil
+	}
+	return cr.Spec.DisruptionBudget
+}
+
+// GetTopologySpreadConstraints returns the topology spread constraints settings
+func (cr *RuntimeComponent) GetTopologySpreadConstraints() common.BaseComponentTopologySpreadConstraints {
+	if cr.Spec.TopologySpreadConstraints == nil {
+		return nil
+	}
+	return cr.Spec.TopologySpreadConstraints
+}
+
+// GetStorage returns storage settings
+func (ss *RuntimeComponentStatefulSet) GetStorage() common.BaseComponentStorage {
+	if ss.Storage == nil {
+		return nil
+	}
+	return ss.Storage
+}
+
+// GetService returns service settings
+func (cr *RuntimeComponent) GetService() common.BaseComponentService {
+	if cr.Spec.Service == nil {
+		return nil
+	}
+	return cr.Spec.Service
+}
+
+func (cr *RuntimeComponent) GetNetworkPolicy() common.BaseComponentNetworkPolicy {
+	return cr.Spec.NetworkPolicy
+}
+
+// GetApplicationVersion returns application version
+func (cr *RuntimeComponent) GetApplicationVersion() string {
+	return cr.Spec.ApplicationVersion
+}
+
+// GetApplicationName returns Application name
+func (cr *RuntimeComponent) GetApplicationName() string {
+	return cr.Spec.ApplicationName
+}
+
+// GetMonitoring returns monitoring settings
+func (cr *RuntimeComponent) GetMonitoring() common.BaseComponentMonitoring {
+	if cr.Spec.Monitoring == nil {
+		return nil
+	}
+	return cr.Spec.Monitoring
+}
+
+// GetStatus returns RuntimeComponent status
+func (cr *RuntimeComponent) GetStatus() common.BaseComponentStatus {
+	return &cr.Status
+}
+
+// GetInitContainers returns list of init containers
+func (cr *RuntimeComponent) GetInitContainers() []corev1.Container {
+	return cr.Spec.InitContainers
+}
+
+// GetSidecarContainers returns list of sidecar containers
+func (cr *RuntimeComponent) GetSidecarContainers() []corev1.Container {
+	return cr.Spec.SidecarContainers
+}
+
+// GetGroupName returns group name to be used in labels and annotation
+func (cr *RuntimeComponent) GetGroupName() string {
+	return "rc.app.stacks"
+}
+
+// GetRoute returns route
+func (cr *RuntimeComponent) GetRoute() common.BaseComponentRoute {
+	if cr.Spec.Route == nil {
+		return nil
+	}
+	return cr.Spec.Route
+}
+
+// GetTrustBundle returns the trust bundle mounted in the application container
+func (cr *RuntimeComponent) GetTrustBundle() common.BaseComponentTrustBundle {
+	if cr.Spec.TrustBundle == nil {
+		return nil
+	}
+	return cr.Spec.TrustBundle
+}
+
+// GetMTLS returns the mutual TLS settings
+func (cr *RuntimeComponent) GetMTLS() common.BaseComponentMTLS {
+	if cr.Spec.MTLS == nil {
+		return nil
+	}
+	return cr.Spec.MTLS
+}
+
+// GetKnative returns the Knative service settings
+func (cr *RuntimeComponent) GetKnative() common.BaseComponentKnative {
+	if cr.Spec.Knative == nil {
+		return nil
+	}
+	return cr.Spec.Knative
+}
+
+// GetGateway returns the Gateway API HTTPRoute settings
+func (cr *RuntimeComponent) GetGateway() common.BaseComponentGateway {
+	if cr.Spec.Gateway == nil {
+		return nil
+	}
+	return cr.Spec.Gateway
+}
+
+// GetAffinity returns deployment's node and pod affinity settings
+func (cr *RuntimeComponent) GetAffinity() common.BaseComponentAffinity {
+	if cr.Spec.Affinity == nil {
+		return nil
+	}
+	return cr.Spec.Affinity
+}
+
+// GetAffinity returns deployment's node and pod affinity settings
+func (cr *RuntimeComponent) GetManageTLS() *bool {
+	return cr.Spec.ManageTLS
+}
+
+// GetDeployment returns deployment settings
+func (cr *RuntimeComponent) GetDeployment() common.BaseComponentDeployment {
+	if cr.Spec.Deployment == nil {
+		return nil
+	}
+	return cr.Spec.Deployment
+}
+
+// GetDeploymentStrategy returns deployment strategy struct
+func (cr *RuntimeComponentDeployment) GetDeploymentUpdateStrategy() *appsv1.DeploymentStrategy {
+	return cr.UpdateStrategy
+}
+
+// GetAnnotations returns annotations to be added only to the Deployment and its child resources
+func (rcd *RuntimeComponentDeployment) GetAnnotations() map[string]string {
+	return rcd.Annotations
+}
+
+// GetStatefulSet returns statefulSet settings
+func (cr *RuntimeComponent) GetStatefulSet() common.BaseComponentStatefulSet {
+	if cr.Spec.StatefulSet == nil {
+		return nil
+	}
+	return cr.Spec.StatefulSet
+}
+
+// GetStatefulSetUpdateStrategy returns statefulSet strategy struct
+func (cr *RuntimeComponentStatefulSet) GetStatefulSetUpdateStrategy() *appsv1.StatefulSetUpdateStrategy {
+	return cr.UpdateStrategy
+}
+
+// GetAnnotations returns annotations to be added only to the StatefulSet and its child resources
+func (rcss *RuntimeComponentStatefulSet) GetAnnotations() map[string]string {
+	return rcss.Annotations
+}
+
+// GetImageReference returns Docker image reference to be deployed by the CR
+func (s *RuntimeComponentStatus) GetImageReference() string {
+	return s.ImageReference
+}
+
+// SetImageReference sets Docker image reference on the status portion of the CR
+func (s *RuntimeComponentStatus) SetImageReference(imageReference string) {
+	s.ImageReference = imageReference
+}
+
+// GetBinding returns BindingStatus representing binding status
+func (s *RuntimeComponentStatus) GetBinding() *corev1.LocalObjectReference {
+	return s.Binding
+}
+
+// SetBinding sets BindingStatus representing binding status
+func (s *RuntimeComponentStatus) SetBinding(r *corev1.LocalObjectReference) {
+	s.Binding = r
+}
+
+// GetMinReplicas returns minimum replicas
+func (a *RuntimeComponentAutoScaling) GetMinReplicas() *int32 {
+	return a.MinReplicas
+}
+
+// GetMaxReplicas returns maximum replicas
+func (a *RuntimeComponentAutoScaling) GetMaxReplicas() int32 {
+	return a.MaxReplicas
+}
+
+// GetTargetCPUUtilizationPercentage returns target cpu usage
+func (a *RuntimeComponentAutoScaling) GetTargetCPUUtilizationPercentage() *int32 {
+	return a.TargetCPUUtilizationPercentage
+}
+
+// GetMinReplicas returns the lower limit for the number of pods
+func (a *RuntimeComponentEventDrivenAutoScaling) GetMinReplicas() *int32 {
+	return a.MinReplicas
+}
+
+// GetMaxReplicas returns the upper limit for the number of pods
+func (a *RuntimeComponentEventDrivenAutoScaling) GetMaxReplicas() *int32 {
+	return a.MaxReplicas
+}
+
+// GetPollingInterval returns the interval to check each trigger
+func (a *RuntimeComponentEventDrivenAutoScaling) GetPollingInterval() *int32 {
+	return a.PollingInterval
+}
+
+// GetCooldownPeriod returns the period to wait before scaling to zero
+func (a *RuntimeComponentEventDrivenAutoScaling) GetCooldownPeriod() *int32 {
+	return a.CooldownPeriod
+}
+
+// GetTriggers returns the triggers that activate the scaling
+func (a *RuntimeComponentEventDrivenAutoScaling) GetTriggers() []common.BaseComponentScaleTrigger {
+	triggers := make([]common.BaseComponentScaleTrigger, len(a.Triggers))
+	for i := range a.Triggers {
+		triggers[i] = &a.Triggers[i]
+	}
+	return triggers
+}
+
+// GetType returns the type of the KEDA scaler
+func (t *RuntimeComponentScaleTrigger) GetType() string {
+	return t.Type
+}
+
+// GetName returns the name of the trigger
+func (t *RuntimeComponentScaleTrigger) GetName() string {
+	return t.Name
+}
+
+// GetMetadata returns the configuration of the scaler
+func (t *RuntimeComponentScaleTrigger) GetMetadata() map[string]string {
+	return t.Metadata
+}
+
+// GetAuthenticationRef returns the name of the authentication of the scaler
+func (t *RuntimeComponentScaleTrigger) GetAuthenticationRef() string {
+	if t.AuthenticationRef == nil {
+		return ""
+	}
+	return t.AuthenticationRef.Name
+}
+
+// GetAuthenticationKind returns the kind of the authentication of the scaler
+func (t *RuntimeComponentScaleTrigger) GetAuthenticationKind() string {
+	if t.AuthenticationRef == nil || t.AuthenticationRef.Kind == "" {
+		return "TriggerAuthentication"
+	}
+	return t.AuthenticationRef.Kind
+}
+
+// GetUpdateMode returns how the recommended resources are applied
+func (a *RuntimeComponentVerticalAutoScaling) GetUpdateMode() *string {
+	return a.UpdateMode
+}
+
+// GetMinAllowed returns the lower limit of the recommended resources
+func (a *RuntimeComponentVerticalAutoScaling) GetMinAllowed() corev1.ResourceList {
+	return a.MinAllowed
+}
+
+// GetMaxAllowed returns the upper limit of the recommended resources
+func (a *RuntimeComponentVerticalAutoScaling) GetMaxAllowed() corev1.ResourceList {
+	return a.MaxAllowed
+}
+
+// GetControlledResources returns the resources to recommend
+func (a *RuntimeComponentVerticalAutoScaling) GetControlledResources() []corev1.ResourceName {
+	return a.ControlledResources
+}
+
+// GetControlledValues returns whether the limits are updated with the requests
+func (a *RuntimeComponentVerticalAutoScaling) GetControlledValues() *string {
+	return a.ControlledValues
+}
+
+// GetEnabled returns whether a PodDisruptionBudget is created
+func (d *RuntimeComponentDisruptionBudget) GetEnabled() *bool {
+	return d.Enabled
+}
+
+// GetMinAvailable returns the minimum number or percentage of available pods
+func (d *RuntimeComponentDisruptionBudget) GetMinAvailable() *intstr.IntOrString {
+	return d.MinAvailable
+}
+
+// GetMaxUnavailable returns the maximum number or percentage of unavailable pods
+func (d *RuntimeComponentDisruptionBudget) GetMaxUnavailable() *intstr.IntOrString {
+	return d.MaxUnavailable
+}
+
+// GetMetrics returns the metrics used to calculate the desired number of pods
+func (a *RuntimeComponentAutoScaling) GetMetrics() []autoscalingv2beta2.MetricSpec {
+	return a.Metrics
+}
+
+// GetBehavior returns the scaling policies
+func (a *RuntimeComponentAutoScaling) GetBehavior() *autoscalingv2beta2.HorizontalPodAutoscalerBehavior {
+	return a.Behavior
+}
+
+// GetSize returns persistent volume size
+func (s *RuntimeComponentStorage) GetSize() string {
+	return s.Size
+}
+
+// GetClassName returns persistent volume ClassName
+func (s *RuntimeComponentStorage) GetClassName() string {
+	return s.ClassName
+}
+
+// GetMountPath returns mount path for persistent volume
+func (s *RuntimeComponentStorage) GetMountPath() string {
+	return s.MountPath
+}
+
+// GetVolumeClaimTemplate returns a template representing requested persistent volume
+func (s *RuntimeComponentStorage) GetVolumeClaimTemplate() *corev1.PersistentVolumeClaim {
+	return s.VolumeClaimTemplate
+}
+
+// GetAnnotations returns a set of annotations to be added to the service
+func (s *RuntimeComponentService) GetAnnotations() map[string]string {
+	return s.Annotations
+}
+
+// GetPort returns service port
+func (s *RuntimeComponentService) GetPort() int32 {
+	return s.Port
+}
+
+// GetNodePort returns service nodePort
+func (s *RuntimeComponentService) GetNodePort() *int32 {
+	if s.NodePort == nil {
+		return nil
+	}
+	return s.NodePort
+}
+
+// GetTargetPort returns the internal target port for containers
+func (s *RuntimeComponentService) GetTargetPort() *int32 {
+	return s.TargetPort
+}
+
+// GetPortName returns name of service port
+func (s *RuntimeComponentService) GetPortName() string {
+	return s.PortName
+}
+
+// GetType returns service type
+func (s *RuntimeComponentService) GetType() *corev1.ServiceType {
+	return s.Type
+}
+
+// GetPorts returns a list of service ports
+func (s *RuntimeComponentService) GetPorts() []corev1.ServicePort {
+	return s.Ports
+}
+
+// GetCertificateSecretRef returns a secret reference with a certificate
+func (s *RuntimeComponentService) GetCertificateSecretRef() *string {
+	return s.CertificateSecretRef
+}
+
+// GetBindable returns whether the application should be exposable as a service
+func (s *RuntimeComponentService) GetBindable() *bool {
+	return s.Bindable
+}
+
+// GetCertificate returns the settings of the service certificate issued with cert-manager
+func (s *RuntimeComponentService) GetCertificate() common.BaseComponentServiceCertificate {
+	if s.Certificate == nil {
+		return nil
+	}
+	return s.Certificate
+}
+
+// GetIssuerName returns the name of the cert-manager issuer of the service certificate
+func (c *RuntimeComponentServiceCertificate) GetIssuerName() string {
+	if c.IssuerRef == nil {
+		return ""
+	}
+	return c.IssuerRef.Name
+}
+
+// GetIssuerKind returns the kind of the cert-manager issuer of the service certificate
+func (c *RuntimeComponentServiceCertificate) GetIssuerKind() string {
+	if c.IssuerRef == nil {
+		return ""
+	}
+	if c.IssuerRef.Kind == "" {
+		return "ClusterIssuer"
+	}
+	return c.IssuerRef.Kind
+}
+
+// GetAdditionalDNSNames returns the additional DNS names of the service certificate
+func (c *RuntimeComponentServiceCertificate) GetAdditionalDNSNames() []string {
+	return c.AdditionalDNSNames
+}
+
+// GetPrivateKeyAlgorithm returns the algorithm of the private key of the service certificate
+func (c *RuntimeComponentServiceCertificate) GetPrivateKeyAlgorithm() string {
+	if c.PrivateKey == nil {
+		return ""
+	}
+	return c.PrivateKey.Algorithm
+}
+
+// GetPrivateKeySize returns the size of the private key of the service certificate
+func (c *RuntimeComponentServiceCertificate) GetPrivateKeySize() int {
+	if c.PrivateKey == nil {
+		return 0
+	}
+	return c.PrivateKey.Size
+}
+
+// GetRenewBefore returns how long before its expiry the service certificate is renewed
+func (c *RuntimeComponentServiceCertificate) GetRenewBefore() *metav1.Duration {
+	return c.RenewBefore
+}
+
+func (np *RuntimeComponentNetworkPolicy) GetNamespaceLabels() map[string]string {
+	if np == nil || np.NamespaceLabels == nil {
+		return nil
+	}
+	return *np.NamespaceLabels
+}
+
+func (np *RuntimeComponentNetworkPolicy) GetFromLabels() map[string]string {
+	if np == nil || np.FromLabels == nil {
+		return nil
+	}
+	return *np.FromLabels
+}
+
+func (np *RuntimeComponentNetworkPolicy) IsDisabled() bool {
+	return np != nil && np.Disable != nil && *np.Disable
+}
+
+// GetLabels returns labels to be added on ServiceMonitor
+func (m *RuntimeComponentMonitoring) GetLabels() map[string]string {
+	return m.Labels
+}
+
+// GetEndpoints returns endpoints to be added to ServiceMonitor
+func (m *RuntimeComponentMonitoring) GetEndpoints() []prometheusv1.Endpoint {
+	return m.Endpoints
+}
+
+// GetAnnotations returns route annotations
+func (r *RuntimeComponentRoute) GetAnnotations() map[string]string {
+	return r.Annotations
+}
+
+// GetCertificateSecretRef returns a secret reference with a certificate
+func (r *RuntimeComponentRoute) GetCertificateSecretRef() *string {
+	return r.CertificateSecretRef
+}
+
+// GetTermination returns terminatation of the route's TLS
+func (r *RuntimeComponentRoute) GetTermination() *routev1.TLSTerminationType {
+	return r.Termination
+}
+
+// GetInsecureEdgeTerminationPolicy returns terminatation of the route's TLS
+func (r *RuntimeComponentRoute) GetInsecureEdgeTerminationPolicy() *routev1.InsecureEdgeTerminationPolicyType {
+	return r.InsecureEdgeTerminationPolicy
+}
+
+// GetHost returns hostname to be used by the route
+func (r *RuntimeComponentRoute) GetHost() string {
+	return r.Host
+}
+
+// GetPath returns path to use for the route
+func (r *RuntimeComponentRoute) GetPath() string {
+	return r.Path
+}
+
+// GetPathType returns pathType to use for the route
+func (r *RuntimeComponentRoute) GetPathType() networkingv1.PathType {
+	return r.PathType
+}
+
+// GetIngressClassName returns the IngressClass of the Ingress
+func (r *RuntimeComponentRoute) GetIngressClassName() *string {
+	return r.IngressClassName
+}
+
+// GetAdditionalHosts returns the additional hostnames of the Ingress
+func (r *RuntimeComponentRoute) GetAdditionalHosts() []string {
+	return r.AdditionalHosts
+}
+
+// GetAdditionalPaths returns the additional paths of the Ingress
+func (r *RuntimeComponentRoute) GetAdditionalPaths() []common.BaseComponentIngressPath {
+	paths := make([]common.BaseComponentIngressPath, len(r.AdditionalPaths))
+	for i := range r.AdditionalPaths {
+		paths[i] = &r.AdditionalPaths[i]
+	}
+	return paths
+}
+
+// GetCertificateIssuerName returns the name of the cert-manager issuer of the certificate of the Ingress
+func (r *RuntimeComponentRoute) GetCertificateIssuerName() string {
+	if r.CertificateIssuerRef == nil {
+		return ""
+	}
+	return r.CertificateIssuerRef.Name
+}
+
+// GetCertificateIssuerKind returns the kind of the cert-manager issuer of the certificate of the Ingress
+func (r *RuntimeComponentRoute) GetCertificateIssuerKind() string {
+	if r.CertificateIssuerRef == nil {
+		return ""
+	}
+	if r.CertificateIssuerRef.Kind == "" {
+		return "ClusterIssuer"
+	}
+	return r.CertificateIssuerRef.Kind
+}
+
+// GetPath returns the additional path of the Ingress
+func (p *RuntimeComponentIngressPath) GetPath() string {
+	return p.Path
+}
+
+// GetPathType returns the path type of the additional path of the Ingress
+func (p *RuntimeComponentIngressPath) GetPathType() networkingv1.PathType {
+	return p.PathType
+}
+
+// GetParentRefs returns the Gateways that the HTTPRoute attaches to
+func (g *RuntimeComponentGateway) GetParentRefs() []common.BaseComponentGatewayParentRef {
+	parentRefs := make([]common.BaseComponentGatewayParentRef, len(g.ParentRefs))
+	for i := range g.ParentRefs {
+		parentRefs[i] = &g.ParentRefs[i]
+	}
+	return parentRefs
+}
+
+// GetHostnames returns the hostnames of the HTTPRoute
+func (g *RuntimeComponentGateway) GetHostnames() []string {
+	return g.Hostnames
+}
+
+// GetPath returns the path of the HTTPRoute
+func (g *RuntimeComponentGateway) GetPath() string {
+	return g.Path
+}
+
+// GetPathMatchType returns how the path of the HTTPRoute is matched
+func (g *RuntimeComponentGateway) GetPathMatchType() string {
+	return g.PathMatchType
+}
+
+// GetAnnotations returns the annotations of the HTTPRoute
+func (g *RuntimeComponentGateway) GetAnnotations() map[string]string {
+	return g.Annotations
+}
+
+// GetConfigMapName returns the name of the ConfigMap of the trust bundle
+func (t *RuntimeComponentTrustBundle) GetConfigMapName() string {
+	if t.ConfigMapName == "" {
+		return "rco-trust-bundle"
+	}
+	return t.ConfigMapName
+}
+
+// GetKey returns the key of the PEM certificates in the ConfigMap of the trust bundle
+func (t *RuntimeComponentTrustBundle) GetKey() string {
+	if t.Key == "" {
+		return "ca-bundle.crt"
+	}
+	return t.Key
+}
+
+// GetAllowedCallers returns the components that may call the component
+func (m *RuntimeComponentMTLS) GetAllowedCallers() []common.BaseComponentMTLSCaller {
+	callers := make([]common.BaseComponentMTLSCaller, len(m.AllowedCallers))
+	for i := range m.AllowedCallers {
+		callers[i] = &m.AllowedCallers[i]
+	}
+	return callers
+}
+
+// GetName returns the name of the calling component
+func (c *RuntimeComponentMTLSCaller) GetName() string {
+	return c.Name
+}
+
+// GetNamespace returns the namespace of the calling component, which is empty when it is the namespace of the component
+func (c *RuntimeComponentMTLSCaller) GetNamespace() string {
+	return c.Namespace
+}
+
+// GetMinScale returns the lower limit for the number of pods of a revision
+func (k *RuntimeComponentKnative) GetMinScale() *int32 {
+	return k.MinScale
+}
+
+// GetMaxScale returns the upper limit for the number of pods of a revision
+func (k *RuntimeComponentKnative) GetMaxScale() *int32 {
+	return k.MaxScale
+}
+
+// GetContainerConcurrency returns the maximum number of requests that a pod handles at the same time
+func (k *RuntimeComponentKnative) GetContainerConcurrency() *int64 {
+	return k.ContainerConcurrency
+}
+
+// GetTargetUtilizationPercentage returns the percentage of the concurrency target to reach before adding pods
+func (k *RuntimeComponentKnative) GetTargetUtilizationPercentage() *int32 {
+	return k.TargetUtilizationPercentage
+}
+
+// GetScaleToZeroPodRetentionPeriod returns the time that the last pod is kept before scaling to zero
+func (k *RuntimeComponentKnative) GetScaleToZeroPodRetentionPeriod() *metav1.Duration {
+	return k.ScaleToZeroPodRetentionPeriod
+}
+
+// GetResources returns the resource requirements of the application container of the Knative service
+func (k *RuntimeComponentKnative) GetResources() *corev1.ResourceRequirements {
+	return k.Resources
+}
+
+// GetRevisionName returns the name of the revision created for the current configuration
+func (k *RuntimeComponentKnative) GetRevisionName() string {
+	return k.RevisionName
+}
+
+// GetTraffic returns the split of the traffic between the revisions
+func (k *RuntimeComponentKnative) GetTraffic() []common.BaseComponentKnativeTraffic {
+	traffic := make([]common.BaseComponentKnativeTraffic, len(k.Traffic))
+	for i := range k.Traffic {
+		traffic[i] = &k.Traffic[i]
+	}
+	return traffic
+}
+
+// GetRevisionName returns the name of the revision, without the prefix of the application name
+func (t *RuntimeComponentKnativeTraffic) GetRevisionName() string {
+	return t.RevisionName
+}
+
+// GetPercent returns the percentage of the traffic sent to the revision
+func (t *RuntimeComponentKnativeTraffic) GetPercent() *int64 {
+	return t.Percent
+}
+
+// GetTag returns the tag of the revision
+func (t *RuntimeComponentKnativeTraffic) GetTag() string {
+	return t.Tag
+}
+
+// GetName returns the name of the Gateway
+func (p *RuntimeComponentGatewayParentRef) GetName() string {
+	return p.Name
+}
+
+// GetNamespace returns the namespace of the Gateway
+func (p *RuntimeComponentGatewayParentRef) GetNamespace() string {
+	return p.Namespace
+}
+
+// GetSectionName returns the name of the listener of the Gateway
+func (p *RuntimeComponentGatewayParentRef) GetSectionName() string {
+	return p.SectionName
+}
+
+// GetConstraints returns the topology spread constraints
+func (c *RuntimeComponentTopologySpreadConstraints) GetConstraints() []corev1.TopologySpreadConstraint {
+	return c.Constraints
+}
+
+// GetDisableOperatorDefaults returns whether the default topology spread constraints are disabled
+func (c *RuntimeComponentTopologySpreadConstraints) GetDisableOperatorDefaults() *bool {
+	return c.DisableOperatorDefaults
+}
+
+// GetNodeAffinity returns node affinity
+func (a *RuntimeComponentAffinity) GetNodeAffinity() *corev1.NodeAffinity {
+	return a.NodeAffinity
+}
+
+// GetPodAffinity returns pod affinity
+func (a *RuntimeComponentAffinity) GetPodAffinity() *corev1.PodAffinity {
+	return a.PodAffinity
+}
+
+// GetPodAntiAffinity returns pod anti-affinity
+func (a *RuntimeComponentAffinity) GetPodAntiAffinity() *corev1.PodAntiAffinity {
+	return a.PodAntiAffinity
+}
+
+// GetArchitecture returns list of architecture names
+func (a *RuntimeComponentAffinity) GetArchitecture() []string {
+	return a.Architecture
+}
+
+// GetNodeAffinityLabels returns list of architecture names
+func (a *RuntimeComponentAffinity) GetNodeAffinityLabels() map[string]string {
+	return a.NodeAffinityLabels
+}
+
+// GetSecurityContext returns container security context
+func (cr *RuntimeComponent) GetSecurityContext() *corev1.SecurityContext {
+	return cr.Spec.SecurityContext
+}
+
+// Initialize the RuntimeComponent instance
+func (cr *RuntimeComponent) Initialize() {
+	if cr.Spec.PullPolicy == nil {
+		pp := corev1.PullIfNotPresent
+		cr.Spec.PullPolicy = &pp
+	}
+
+	if cr.Spec.Resources == nil {
+		cr.Spec.Resources = &corev1.ResourceRequirements{}
+	}
+
+	// Default applicationName to cr.Name, if a user sets createAppDefinition to true but doesn't set applicationName
+	if cr.Spec.ApplicationName == "" {
+		if cr.Labels != nil && cr.Labels["app.kubernetes.io/part-of"] != "" {
+			cr.Spec.ApplicationName = cr.Labels["app.kubernetes.io/part-of"]
+		} else {
+			cr.Spec.ApplicationName = cr.Name
+		}
+	}
+
+	if cr.Labels != nil {
+		cr.Labels["app.kubernetes.io/part-of"] = cr.Spec.ApplicationName
+	}
+
+	// This is to handle when there is no service in the CR
+	if cr.Spec.Service == nil {
+		cr.Spec.Service = &RuntimeComponentService{}
+	}
+
+	if cr.Spec.Service.Type == nil {
+		st := corev1.ServiceTypeClusterIP
+		cr.Spec.Service.Type = &st
+	}
+
+	if cr.Spec.Service.Port == 0 {
+		cr.Spec.Service.Port = 8080
+	}
+
+	// If TargetPorts on Serviceports are not set, default them to the Port value in the CR
+	numOfAdditionalPorts := len(cr.GetService().GetPorts())
+	for i := 0; i < numOfAdditionalPorts; i++ {
+		if cr.Spec.Service.Ports[i].TargetPort.String() == "0" {
+			cr.Spec.Service.Ports[i].TargetPort = intstr.FromInt(int(cr.Spec.Service.Ports[i].Port))
+		}
+	}
+}
+
+// GetLabels returns set of labels to be added to all resources
+func (cr *RuntimeComponent) GetLabels() map[string]string {
+	labels := map[string]string{
+		"app.kubernetes.io/instance":     cr.Name,
+		"app.kubernetes.io/name":         cr.Name,
+		"app.kubernetes.io/managed-by":   "runtime-component-operator",
+		"app.kubernetes.io/component":    "backend",
+		"app.kubernetes.io/part-of":      cr.Spec.ApplicationName,
+		common.GetComponentNameLabel(cr): cr.Name,
+	}
+
+	if cr.Spec.ApplicationVersion != "" {
+		labels["app.kubernetes.io/version"] = cr.Spec.ApplicationVersion
+	}
+
+	for key, value := range cr.Labels {
+		if key != "app.kubernetes.io/instance" {
+			labels[key] = value
+		}
+	}
+
+	return labels
+}
+
+// GetAnnotations returns set of annotations to be added to all resources
+func (cr *RuntimeComponent) GetAnnotations() map[string]string {
+	annotations := map[string]string{}
+	for k, v := range cr.Annotations {
+		annotations[k] = v
+	}
+	delete(annotations, "kubectl.kubernetes.io/last-applied-configuration")
+	return annotations
+}
+
+// GetType returns status condition type
+func (c *StatusCondition) GetType() common.StatusConditionType {
+	return convertToCommonStatusConditionType(c.Type)
+}
+
+// SetType returns status condition type
+func (c *StatusCondition) SetType(ct common.StatusConditionType) {
+	c.Type = convertFromCommonStatusConditionType(ct)
+}
+
+// GetLastTransitionTime returns time of last status change
+func (c *StatusCondition) GetLastTransitionTime() *metav1.Time {
+	return c.LastTransitionTime
+}
+
+// SetLastTransitionTime sets time of last status change
+func (c *StatusCondition) SetLastTransitionTime(t *metav1.Time) {
+	c.LastTransitionTime = t
+}
+
+// GetMessage returns condition's message
+func (c *StatusCondition) GetMessage() string {
+	return c.Message
+}
+
+// SetMessage sets condition's message
+func (c *StatusCondition) SetMessage(m string) {
+	c.Message = m
+}
+
+// GetReason returns condition's message
+func (c *StatusCondition) GetReason() string {
+	return c.Reason
+}
+
+// SetReason sets condition's reason
+func (c *StatusCondition) SetReason(r string) {
+	c.Reason = r
+}
+
+// GetStatus returns condition's status
+func (c *StatusCondition) GetStatus() corev1.ConditionStatus {
+	return c.Status
+}
+
+// SetStatus sets condition's status
+func (c *StatusCondition) SetStatus(s corev1.ConditionStatus) {
+	c.Status = s
+}
+
+// SetConditionFields sets status condition fields
+func (c *StatusCondition) SetConditionFields(message string, reason string, status corev1.ConditionStatus) common.StatusCondition {
+	c.Message = message
+	c.Reason = reason
+	c.Status = status
+	return c
+}
+
+// NewCondition returns new condition
+func (s *RuntimeComponentStatus) NewCondition(ct common.StatusConditionType) common.StatusCondition {
+	c := &StatusCondition{}
+	c.Type = convertFromCommonStatusConditionType(ct)
+	return c
+}
+
+// GetConditions returns slice of conditions
+func (s *RuntimeComponentStatus) GetConditions() []common.StatusCondition {
+	var conditions = make([]common.StatusCondition, len(s.Conditions))
+	for i := range s.Conditions {
+		conditions[i] = &s.Conditions[i]
+	}
+	return conditions
+}
+
+// GetCondition returns status condition with status condition type
+func (s *RuntimeComponentStatus) GetCondition(t common.StatusConditionType) common.StatusCondition {
+	for i := range s.Conditions {
+		if s.Conditions[i].GetType() == t {
+			return &s.Conditions[i]
+		}
+	}
+	return nil
+}
+
+// SetCondition sets status condition
+func (s *RuntimeComponentStatus) SetCondition(c common.StatusCondition) {
+	condition := &StatusCondition{}
+	found := false
+	for i := range s.Conditions {
+		if s.Conditions[i].GetType() == c.GetType() {
+			condition = &s.Conditions[i]
+			found = true
+			break
+		}
+	}
+
+	if condition.GetStatus() != c.GetStatus() || condition.GetMessage() != c.GetMessage() {
+		condition.SetLastTransitionTime(&metav1.Time{Time: time.Now()})
+	}
+
+	condition.SetReason(c.GetReason())
+	condition.SetMessage(c.GetMessage())
+	condition.SetStatus(c.GetStatus())
+	condition.SetType(c.GetType())
+	if !found {
+		s.Conditions = append(s.Conditions, *condition)
+	}
+}
+
+func (s *RuntimeComponentStatus) GetReferences() common.StatusReferences {
+	if s.References == nil {
+		s.References = make(common.StatusReferences)
+	}
+	return s.References
+}
+
+func (s *RuntimeComponentStatus) SetReferences(refs common.StatusReferences) {
+	s.References = refs
+}
+
+func (s *RuntimeComponentStatus) SetReference(name string, value string) {
+	if s.References == nil {
+		s.References = make(common.StatusReferences)
+	}
+	s.References[name] = value
+}
+
+func convertToCommonStatusConditionType(c StatusConditionType) common.StatusConditionType {
+	switch c {
+	case StatusConditionTypeReconciled:
+		return common.StatusConditionTypeReconciled
+	case StatusConditionTypeResourcesReady:
+		return common.StatusConditionTypeResourcesReady
+	case StatusConditionTypeReady:
+		return common.StatusConditionTypeReady
+	case StatusConditionTypeCanaryProgressing:
+		return common.StatusConditionTypeCanaryProgressing
+	case StatusConditionTypeBlueGreenProgressing:
+		return common.StatusConditionTypeBlueGreenProgressing
+	case StatusConditionTypeRolledBack:
+		return common.StatusConditionTypeRolledBack
+	case StatusConditionTypeCertificatesReady:
+		return common.StatusConditionTypeCertificatesReady
+	default:
+		panic(c)
+	}
+}
+
+func convertFromCommonStatusConditionType(c common.StatusConditionType) StatusConditionType {
+	switch c {
+	case common.StatusConditionTypeReconciled:
+		return StatusConditionTypeReconciled
+	case common.StatusConditionTypeResourcesReady:
+		return StatusConditionTypeResourcesReady
+	case common.StatusConditionTypeReady:
+		return StatusConditionTypeReady
+	case common.StatusConditionTypeCanaryProgressing:
+		return StatusConditionTypeCanaryProgressing
+	case common.StatusConditionTypeBlueGreenProgressing:
+		return StatusConditionTypeBlueGreenProgressing
+	case common.StatusConditionTypeRolledBack:
+		return StatusConditionTypeRolledBack
+	case common.StatusConditionTypeCertificatesReady:
+		return StatusConditionTypeCertificatesReady
+	default:
+		panic(c)
+	}
+}
+
+// GetEndpointName returns endpoint name in status
+func (e *StatusEndpoint) GetEndpointName() string {
+	return e.Name
+}
+
+// SetEndpointName sets endpoint name in status
+func (e *StatusEndpoint) SetEndpointName(n string) {
+	e.Name = n
+}
+
+// GetEndpointScope returns endpoint scope in status
+func (e *StatusEndpoint) GetEndpointScope() common.StatusEndpointScope {
+	return convertToCommonStatusEndpointScope(e.Scope)
+}
+
+// SetEndpointScope sets endpoint scope in status
+func (e *StatusEndpoint) SetEndpointScope(s common.StatusEndpointScope) {
+	e.Scope = convertFromCommonStatusEndpointScope(s)
+}
+
+// GetEndpointType returns endpoint type in status
+func (e *StatusEndpoint) GetEndpointType() string {
+	return e.Type
+}
+
+// SetEndpointType sets endpoint type in status
+func (e *StatusEndpoint) SetEndpointType(t string) {
+	e.Type = t
+}
+
+// GetEndpointUri returns endpoint uri in status
+func (e *StatusEndpoint) GetEndpointUri() string {
+	return e.URI
+}
+
+// SetEndpointUri sets endpoint uri in status
+func (e *StatusEndpoint) SetEndpointUri(u string) {
+	e.URI = u
+}
+
+// SetStatusEndpointFields sets endpoint information fields
+func (e *StatusEndpoint) SetStatusEndpointFields(eScope common.StatusEndpointScope, eType string, eUri string) common.StatusEndpoint {
+	e.Scope = convertFromCommonStatusEndpointScope(eScope)
+	e.Type = eType
+	e.URI = eUri
+	return e
+}
+
+// RemoveEndpoint removes endpoint in status
+func (s *RuntimeComponentStatus) RemoveStatusEndpoint(endpointName string) {
+	endpoints := s.Endpoints
+	for i, ep := range endpoints {
+		if ep.GetEndpointName() == endpointName {
+			s.Endpoints = append(endpoints[:i], endpoints[i+1:]...)
+			break
+		}
+	}
+}
+
+// NewStatusEndpoint returns new endpoint information
+func (s *RuntimeComponentStatus) NewStatusEndpoint(endpointName string) common.StatusEndpoint {
+	e := &StatusEndpoint{}
+	e.Name = endpointName
+	return e
+}
+
+// GetStatusEndpoint returns endpoint information with endpoint name
+func (s *RuntimeComponentStatus) GetStatusEndpoint(endpointName string) common.StatusEndpoint {
+	for i := range s.Endpoints {
+		if s.Endpoints[i].GetEndpointName() == endpointName {
+			return &s.Endpoints[i]
+		}
+	}
+	return nil
+}
+
+// SetStatusEndpoint sets endpoint in status
+func (s *RuntimeComponentStatus) SetStatusEndpoint(c common.StatusEndpoint) {
+	endpoint := &StatusEndpoint{}
+	found := false
+	for i := range s.Endpoints {
+		if s.Endpoints[i].GetEndpointName() == c.GetEndpointName() {
+			endpoint = &s.Endpoints[i]
+			found = true
+			break
+		}
+	}
+
+	endpoint.SetEndpointName(c.GetEndpointName())
+	endpoint.SetEndpointScope(c.GetEndpointScope())
+	endpoint.SetEndpointType(c.GetEndpointType())
+	endpoint.SetEndpointUri(c.GetEndpointUri())
+	if !found {
+		s.Endpoints = append(s.Endpoints, *endpoint)
+	}
+}
+
+func convertToCommonStatusEndpointScope(c StatusEndpointScope) common.StatusEndpointScope {
+	switch c {
+	case StatusEndpointScopeExternal:
+		return common.StatusEndpointScopeExternal
+	case StatusEndpointScopeInternal:
+		return common.StatusEndpointScopeInternal
+	default:
+		panic(c)
+	}
+}
+
+func convertFromCommonStatusEndpointScope(c common.StatusEndpointScope) StatusEndpointScope {
+	switch c {
+	case common.StatusEndpointScopeExternal:
+		return StatusEndpointScopeExternal
+	case common.StatusEndpointScopeInternal:
+		return StatusEndpointScopeInternal
+	default:
+		panic(c)
+	}
+}
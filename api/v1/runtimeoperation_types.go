@@ -0,0 +1,167 @@
+/*
+
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// EDIT THIS FILE!  THIS IS SCAFFOLDING FOR YOU TO OWN!
+// NOTE: json tags are required.  Any new fields you add must have json tags for the fields to be serialized.
+
+// Defines the desired state of RuntimeOperation
+type RuntimeOperationSpec struct {
+	// Name of the Pod to perform runtime operation on. Pod must be from the same namespace as the RuntimeOperation instance.
+	// Exactly one of podName, componentName and selector must be set.
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Pod Name",xDescriptors="urn:alm:descriptor:com.tectonic.ui:text"
+	PodName string `json:"podName,omitempty"`
+
+	// Name of the RuntimeComponent whose running pods to perform runtime operation on. The RuntimeComponent must be from the same namespace as the RuntimeOperation instance.
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Component Name",xDescriptors="urn:alm:descriptor:com.tectonic.ui:text"
+	ComponentName string `json:"componentName,omitempty"`
+
+	// Label selector of the running pods to perform runtime operation on, from the same namespace as the RuntimeOperation instance.
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Pod Selector"
+	Selector *metav1.LabelSelector `json:"selector,omitempty"`
+
+	// Maximum number of pods to run the command on at the same time. Defaults to 1.
+	// +kubebuilder:validation:Minimum=1
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Concurrency",xDescriptors="urn:alm:descriptor:com.tectonic.ui:number"
+	Concurrency *int32 `json:"concurrency,omitempty"`
+
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Container Name",xDescriptors="urn:alm:descriptor:com.tectonic.ui:text"
+	ContainerName string `json:"containerName,omitempty"`
+
+	// Command to execute. Not executed within a shell.
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Command",xDescriptors="urn:alm:descriptor:com.tectonic.ui:text"
+	Command []string `json:"command"`
+
+	// Maximum duration of the operation, including retries, for example 30s or 10m. When it is exceeded, the command is stopped and the operation fails with reason TimedOut.
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Timeout",xDescriptors="urn:alm:descriptor:com.tectonic.ui:text"
+	Timeout *metav1.Duration `json:"timeout,omitempty"`
+
+	// Number of times to retry the command on a pod where it cannot be run, such as when the connection to the pod fails. A command that exits with a non-zero code is not retried. Retries are delayed by an exponential backoff, starting at 10 seconds and capped at 5 minutes. Defaults to 0.
+	// +kubebuilder:validation:Minimum=0
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Retries",xDescriptors="urn:alm:descriptor:com.tectonic.ui:number"
+	Retries *int32 `json:"retries,omitempty"`
+
+	// Cancels the operation. The command is stopped on all pods and the operation fails with reason Cancelled. Defaults to false.
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Cancel",xDescriptors="urn:alm:descriptor:com.tectonic.ui:booleanSwitch"
+	Cancel *bool `json:"cancel,omitempty"`
+
+	// Stores the full output of the command in a ConfigMap or Secret owned by the RuntimeOperation.
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Output"
+	Output *RuntimeOperationOutput `json:"output,omitempty"`
+
+	// Deletes the RuntimeOperation the specified number of seconds after it completes. Applies to successful and failed operations,
+	// unless ttlSecondsAfterFailure is set. Defaults to the operationTTLSecondsAfterSuccess and operationTTLSecondsAfterFailure
+	// settings of the operator ConfigMap. The RuntimeOperation is kept if no TTL is set.
+	// +kubebuilder:validation:Minimum=0
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="TTL Seconds After Finished",xDescriptors="urn:alm:descriptor:com.tectonic.ui:number"
+	TTLSecondsAfterFinished *int32 `json:"ttlSecondsAfterFinished,omitempty"`
+
+	// Deletes the RuntimeOperation the specified number of seconds after it fails. Overrides ttlSecondsAfterFinished for failed operations.
+	// +kubebuilder:validation:Minimum=0
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="TTL Seconds After Failure",xDescriptors="urn:alm:descriptor:com.tectonic.ui:number"
+	TTLSecondsAfterFailure *int32 `json:"ttlSecondsAfterFailure,omitempty"`
+}
+
+// Configures where the full output of the command is stored.
+type RuntimeOperationOutput struct {
+	// Kind of the resource to store the output in. Can be one of ConfigMap and Secret. Defaults to ConfigMap.
+	// +kubebuilder:validation:Enum=ConfigMap;Secret
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Output Kind",xDescriptors="urn:alm:descriptor:com.tectonic.ui:select:ConfigMap,urn:alm:descriptor:com.tectonic.ui:select:Secret"
+	Kind string `json:"kind,omitempty"`
+}
+
+// Defines the observed state of RuntimeOperation.
+type RuntimeOperationStatus struct {
+	// +listType=atomic
+	Conditions []OperationStatusCondition `json:"conditions,omitempty"`
+
+	// Exit code of the command. Only set when the operation targets a single pod.
+	ExitCode *int32 `json:"exitCode,omitempty"`
+
+	// The last 4 KiB of the standard output of the command. Only set when the operation targets a single pod.
+	Stdout string `json:"stdout,omitempty"`
+
+	// The last 4 KiB of the standard error of the command. Only set when the operation targets a single pod.
+	Stderr string `json:"stderr,omitempty"`
+
+	// Results of the command on each of the targeted pods.
+	// +listType=map
+	// +listMapKey=podName
+	Pods []OperationPodStatus `json:"pods,omitempty"`
+
+	// Reference to the ConfigMap or Secret that holds the full output of the command.
+	OutputRef *corev1.TypedLocalObjectReference `json:"outputRef,omitempty"`
+
+	// The generation of the spec that was last observed by the operator.
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+}
+
+// Defines the result of the command on a pod.
+type OperationPodStatus struct {
+	// Name of the pod.
+	PodName string `json:"podName"`
+
+	// Whether the command completed successfully on the pod.
+	Completed corev1.ConditionStatus `json:"completed,omitempty"`
+
+	// Exit code of the command.
+	ExitCode *int32 `json:"exitCode,omitempty"`
+
+	// Number of times the command was run on the pod.
+	Attempts int32 `json:"attempts,omitempty"`
+
+	// The last 1 KiB of the standard output of the command.
+	Stdout string `json:"stdout,omitempty"`
+
+	// The last 1 KiB of the standard error of the command.
+	Stderr string `json:"stderr,omitempty"`
+
+	// Error encountered while running the command.
+	Message string `json:"message,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:storageversion
+//+operator-sdk:csv:customresourcedefinitions:displayName="RuntimeOperation"
+
+// Day-2 operation to execute on an instance of runtime component
+type RuntimeOperation struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   RuntimeOperationSpec   `json:"spec,omitempty"`
+	Status RuntimeOperationStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// RuntimeOperationList contains a list of RuntimeOperation.
+type RuntimeOperationList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []RuntimeOperation `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&RuntimeOperation{}, &RuntimeOperationList{})
+}
@@ -0,0 +1,160 @@
+/*
+
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// Defines the desired state of RuntimeOperationSchedule
+type RuntimeOperationScheduleSpec struct {
+	// The schedule in Cron format, for example "0 */6 * * *", evaluated in UTC. See https://en.wikipedia.org/wiki/Cron.
+	// +operator-sdk:csv:customresourcedefinitions:order=1,type=spec,displayName="Schedule",xDescriptors="urn:alm:descriptor:com.tectonic.ui:text"
+	Schedule string `json:"schedule"`
+
+	// Specifies how to treat a scheduled operation when a previous operation is still running. Can be one of Allow, Forbid and Replace. Defaults to Forbid.
+	// +kubebuilder:validation:Enum=Allow;Forbid;Replace
+	// +operator-sdk:csv:customresourcedefinitions:order=2,type=spec,displayName="Concurrency Policy",xDescriptors="urn:alm:descriptor:com.tectonic.ui:select:Allow,urn:alm:descriptor:com.tectonic.ui:select:Forbid,urn:alm:descriptor:com.tectonic.ui:select:Replace"
+	ConcurrencyPolicy OperationConcurrencyPolicy `json:"concurrencyPolicy,omitempty"`
+
+	// Suspends the creation of operations. Operations that already started are not affected. Defaults to false.
+	// +operator-sdk:csv:customresourcedefinitions:order=3,type=spec,displayName="Suspend",xDescriptors="urn:alm:descriptor:com.tectonic.ui:booleanSwitch"
+	Suspend *bool `json:"suspend,omitempty"`
+
+	// The number of successful operations to keep. Defaults to 3.
+	// +kubebuilder:validation:Minimum=0
+	// +operator-sdk:csv:customresourcedefinitions:order=4,type=spec,displayName="Successful Operations History Limit",xDescriptors="urn:alm:descriptor:com.tectonic.ui:number"
+	SuccessfulOperationsHistoryLimit *int32 `json:"successfulOperationsHistoryLimit,omitempty"`
+
+	// The number of failed operations to keep. Defaults to 1.
+	// +kubebuilder:validation:Minimum=0
+	// +operator-sdk:csv:customresourcedefinitions:order=5,type=spec,displayName="Failed Operations History Limit",xDescriptors="urn:alm:descriptor:com.tectonic.ui:number"
+	FailedOperationsHistoryLimit *int32 `json:"failedOperationsHistoryLimit,omitempty"`
+
+	// The RuntimeOperation to create at each scheduled time.
+	// +operator-sdk:csv:customresourcedefinitions:order=6,type=spec,displayName="Operation Template"
+	OperationTemplate RuntimeOperationTemplate `json:"operationTemplate"`
+}
+
+// Describes the RuntimeOperation created by a RuntimeOperationSchedule.
+type RuntimeOperationTemplate struct {
+	Metadata RuntimeOperationTemplateMetadata `json:"metadata,omitempty"`
+
+	Spec RuntimeOperationSpec `json:"spec"`
+}
+
+// Labels and annotations added to the RuntimeOperation created by a RuntimeOperationSchedule.
+type RuntimeOperationTemplateMetadata struct {
+	Labels      map[string]string `json:"labels,omitempty"`
+	Annotations map[string]string `json:"annotations,omitempty"`
+}
+
+const (
+	// OperationScheduleLabel is set on the RuntimeOperations created by a RuntimeOperationSchedule to the name of the schedule
+	OperationScheduleLabel = "rc.app.stacks/schedule"
+	// OperationScheduledTimeAnnotation is set on the RuntimeOperations created by a RuntimeOperationSchedule to the scheduled time
+	OperationScheduledTimeAnnotation = "rc.app.stacks/scheduled-time"
+)
+
+// OperationConcurrencyPolicy describes how a scheduled operation is treated when a previous operation is still running
+type OperationConcurrencyPolicy string
+
+const (
+	// OperationConcurrencyPolicyAllow allows operations to run concurrently
+	OperationConcurrencyPolicyAllow OperationConcurrencyPolicy = "Allow"
+	// OperationConcurrencyPolicyForbid skips the scheduled operation if a previous operation is still running
+	OperationConcurrencyPolicyForbid OperationConcurrencyPolicy = "Forbid"
+	// OperationConcurrencyPolicyReplace cancels the running operations and creates the scheduled operation
+	OperationConcurrencyPolicyReplace OperationConcurrencyPolicy = "Replace"
+)
+
+// Defines the observed state of RuntimeOperationSchedule
+type RuntimeOperationScheduleStatus struct {
+	// The last time an operation was due. The operation is not created if it was skipped because of the concurrency policy.
+	LastScheduleTime *metav1.Time `json:"lastScheduleTime,omitempty"`
+
+	// The last time an operation completed successfully.
+	LastSuccessfulTime *metav1.Time `json:"lastSuccessfulTime,omitempty"`
+
+	// The operations that are running.
+	// +listType=atomic
+	Active []corev1.LocalObjectReference `json:"active,omitempty"`
+
+	// Error encountered while scheduling operations, such as an invalid schedule.
+	Message string `json:"message,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:path=runtimeoperationschedules,scope=Namespaced,shortName=runtimeopsched
+// +kubebuilder:printcolumn:name="Schedule",type="string",JSONPath=".spec.schedule",priority=0,description="Schedule of the operations"
+// +kubebuilder:printcolumn:name="Suspend",type="boolean",JSONPath=".spec.suspend",priority=0,description="Whether the creation of operations is suspended"
+// +kubebuilder:printcolumn:name="LastSchedule",type="date",JSONPath=".status.lastScheduleTime",priority=0,description="Last time an operation was scheduled"
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp",priority=0,description="Age of the resource"
+//+operator-sdk:csv:customresourcedefinitions:displayName="RuntimeOperationSchedule"
+
+// Day-2 operation to execute on a schedule
+type RuntimeOperationSchedule struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   RuntimeOperationScheduleSpec   `json:"spec,omitempty"`
+	Status RuntimeOperationScheduleStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// RuntimeOperationScheduleList contains a list of RuntimeOperationSchedule.
+type RuntimeOperationScheduleList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []RuntimeOperationSchedule `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&RuntimeOperationSchedule{}, &RuntimeOperationScheduleList{})
+}
+
+// GetConcurrencyPolicy returns how a scheduled operation is treated when a previous operation is still running
+func (s *RuntimeOperationScheduleSpec) GetConcurrencyPolicy() OperationConcurrencyPolicy {
+	if s.ConcurrencyPolicy == "" {
+		return OperationConcurrencyPolicyForbid
+	}
+	return s.ConcurrencyPolicy
+}
+
+// IsSuspended returns whether the creation of operations is suspended
+func (s *RuntimeOperationScheduleSpec) IsSuspended() bool {
+	return s.Suspend != nil && *s.Suspend
+}
+
+// GetSuccessfulOperationsHistoryLimit returns the number of successful operations to keep
+func (s *RuntimeOperationScheduleSpec) GetSuccessfulOperationsHistoryLimit() int {
+	if s.SuccessfulOperationsHistoryLimit == nil {
+		return 3
+	}
+	return int(*s.SuccessfulOperationsHistoryLimit)
+}
+
+// GetFailedOperationsHistoryLimit returns the number of failed operations to keep
+func (s *RuntimeOperationScheduleSpec) GetFailedOperationsHistoryLimit() int {
+	if s.FailedOperationsHistoryLimit == nil {
+		return 1
+	}
+	return int(*s.FailedOperationsHistoryLimit)
+}
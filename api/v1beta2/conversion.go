@@ -0,0 +1,206 @@
+/*
+
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta2
+
+import (
+	"encoding/json"
+
+	v1 "github.com/application-stacks/runtime-component-operator/api/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/conversion"
+)
+
+const (
+	// V1FieldsAnnotation holds the fields of a v1 object that cannot be represented in v1beta2
+	V1FieldsAnnotation = "rc.app.stacks/v1-fields"
+
+	// V1beta2FieldsAnnotation holds the fields of a v1beta2 object that cannot be represented in v1
+	V1beta2FieldsAnnotation = "rc.app.stacks/v1beta2-fields"
+)
+
+var _ conversion.Convertible = &RuntimeComponent{}
+var _ conversion.Convertible = &RuntimeOperation{}
+
+// ConvertTo converts this RuntimeComponent to the hub version (v1)
+func (src *RuntimeComponent) ConvertTo(dstRaw conversion.Hub) error {
+	return convertObject(src, dstRaw.(*v1.RuntimeComponent), v1.GroupVersion.WithKind("RuntimeComponent"), V1beta2FieldsAnnotation, V1FieldsAnnotation)
+}
+
+// ConvertFrom converts from the hub version (v1) to this RuntimeComponent
+func (dst *RuntimeComponent) ConvertFrom(srcRaw conversion.Hub) error {
+	return convertObject(srcRaw.(*v1.RuntimeComponent), dst, GroupVersion.WithKind("RuntimeComponent"), V1FieldsAnnotation, V1beta2FieldsAnnotation)
+}
+
+// ConvertTo converts this RuntimeOperation to the hub version (v1)
+func (src *RuntimeOperation) ConvertTo(dstRaw conversion.Hub) error {
+	return convertObject(src, dstRaw.(*v1.RuntimeOperation), v1.GroupVersion.WithKind("RuntimeOperation"), V1beta2FieldsAnnotation, V1FieldsAnnotation)
+}
+
+// ConvertFrom converts from the hub version (v1) to this RuntimeOperation
+func (dst *RuntimeOperation) ConvertFrom(srcRaw conversion.Hub) error {
+	return convertObject(srcRaw.(*v1.RuntimeOperation), dst, GroupVersion.WithKind("RuntimeOperation"), V1FieldsAnnotation, V1beta2FieldsAnnotation)
+}
+
+// convertObject converts src into dst through their shared JSON representation.
+// The spec and status fields of src that dst has no place for are recorded in the
+// lostAnnotation of dst, and the fields recorded in the restoreAnnotation of src by
+// an earlier conversion are put back into dst, so that a round trip loses nothing.
+func convertObject(src, dst client.Object, gvk schema.GroupVersionKind, lostAnnotation, restoreAnnotation string) error {
+	srcFields, err := toFields(src)
+	if err != nil {
+		return err
+	}
+	if err := fromFields(srcFields, dst); err != nil {
+		return err
+	}
+	dstFields, err := toFields(dst)
+	if err != nil {
+		return err
+	}
+
+	lost := map[string]interface{}{}
+	for _, key := range []string{"spec", "status"} {
+		srcSection, srcOk := srcFields[key].(map[string]interface{})
+		dstSection, _ := dstFields[key].(map[string]interface{})
+		if !srcOk {
+			continue
+		}
+		if fields := missingFields(srcSection, dstSection); len(fields) > 0 {
+			lost[key] = fields
+		}
+	}
+
+	if restore, ok := src.GetAnnotations()[restoreAnnotation]; ok {
+		restored := map[string]interface{}{}
+		if err := json.Unmarshal([]byte(restore), &restored); err != nil {
+			return err
+		}
+		restoreFields(dstFields, restored)
+		if err := fromFields(dstFields, dst); err != nil {
+			return err
+		}
+	}
+
+	annotations := map[string]string{}
+	for k, v := range src.GetAnnotations() {
+		if k != restoreAnnotation && k != lostAnnotation {
+			annotations[k] = v
+		}
+	}
+	if len(lost) > 0 {
+		data, err := json.Marshal(lost)
+		if err != nil {
+			return err
+		}
+		annotations[lostAnnotation] = string(data)
+	}
+	if len(annotations) == 0 {
+		annotations = nil
+	}
+	dst.SetAnnotations(annotations)
+	dst.GetObjectKind().SetGroupVersionKind(gvk)
+	return nil
+}
+
+func toFields(obj interface{}) (map[string]interface{}, error) {
+	data, err := json.Marshal(obj)
+	if err != nil {
+		return nil, err
+	}
+	fields := map[string]interface{}{}
+	err = json.Unmarshal(data, &fields)
+	return fields, err
+}
+
+func fromFields(fields map[string]interface{}, obj interface{}) error {
+	data, err := json.Marshal(fields)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, obj)
+}
+
+// missingFields returns the fields of src that are absent from dst. Lists of the same
+// length are compared element by element.
+func missingFields(src, dst map[string]interface{}) map[string]interface{} {
+	missing := map[string]interface{}{}
+	for key, value := range src {
+		dstValue, ok := dst[key]
+		if !ok {
+			missing[key] = value
+			continue
+		}
+		switch v := value.(type) {
+		case map[string]interface{}:
+			if dv, ok := dstValue.(map[string]interface{}); ok {
+				if fields := missingFields(v, dv); len(fields) > 0 {
+					missing[key] = fields
+				}
+			}
+		case []interface{}:
+			dv, ok := dstValue.([]interface{})
+			if !ok || len(dv) != len(v) {
+				continue
+			}
+			items, found := make([]interface{}, len(v)), false
+			for i := range v {
+				item := map[string]interface{}{}
+				srcItem, srcOk := v[i].(map[string]interface{})
+				dstItem, dstOk := dv[i].(map[string]interface{})
+				if srcOk && dstOk {
+					item = missingFields(srcItem, dstItem)
+				}
+				found = found || len(item) > 0
+				items[i] = item
+			}
+			if found {
+				missing[key] = items
+			}
+		}
+	}
+	return missing
+}
+
+// restoreFields adds the fields to dst without overwriting any value already set in dst
+func restoreFields(dst, fields map[string]interface{}) {
+	for key, value := range fields {
+		dstValue, ok := dst[key]
+		if !ok {
+			dst[key] = value
+			continue
+		}
+		switch v := value.(type) {
+		case map[string]interface{}:
+			if dv, ok := dstValue.(map[string]interface{}); ok {
+				restoreFields(dv, v)
+			}
+		case []interface{}:
+			dv, ok := dstValue.([]interface{})
+			if !ok || len(dv) != len(v) {
+				continue
+			}
+			for i := range v {
+				item, itemOk := v[i].(map[string]interface{})
+				dstItem, dstOk := dv[i].(map[string]interface{})
+				if itemOk && dstOk {
+					restoreFields(dstItem, item)
+				}
+			}
+		}
+	}
+}
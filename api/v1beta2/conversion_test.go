@@ -0,0 +1,137 @@
+package v1beta2
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	v1 "github.com/application-stacks/runtime-component-operator/api/v1"
+	"github.com/application-stacks/runtime-component-operator/common"
+	routev1 "github.com/openshift/api/route/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestRuntimeComponentRoundTripFromHub(t *testing.T) {
+	ingressClassName := "nginx"
+	replicas := int32(2)
+	hub := &v1.RuntimeComponent{
+		ObjectMeta: metav1.ObjectMeta{Name: "my-app", Namespace: "test", Annotations: map[string]string{"team": "payments"}},
+		Spec: v1.RuntimeComponentSpec{
+			ApplicationImage: "my-image",
+			Replicas:         &replicas,
+			Route:            &v1.RuntimeComponentRoute{Host: "my-app.example.com", IngressClassName: &ingressClassName},
+			Service: &v1.RuntimeComponentService{Port: 8443, Ports: []corev1.ServicePort{{Port: 9443, Name: "admin"}},
+				Certificate: &v1.RuntimeComponentServiceCertificate{AdditionalDNSNames: []string{"my-app.example.com"}}},
+			TrustBundle: &v1.RuntimeComponentTrustBundle{ConfigMapName: "corporate-ca"},
+			MTLS:        &v1.RuntimeComponentMTLS{AllowedCallers: []v1.RuntimeComponentMTLSCaller{{Name: "frontend"}}},
+		},
+		Status: v1.RuntimeComponentStatus{
+			References: common.StatusReferences{common.StatusReferenceCertSecretName: "my-app-svc-tls-cm"},
+			Rollout:    &v1.RuntimeComponentRolloutStatus{LastHealthyImage: "my-image:1"},
+		},
+	}
+
+	spoke := &RuntimeComponent{}
+	if err := spoke.ConvertFrom(hub.DeepCopy()); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := spoke.Annotations[V1FieldsAnnotation]; !ok {
+		t.Errorf("the %s annotation is not set on the v1beta2 object", V1FieldsAnnotation)
+	}
+	if spoke.Spec.Route == nil || spoke.Spec.Route.Host != "my-app.example.com" {
+		t.Errorf("the route host is not converted to v1beta2: %+v", spoke.Spec.Route)
+	}
+
+	result := &v1.RuntimeComponent{}
+	if err := spoke.ConvertTo(result); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(hub.Spec, result.Spec) {
+		t.Errorf("the v1 spec changed after a round trip through v1beta2:\nexpected %+v\nactual   %+v", hub.Spec, result.Spec)
+	}
+	if !reflect.DeepEqual(hub.Status, result.Status) {
+		t.Errorf("the v1 status changed after a round trip through v1beta2:\nexpected %+v\nactual   %+v", hub.Status, result.Status)
+	}
+	if !reflect.DeepEqual(hub.Annotations, result.Annotations) {
+		t.Errorf("the annotations changed after a round trip through v1beta2: expected %v, actual %v", hub.Annotations, result.Annotations)
+	}
+}
+
+func TestRuntimeComponentRoundTripFromSpoke(t *testing.T) {
+	termination := routev1.TLSTerminationReencrypt
+	spoke := &RuntimeComponent{
+		ObjectMeta: metav1.ObjectMeta{Name: "my-app", Namespace: "test"},
+		Spec: RuntimeComponentSpec{
+			ApplicationImage: "my-image",
+			Route:            &RuntimeComponentRoute{Host: "my-app.example.com", Termination: &termination},
+			Service:          &RuntimeComponentService{Port: 8443},
+		},
+	}
+
+	hub := &v1.RuntimeComponent{}
+	if err := spoke.DeepCopy().ConvertTo(hub); err != nil {
+		t.Fatal(err)
+	}
+	result := &RuntimeComponent{}
+	if err := result.ConvertFrom(hub); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(spoke.Spec, result.Spec) {
+		t.Errorf("the v1beta2 spec changed after a round trip through v1:\nexpected %+v\nactual   %+v", spoke.Spec, result.Spec)
+	}
+	if len(result.Annotations) != 0 {
+		t.Errorf("unexpected annotations after a round trip through v1: %v", result.Annotations)
+	}
+}
+
+func TestRuntimeComponentSpokeUpdateKeepsHubFields(t *testing.T) {
+	hub := &v1.RuntimeComponent{
+		ObjectMeta: metav1.ObjectMeta{Name: "my-app", Namespace: "test"},
+		Spec: v1.RuntimeComponentSpec{
+			ApplicationImage: "my-image:1",
+			Knative:          &v1.RuntimeComponentKnative{RevisionName: "v1"},
+		},
+	}
+
+	// A client that only knows v1beta2 changes the image of the object it read
+	spoke := &RuntimeComponent{}
+	if err := spoke.ConvertFrom(hub); err != nil {
+		t.Fatal(err)
+	}
+	spoke.Spec.ApplicationImage = "my-image:2"
+
+	result := &v1.RuntimeComponent{}
+	if err := spoke.ConvertTo(result); err != nil {
+		t.Fatal(err)
+	}
+	if result.Spec.ApplicationImage != "my-image:2" {
+		t.Errorf("the change of the v1beta2 object is lost: %s", result.Spec.ApplicationImage)
+	}
+	if result.Spec.Knative == nil || result.Spec.Knative.RevisionName != "v1" {
+		t.Errorf("the v1-only knative field is lost: %+v", result.Spec.Knative)
+	}
+}
+
+func TestRuntimeOperationRoundTripFromHub(t *testing.T) {
+	hub := &v1.RuntimeOperation{
+		ObjectMeta: metav1.ObjectMeta{Name: "dump", Namespace: "test"},
+		Spec: v1.RuntimeOperationSpec{
+			ComponentName: "my-app",
+			Command:       []string{"/bin/sh", "-c", "kill -3 1"},
+			Timeout:       &metav1.Duration{Duration: time.Minute},
+		},
+	}
+
+	spoke := &RuntimeOperation{}
+	if err := spoke.ConvertFrom(hub.DeepCopy()); err != nil {
+		t.Fatal(err)
+	}
+	result := &v1.RuntimeOperation{}
+	if err := spoke.ConvertTo(result); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(hub.Spec, result.Spec) {
+		t.Errorf("the v1 spec changed after a round trip through v1beta2:\nexpected %+v\nactual   %+v", hub.Spec, result.Spec)
+	}
+}
@@ -23,6 +23,7 @@ import (
 	routev1 "github.com/openshift/api/route/v1"
 	prometheusv1 "github.com/prometheus-operator/prometheus-operator/pkg/apis/monitoring/v1"
 	appsv1 "k8s.io/api/apps/v1"
+	autoscalingv2beta2 "k8s.io/api/autoscaling/v2beta2"
 	corev1 "k8s.io/api/core/v1"
 	networkingv1 "k8s.io/api/networking/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -543,6 +544,46 @@ func (cr *RuntimeComponent) GetAutoscaling() common.BaseComponentAutoscaling {
 	return cr.Spec.Autoscaling
 }
 
+// GetEventDrivenAutoscaling returns nil, as event-driven autoscaling is only supported in v1
+func (cr *RuntimeComponent) GetEventDrivenAutoscaling() common.BaseComponentEventDrivenAutoscaling {
+	return nil
+}
+
+// GetVerticalAutoscaling returns nil, as vertical autoscaling is only supported in v1
+func (cr *RuntimeComponent) GetVerticalAutoscaling() common.BaseComponentVerticalAutoscaling {
+	return nil
+}
+
+// GetDisruptionBudget returns nil, as disruption budgets are only supported in v1
+func (cr *RuntimeComponent) GetDisruptionBudget() common.BaseComponentDisruptionBudget {
+	return nil
+}
+
+// GetTrustBundle returns nil, as trust bundles are only supported in v1
+func (cr *RuntimeComponent) GetTrustBundle() common.BaseComponentTrustBundle {
+	return nil
+}
+
+// GetMTLS returns nil, as mutual TLS is only supported in v1
+func (cr *RuntimeComponent) GetMTLS() common.BaseComponentMTLS {
+	return nil
+}
+
+// GetKnative returns nil, as the Knative settings are only supported in v1
+func (cr *RuntimeComponent) GetKnative() common.BaseComponentKnative {
+	return nil
+}
+
+// GetGateway returns nil, as Gateway API HTTPRoutes are only supported in v1
+func (cr *RuntimeComponent) GetGateway() common.BaseComponentGateway {
+	return nil
+}
+
+// GetTopologySpreadConstraints returns nil, as topology spread constraints are only supported in v1
+func (cr *RuntimeComponent) GetTopologySpreadConstraints() common.BaseComponentTopologySpreadConstraints {
+	return nil
+}
+
 // GetStorage returns storage settings
 func (ss *RuntimeComponentStatefulSet) GetStorage() common.BaseComponentStorage {
 	if ss.Storage == nil {
@@ -693,6 +734,16 @@ func (a *RuntimeComponentAutoScaling) GetTargetCPUUtilizationPercentage() *int32
 	return a.TargetCPUUtilizationPercentage
 }
 
+// GetMetrics returns nil, as metrics other than the CPU utilization are only supported in v1
+func (a *RuntimeComponentAutoScaling) GetMetrics() []autoscalingv2beta2.MetricSpec {
+	return nil
+}
+
+// GetBehavior returns nil, as scaling policies are only supported in v1
+func (a *RuntimeComponentAutoScaling) GetBehavior() *autoscalingv2beta2.HorizontalPodAutoscalerBehavior {
+	return nil
+}
+
 // GetSize returns persistent volume size
 func (s *RuntimeComponentStorage) GetSize() string {
 	return s.Size
@@ -761,6 +812,11 @@ func (s *RuntimeComponentService) GetBindable() *bool {
 	return s.Bindable
 }
 
+// GetCertificate returns nil, as the service certificate settings are only supported in v1
+func (s *RuntimeComponentService) GetCertificate() common.BaseComponentServiceCertificate {
+	return nil
+}
+
 func (np *RuntimeComponentNetworkPolicy) GetNamespaceLabels() map[string]string {
 	if np == nil || np.NamespaceLabels == nil {
 		return nil
@@ -824,6 +880,31 @@ func (r *RuntimeComponentRoute) GetPathType() networkingv1.PathType {
 	return r.PathType
 }
 
+// GetIngressClassName returns nil, as the IngressClass is only supported in v1
+func (r *RuntimeComponentRoute) GetIngressClassName() *string {
+	return nil
+}
+
+// GetAdditionalHosts returns nil, as additional hosts are only supported in v1
+func (r *RuntimeComponentRoute) GetAdditionalHosts() []string {
+	return nil
+}
+
+// GetAdditionalPaths returns nil, as additional paths are only supported in v1
+func (r *RuntimeComponentRoute) GetAdditionalPaths() []common.BaseComponentIngressPath {
+	return nil
+}
+
+// GetCertificateIssuerName returns an empty name, as Ingress certificates are only supported in v1
+func (r *RuntimeComponentRoute) GetCertificateIssuerName() string {
+	return ""
+}
+
+// GetCertificateIssuerKind returns an empty kind, as Ingress certificates are only supported in v1
+func (r *RuntimeComponentRoute) GetCertificateIssuerKind() string {
+	return ""
+}
+
 // GetNodeAffinity returns node affinity
 func (a *RuntimeComponentAffinity) GetNodeAffinity() *corev1.NodeAffinity {
 	return a.NodeAffinity
@@ -1,14 +1,14 @@
 package common
 
 import (
-	corev1 "k8s.io/api/core/v1"
+	"time"
 )
 
-// OpConfig stored operator configuration
-type OpConfig map[string]string
-
 const (
 
+	// OpConfigMapName name of the ConfigMap that holds the operator configuration
+	OpConfigMapName = "runtime-component-operator"
+
 	// OpConfigDefaultHostname a DNS name to be used for hostname generation.
 	OpConfigDefaultHostname = "defaultHostname"
 
@@ -17,27 +17,60 @@ const (
 
 	// OpConfigCMCADuration default duration for cert-manager issued service certificate
 	OpConfigCMCertDuration = "certManagerCertDuration"
-)
 
-// Config stores operator configuration
-var Config = OpConfig{}
+	// OpConfigCMIssuerName name of an existing cert-manager issuer that signs the service certificates. Empty uses the CA issuer created by the operator.
+	OpConfigCMIssuerName = "certManagerIssuerName"
+
+	// OpConfigCMIssuerKind kind of the cert-manager issuer that signs the service certificates, Issuer or ClusterIssuer
+	OpConfigCMIssuerKind = "certManagerIssuerKind"
 
-// LoadFromConfigMap creates a config out of kubernetes config map
-func (oc OpConfig) LoadFromConfigMap(cm *corev1.ConfigMap) {
-	for k, v := range DefaultOpConfig() {
-		oc[k] = v
-	}
+	// OpConfigMTLSTrustDomain trust domain of the SPIFFE IDs in the client certificates of the components
+	OpConfigMTLSTrustDomain = "mtlsTrustDomain"
+
+	// OpConfigCertExpiryWarningThresholds comma-separated durations before the expiry of a certificate at which a warning event is recorded
+	OpConfigCertExpiryWarningThresholds = "certificateExpiryWarningThresholds"
+
+	// OpConfigOperationTTLAfterSuccess default number of seconds to keep a RuntimeOperation after it succeeded. Empty keeps it.
+	OpConfigOperationTTLAfterSuccess = "operationTTLSecondsAfterSuccess"
+
+	// OpConfigOperationTTLAfterFailure default number of seconds to keep a RuntimeOperation after it failed. Empty keeps it.
+	OpConfigOperationTTLAfterFailure = "operationTTLSecondsAfterFailure"
+)
 
-	for k, v := range cm.Data {
-		oc[k] = v
-	}
+// OperatorConfig is the validated operator configuration. A reconciler gets the current configuration when it
+// starts reconciling a resource and uses it until it is done. It must not be modified.
+type OperatorConfig struct {
+	// DefaultHostname is the domain of the host names generated for Routes and Ingresses
+	DefaultHostname string
+	// CertManagerCACertDuration is the duration of the CA certificate issued with cert-manager
+	CertManagerCACertDuration time.Duration
+	// CertManagerCertDuration is the duration of the service certificates issued with cert-manager
+	CertManagerCertDuration time.Duration
+	// CertManagerIssuerName is the name of the cert-manager issuer that signs the service certificates. Empty uses the CA issuer created by the operator.
+	CertManagerIssuerName string
+	// CertManagerIssuerKind is the kind of the cert-manager issuer that signs the service certificates
+	CertManagerIssuerKind string
+	// MTLSTrustDomain is the trust domain of the SPIFFE IDs in the client certificates of the components
+	MTLSTrustDomain string
+	// CertExpiryWarningThresholds are the durations before the expiry of a certificate at which a warning event is recorded, longest first
+	CertExpiryWarningThresholds []time.Duration
+	// OperationTTLSecondsAfterSuccess is the default number of seconds to keep a RuntimeOperation after it succeeded
+	OperationTTLSecondsAfterSuccess *int32
+	// OperationTTLSecondsAfterFailure is the default number of seconds to keep a RuntimeOperation after it failed
+	OperationTTLSecondsAfterFailure *int32
 }
 
-// DefaultOpConfig returns default configuration
-func DefaultOpConfig() OpConfig {
-	cfg := OpConfig{}
+// DefaultOpConfig returns default configuration, as stored in the operator ConfigMap
+func DefaultOpConfig() map[string]string {
+	cfg := map[string]string{}
 	cfg[OpConfigDefaultHostname] = ""
 	cfg[OpConfigCMCADuration] = "8766h"
 	cfg[OpConfigCMCertDuration] = "2160h"
+	cfg[OpConfigCMIssuerName] = ""
+	cfg[OpConfigCMIssuerKind] = "ClusterIssuer"
+	cfg[OpConfigMTLSTrustDomain] = "cluster.local"
+	cfg[OpConfigCertExpiryWarningThresholds] = "720h,168h,24h"
+	cfg[OpConfigOperationTTLAfterSuccess] = ""
+	cfg[OpConfigOperationTTLAfterFailure] = ""
 	return cfg
 }
@@ -4,9 +4,11 @@ import (
 	routev1 "github.com/openshift/api/route/v1"
 	prometheusv1 "github.com/prometheus-operator/prometheus-operator/pkg/apis/monitoring/v1"
 	appsv1 "k8s.io/api/apps/v1"
+	autoscalingv2beta2 "k8s.io/api/autoscaling/v2beta2"
 	corev1 "k8s.io/api/core/v1"
 	networkingv1 "k8s.io/api/networking/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
 )
 
 // StatusConditionType ...
@@ -21,6 +23,18 @@ const (
 	StatusReferenceCertSecretName    = "svcCertSecretName"
 	StatusReferencePullSecretName    = "saPullSecretName"
 	StatusReferenceSAResourceVersion = "saResourceVersion"
+	// StatusReferenceDeploymentName is the name of the Deployment that receives the traffic, when it is not the name of the component
+	StatusReferenceDeploymentName = "deploymentName"
+	// StatusReferenceTrustBundleResourceVersion is the resource version of the trust bundle ConfigMap mounted in the pods
+	StatusReferenceTrustBundleResourceVersion = "trustBundleResourceVersion"
+	// StatusReferenceMTLSResourceVersion is the resource version of the mutual TLS ConfigMap mounted in the pods
+	StatusReferenceMTLSResourceVersion = "mtlsResourceVersion"
+	// StatusReferenceClientCertSecretName is the name of the Secret of the client certificate of the component
+	StatusReferenceClientCertSecretName = "clientCertSecretName"
+	// StatusReferenceRouteCertSecretName is the name of the Secret of the certificate of the Route or Ingress of the component
+	StatusReferenceRouteCertSecretName = "routeCertSecretName"
+	// StatusReferenceCertExpiryWarning is the last expiry warning threshold of the certificates that was recorded in an event
+	StatusReferenceCertExpiryWarning = "certExpiryWarning"
 )
 
 // StatusCondition ...
@@ -89,6 +103,18 @@ const (
 	StatusConditionTypeResourcesReady StatusConditionType = "ResourcesReady"
 	StatusConditionTypeReady          StatusConditionType = "Ready"
 
+	// StatusConditionTypeCanaryProgressing is True while a canary rollout is in progress
+	StatusConditionTypeCanaryProgressing StatusConditionType = "CanaryProgressing"
+
+	// StatusConditionTypeBlueGreenProgressing is True while new pods are previewed by the BlueGreen strategy
+	StatusConditionTypeBlueGreenProgressing StatusConditionType = "BlueGreenProgressing"
+
+	// StatusConditionTypeRolledBack is True while the workload runs the last healthy image instead of the application image
+	StatusConditionTypeRolledBack StatusConditionType = "RolledBack"
+
+	// StatusConditionTypeCertificatesReady is True while the certificates of the service and of the Route or Ingress are valid
+	StatusConditionTypeCertificatesReady StatusConditionType = "CertificatesReady"
+
 	// Status Endpoint Scopes
 	StatusEndpointScopeExternal StatusEndpointScope = "External"
 	StatusEndpointScopeInternal StatusEndpointScope = "Internal"
@@ -99,6 +125,42 @@ type BaseComponentAutoscaling interface {
 	GetMinReplicas() *int32
 	GetMaxReplicas() int32
 	GetTargetCPUUtilizationPercentage() *int32
+	GetMetrics() []autoscalingv2beta2.MetricSpec
+	GetBehavior() *autoscalingv2beta2.HorizontalPodAutoscalerBehavior
+}
+
+// BaseComponentEventDrivenAutoscaling represents the configuration of a KEDA ScaledObject
+type BaseComponentEventDrivenAutoscaling interface {
+	GetMinReplicas() *int32
+	GetMaxReplicas() *int32
+	GetPollingInterval() *int32
+	GetCooldownPeriod() *int32
+	GetTriggers() []BaseComponentScaleTrigger
+}
+
+// BaseComponentScaleTrigger represents a KEDA trigger
+type BaseComponentScaleTrigger interface {
+	GetType() string
+	GetName() string
+	GetMetadata() map[string]string
+	GetAuthenticationRef() string
+	GetAuthenticationKind() string
+}
+
+// BaseComponentVerticalAutoscaling represents the configuration of a VerticalPodAutoscaler
+type BaseComponentVerticalAutoscaling interface {
+	GetUpdateMode() *string
+	GetMinAllowed() corev1.ResourceList
+	GetMaxAllowed() corev1.ResourceList
+	GetControlledResources() []corev1.ResourceName
+	GetControlledValues() *string
+}
+
+// BaseComponentDisruptionBudget represents the configuration of a PodDisruptionBudget
+type BaseComponentDisruptionBudget interface {
+	GetEnabled() *bool
+	GetMinAvailable() *intstr.IntOrString
+	GetMaxUnavailable() *intstr.IntOrString
 }
 
 // BaseComponentStorage represents basic PVC configuration
@@ -120,6 +182,17 @@ type BaseComponentService interface {
 	GetAnnotations() map[string]string
 	GetCertificateSecretRef() *string
 	GetBindable() *bool
+	GetCertificate() BaseComponentServiceCertificate
+}
+
+// BaseComponentServiceCertificate represents the configuration of a service certificate issued with cert-manager
+type BaseComponentServiceCertificate interface {
+	GetIssuerName() string
+	GetIssuerKind() string
+	GetAdditionalDNSNames() []string
+	GetPrivateKeyAlgorithm() string
+	GetPrivateKeySize() int
+	GetRenewBefore() *metav1.Duration
 }
 
 // BaseComponentNetworkPolicy represents a basic network policy configuration
@@ -143,6 +216,69 @@ type BaseComponentRoute interface {
 	GetPath() string
 	GetPathType() networkingv1.PathType
 	GetCertificateSecretRef() *string
+	GetIngressClassName() *string
+	GetAdditionalHosts() []string
+	GetAdditionalPaths() []BaseComponentIngressPath
+	GetCertificateIssuerName() string
+	GetCertificateIssuerKind() string
+}
+
+// BaseComponentIngressPath represents an additional path of an Ingress
+type BaseComponentIngressPath interface {
+	GetPath() string
+	GetPathType() networkingv1.PathType
+}
+
+// BaseComponentTrustBundle represents the trust bundle mounted in the application container
+type BaseComponentTrustBundle interface {
+	GetConfigMapName() string
+	GetKey() string
+}
+
+// BaseComponentMTLS represents the mutual TLS configuration of a component
+type BaseComponentMTLS interface {
+	GetAllowedCallers() []BaseComponentMTLSCaller
+}
+
+// BaseComponentMTLSCaller represents a component that may call another component
+type BaseComponentMTLSCaller interface {
+	GetName() string
+	GetNamespace() string
+}
+
+// BaseComponentKnative represents the configuration of a Knative service
+type BaseComponentKnative interface {
+	GetMinScale() *int32
+	GetMaxScale() *int32
+	GetContainerConcurrency() *int64
+	GetTargetUtilizationPercentage() *int32
+	GetScaleToZeroPodRetentionPeriod() *metav1.Duration
+	GetResources() *corev1.ResourceRequirements
+	GetRevisionName() string
+	GetTraffic() []BaseComponentKnativeTraffic
+}
+
+// BaseComponentKnativeTraffic represents the traffic of a revision of a Knative service
+type BaseComponentKnativeTraffic interface {
+	GetRevisionName() string
+	GetPercent() *int64
+	GetTag() string
+}
+
+// BaseComponentGateway represents the configuration of a Gateway API HTTPRoute
+type BaseComponentGateway interface {
+	GetParentRefs() []BaseComponentGatewayParentRef
+	GetHostnames() []string
+	GetPath() string
+	GetPathMatchType() string
+	GetAnnotations() map[string]string
+}
+
+// BaseComponentGatewayParentRef represents a Gateway that an HTTPRoute attaches to
+type BaseComponentGatewayParentRef interface {
+	GetName() string
+	GetNamespace() string
+	GetSectionName() string
 }
 
 // BaseComponentAffinity describes deployment and pod affinity
@@ -154,6 +290,12 @@ type BaseComponentAffinity interface {
 	GetNodeAffinityLabels() map[string]string
 }
 
+// BaseComponentTopologySpreadConstraints represents the topology spread constraints of the pods
+type BaseComponentTopologySpreadConstraints interface {
+	GetConstraints() []corev1.TopologySpreadConstraint
+	GetDisableOperatorDefaults() *bool
+}
+
 // BaseComponentDeployment describes deployment
 type BaseComponentDeployment interface {
 	GetDeploymentUpdateStrategy() *appsv1.DeploymentStrategy
@@ -194,6 +336,9 @@ type BaseComponent interface {
 	GetEnvFrom() []corev1.EnvFromSource
 	GetCreateKnativeService() *bool
 	GetAutoscaling() BaseComponentAutoscaling
+	GetEventDrivenAutoscaling() BaseComponentEventDrivenAutoscaling
+	GetVerticalAutoscaling() BaseComponentVerticalAutoscaling
+	GetDisruptionBudget() BaseComponentDisruptionBudget
 	GetService() BaseComponentService
 	GetNetworkPolicy() BaseComponentNetworkPolicy
 	GetDeployment() BaseComponentDeployment
@@ -208,7 +353,12 @@ type BaseComponent interface {
 	GetSidecarContainers() []corev1.Container
 	GetGroupName() string
 	GetRoute() BaseComponentRoute
+	GetGateway() BaseComponentGateway
+	GetKnative() BaseComponentKnative
+	GetTrustBundle() BaseComponentTrustBundle
+	GetMTLS() BaseComponentMTLS
 	GetAffinity() BaseComponentAffinity
+	GetTopologySpreadConstraints() BaseComponentTopologySpreadConstraints
 	GetSecurityContext() *corev1.SecurityContext
 	GetManageTLS() *bool
 }
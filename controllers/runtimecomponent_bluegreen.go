@@ -0,0 +1,276 @@
+/*
+
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	appstacksv1 "github.com/application-stacks/runtime-component-operator/api/v1"
+	"github.com/application-stacks/runtime-component-operator/common"
+	appstacksutils "github.com/application-stacks/runtime-component-operator/utils"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func blueGreenDeployment(instance *appstacksv1.RuntimeComponent, color string) *appsv1.Deployment {
+	return &appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Name: instance.Name + "-" + color, Namespace: instance.Namespace}}
+}
+
+func blueGreenPreviewService(instance *appstacksv1.RuntimeComponent) *corev1.Service {
+	return &corev1.Service{ObjectMeta: metav1.ObjectMeta{Name: instance.Name + "-preview", Namespace: instance.Namespace}}
+}
+
+// blueGreenResources returns the resources of the BlueGreen strategy, which are deleted when it is not used
+func blueGreenResources(instance *appstacksv1.RuntimeComponent) []client.Object {
+	return []client.Object{
+		blueGreenDeployment(instance, appstacksutils.Blue),
+		blueGreenDeployment(instance, appstacksutils.Green),
+		blueGreenPreviewService(instance),
+	}
+}
+
+// deleteBlueGreen deletes the resources of the BlueGreen strategy and clears its status
+func (r *RuntimeComponentReconciler) deleteBlueGreen(instance *appstacksv1.RuntimeComponent) error {
+	instance.Status.BlueGreen = nil
+	delete(instance.Status.References, common.StatusReferenceDeploymentName)
+	return r.DeleteResources(blueGreenResources(instance))
+}
+
+// reconcileBlueGreen keeps the pods of the active revision in the Deployment of the active color, and previews the pods
+// of a new revision in the Deployment of the other color before switching the selector of the Service to it
+func (r *RuntimeComponentReconciler) reconcileBlueGreen(instance *appstacksv1.RuntimeComponent) error {
+	if instance.Status.BlueGreen == nil {
+		instance.Status.BlueGreen = &appstacksv1.RuntimeComponentBlueGreenStatus{}
+	}
+	status := instance.Status.BlueGreen
+	blueGreen := instance.Spec.Deployment.BlueGreen
+
+	// The revision identifies the pods of the spec, whatever the color of their Deployment
+	desired := &appsv1.Deployment{}
+	appstacksutils.CustomizeDeployment(desired, instance)
+	appstacksutils.CustomizePodSpec(&desired.Spec.Template, instance)
+	if err := appstacksutils.CustomizePodWithSVCCertificate(&desired.Spec.Template, instance, r.GetClient()); err != nil {
+		return err
+	}
+	revision := appstacksutils.GetPodTemplateRevision(&desired.Spec.Template)
+
+	if status.ActiveColor != "" {
+		active := blueGreenDeployment(instance, status.ActiveColor)
+		err := r.GetClient().Get(context.TODO(), types.NamespacedName{Name: active.Name, Namespace: active.Namespace}, active)
+		if kerrors.IsNotFound(err) {
+			// The active Deployment was deleted, so the pods of the spec are rolled out again
+			status.ActiveColor, status.ActiveRevision, status.PreviousRevision, status.RollbackDeadline = "", "", "", nil
+		} else if err != nil {
+			return err
+		}
+	}
+
+	if status.ActiveColor == "" {
+		return r.startBlueGreen(instance, revision)
+	}
+	instance.Status.SetReference(common.StatusReferenceDeploymentName, instance.Name+"-"+status.ActiveColor)
+
+	otherColor := appstacksutils.GetOtherColor(status.ActiveColor)
+	active := blueGreenDeployment(instance, status.ActiveColor)
+	other := blueGreenDeployment(instance, otherColor)
+	now := metav1.Now()
+
+	if status.PreviousRevision != "" {
+		if instance.Annotations[appstacksutils.RollbackAnnotation] == status.ActiveRevision && now.Before(status.RollbackDeadline) {
+			return r.rollbackBlueGreen(instance)
+		}
+		if !now.Before(status.RollbackDeadline) || revision != status.ActiveRevision {
+			// The rollback window is over, or the Deployment of the previous revision is needed for a new preview
+			status.PreviousRevision, status.RollbackDeadline = "", nil
+			if err := r.DeleteResource(other); err != nil {
+				return err
+			}
+		}
+	}
+
+	if revision == status.ActiveRevision || revision == status.RolledBackRevision {
+		if status.PreviewRevision != "" {
+			r.setBlueGreenCondition(instance, corev1.ConditionFalse, "Aborted", "The preview of revision "+status.PreviewRevision+" was aborted because the pods changed.")
+			status.PreviewRevision = ""
+			if err := r.DeleteResource(other); err != nil {
+				return err
+			}
+		}
+		if revision == status.ActiveRevision {
+			// A rolled back revision is previewed again once the pods were changed
+			status.RolledBackRevision = ""
+			err := r.CreateOrUpdate(active, instance, func() error {
+				appstacksutils.CustomizeBlueGreenDeployment(active, instance, status.ActiveColor)
+				active.Annotations[appstacksutils.RevisionAnnotation] = revision
+				return appstacksutils.CustomizePodWithSVCCertificate(&active.Spec.Template, instance, r.GetClient())
+			})
+			if err != nil {
+				return err
+			}
+		}
+		if status.PreviousRevision == "" {
+			// The previous Deployments are left behind when the traffic was not switched after the status was saved
+			return r.DeleteResources([]client.Object{
+				&appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Name: instance.Name, Namespace: instance.Namespace}},
+				other,
+				blueGreenPreviewService(instance),
+			})
+		}
+		return r.DeleteResource(blueGreenPreviewService(instance))
+	}
+
+	if status.PreviewRevision != revision {
+		status.PreviewRevision = revision
+		r.GetRecorder().Event(instance, "Normal", "BlueGreenPreviewStarted", "Started the preview of revision "+revision+" in Deployment "+other.Name+".")
+	}
+	err := r.CreateOrUpdate(other, instance, func() error {
+		appstacksutils.CustomizeBlueGreenDeployment(other, instance, otherColor)
+		other.Annotations[appstacksutils.RevisionAnnotation] = revision
+		return appstacksutils.CustomizePodWithSVCCertificate(&other.Spec.Template, instance, r.GetClient())
+	})
+	if err != nil {
+		return err
+	}
+	previewSvc := blueGreenPreviewService(instance)
+	err = r.CreateOrUpdate(previewSvc, instance, func() error {
+		appstacksutils.CustomizeService(previewSvc, instance)
+		previewSvc.Spec.Type = corev1.ServiceTypeClusterIP
+		previewSvc.Spec.Selector[appstacksutils.ColorLabel] = otherColor
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	if !appstacksutils.IsDeploymentReady(other) {
+		r.setBlueGreenCondition(instance, corev1.ConditionTrue, "PreviewNotReady", "Waiting for the pods of revision "+revision+" in Deployment "+other.Name+" to be ready.")
+		return nil
+	}
+	if !appstacksutils.IsBlueGreenAutoPromoted(blueGreen) && instance.Annotations[appstacksutils.PromoteAnnotation] != revision {
+		r.setBlueGreenCondition(instance, corev1.ConditionTrue, "AwaitingPromotion",
+			fmt.Sprintf("The pods of revision %s are ready in the preview Service %s. Set the %s annotation to %s to promote them.",
+				revision, previewSvc.Name, appstacksutils.PromoteAnnotation, revision))
+		return nil
+	}
+	return r.promoteBlueGreen(instance)
+}
+
+// startBlueGreen rolls out the first revision to the blue Deployment, which becomes active once it is ready. The pods
+// of a Deployment created by another strategy keep receiving the traffic until then.
+func (r *RuntimeComponentReconciler) startBlueGreen(instance *appstacksv1.RuntimeComponent, revision string) error {
+	status := instance.Status.BlueGreen
+	delete(instance.Status.References, common.StatusReferenceDeploymentName)
+	blue := blueGreenDeployment(instance, appstacksutils.Blue)
+	err := r.CreateOrUpdate(blue, instance, func() error {
+		appstacksutils.CustomizeBlueGreenDeployment(blue, instance, appstacksutils.Blue)
+		blue.Annotations[appstacksutils.RevisionAnnotation] = revision
+		return appstacksutils.CustomizePodWithSVCCertificate(&blue.Spec.Template, instance, r.GetClient())
+	})
+	if err != nil {
+		return err
+	}
+	if !appstacksutils.IsDeploymentReady(blue) {
+		r.setBlueGreenCondition(instance, corev1.ConditionTrue, "PreviewNotReady", "Waiting for the pods of revision "+revision+" in Deployment "+blue.Name+" to be ready.")
+		return nil
+	}
+
+	status.ActiveColor, status.ActiveRevision, status.PreviewRevision = appstacksutils.Blue, revision, ""
+	msg := "Revision " + revision + " of Deployment " + blue.Name + " receives the traffic."
+	r.setBlueGreenCondition(instance, corev1.ConditionFalse, "Promoted", msg)
+	if err := r.switchBlueGreenService(instance); err != nil {
+		return err
+	}
+	r.GetRecorder().Event(instance, "Normal", "BlueGreenPromoted", msg)
+	return r.DeleteResources([]client.Object{
+		&appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Name: instance.Name, Namespace: instance.Namespace}},
+		blueGreenDeployment(instance, appstacksutils.Green),
+	})
+}
+
+// promoteBlueGreen switches the traffic to the preview Deployment and keeps the previous one for the rollback window
+func (r *RuntimeComponentReconciler) promoteBlueGreen(instance *appstacksv1.RuntimeComponent) error {
+	status := instance.Status.BlueGreen
+	previousColor := status.ActiveColor
+	status.PreviousRevision = status.ActiveRevision
+	status.ActiveColor, status.ActiveRevision, status.PreviewRevision = appstacksutils.GetOtherColor(previousColor), status.PreviewRevision, ""
+	window := appstacksutils.GetBlueGreenRollbackWindow(instance.Spec.Deployment.BlueGreen)
+	if window == 0 {
+		status.PreviousRevision = ""
+	} else {
+		deadline := metav1.NewTime(time.Now().Add(window))
+		status.RollbackDeadline = &deadline
+	}
+	msg := "Revision " + status.ActiveRevision + " of Deployment " + instance.Name + "-" + status.ActiveColor + " was promoted."
+	r.setBlueGreenCondition(instance, corev1.ConditionFalse, "Promoted", msg)
+	if err := r.switchBlueGreenService(instance); err != nil {
+		return err
+	}
+	r.GetRecorder().Event(instance, "Normal", "BlueGreenPromoted", msg)
+
+	if window == 0 {
+		return r.DeleteResources([]client.Object{blueGreenDeployment(instance, previousColor), blueGreenPreviewService(instance)})
+	}
+	return r.DeleteResource(blueGreenPreviewService(instance))
+}
+
+// rollbackBlueGreen switches the traffic back to the Deployment of the previous revision and deletes the other one
+func (r *RuntimeComponentReconciler) rollbackBlueGreen(instance *appstacksv1.RuntimeComponent) error {
+	status := instance.Status.BlueGreen
+	rolledBackColor := status.ActiveColor
+	status.RolledBackRevision = status.ActiveRevision
+	status.ActiveColor, status.ActiveRevision = appstacksutils.GetOtherColor(rolledBackColor), status.PreviousRevision
+	status.PreviousRevision, status.RollbackDeadline = "", nil
+	msg := "Revision " + status.RolledBackRevision + " was rolled back to revision " + status.ActiveRevision + "."
+	r.setBlueGreenCondition(instance, corev1.ConditionFalse, "RolledBack", msg)
+	if err := r.switchBlueGreenService(instance); err != nil {
+		return err
+	}
+	r.GetRecorder().Event(instance, "Warning", "BlueGreenRolledBack", msg)
+	return r.DeleteResource(blueGreenDeployment(instance, rolledBackColor))
+}
+
+// switchBlueGreenService selects the pods of the active color in the Service of the component. The Route or Ingress
+// of the component sends its traffic to this Service, so it is switched at once. The active color is saved in the
+// status first, so that the Deployment of the other color is not deleted while the status still refers to it.
+func (r *RuntimeComponentReconciler) switchBlueGreenService(instance *appstacksv1.RuntimeComponent) error {
+	instance.Status.SetReference(common.StatusReferenceDeploymentName, instance.Name+"-"+instance.Status.BlueGreen.ActiveColor)
+	if err := r.UpdateStatus(instance); err != nil {
+		return err
+	}
+	svc := &corev1.Service{}
+	err := r.GetClient().Get(context.TODO(), types.NamespacedName{Name: instance.Name, Namespace: instance.Namespace}, svc)
+	if err != nil {
+		return err
+	}
+	if svc.Spec.Selector == nil {
+		svc.Spec.Selector = map[string]string{}
+	}
+	svc.Spec.Selector[appstacksutils.ColorLabel] = instance.Status.BlueGreen.ActiveColor
+	return r.GetClient().Update(context.TODO(), svc)
+}
+
+func (r *RuntimeComponentReconciler) setBlueGreenCondition(instance *appstacksv1.RuntimeComponent, status corev1.ConditionStatus, reason string, msg string) {
+	condition := instance.Status.NewCondition(common.StatusConditionTypeBlueGreenProgressing)
+	condition.SetConditionFields(msg, reason, status)
+	instance.Status.SetCondition(condition)
+}
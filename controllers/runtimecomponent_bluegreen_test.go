@@ -0,0 +1,75 @@
+package controllers
+
+import (
+	"context"
+	"testing"
+
+	appstacksv1 "github.com/application-stacks/runtime-component-operator/api/v1"
+	"github.com/application-stacks/runtime-component-operator/utils"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/rest"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func TestPromoteBlueGreenSavesStatusFirst(t *testing.T) {
+	noRollbackWindow := int32(0)
+	instance := &appstacksv1.RuntimeComponent{ObjectMeta: metav1.ObjectMeta{Name: "my-app", Namespace: "bluegreen"}}
+	instance.Spec.Deployment = &appstacksv1.RuntimeComponentDeployment{
+		Strategy:  "BlueGreen",
+		BlueGreen: &appstacksv1.RuntimeComponentBlueGreen{RollbackWindowSeconds: &noRollbackWindow},
+	}
+	instance.Status.BlueGreen = &appstacksv1.RuntimeComponentBlueGreenStatus{ActiveColor: utils.Blue, ActiveRevision: "1", PreviewRevision: "2"}
+	svc := &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{Name: instance.Name, Namespace: instance.Namespace},
+		Spec:       corev1.ServiceSpec{Selector: map[string]string{utils.ColorLabel: utils.Blue}},
+	}
+	blue := blueGreenDeployment(instance, utils.Blue)
+	r, cl := createRuntimeComponentReconciler(instance, svc, blue, blueGreenDeployment(instance, utils.Green), blueGreenPreviewService(instance))
+
+	// The traffic is not switched and the blue Deployment is kept while the status still refers to it
+	failing := &RuntimeComponentReconciler{
+		ReconcilerBase: utils.NewReconcilerBase(cl, &failingStatusClient{Client: cl}, cl.Scheme(), &rest.Config{}, r.GetRecorder()),
+		Log:            r.Log,
+	}
+	if err := failing.promoteBlueGreen(getRuntimeComponent(t, cl, instance)); err == nil {
+		t.Error("expected the status update error to be returned")
+	}
+	if selector := getServiceSelectorColor(t, cl, svc); selector != utils.Blue {
+		t.Errorf("the Service was switched to %s before the status was saved", selector)
+	}
+	if err := cl.Get(context.TODO(), client.ObjectKeyFromObject(blue), &appsv1.Deployment{}); err != nil {
+		t.Errorf("the blue Deployment was deleted before the status was saved: %v", err)
+	}
+
+	if err := r.promoteBlueGreen(getRuntimeComponent(t, cl, instance)); err != nil {
+		t.Fatal(err)
+	}
+	if color := getRuntimeComponent(t, cl, instance).Status.BlueGreen.ActiveColor; color != utils.Green {
+		t.Errorf("expected the active color %s to be saved in the status, got %s", utils.Green, color)
+	}
+	if selector := getServiceSelectorColor(t, cl, svc); selector != utils.Green {
+		t.Errorf("expected the Service to select the %s pods, got %s", utils.Green, selector)
+	}
+	verifyDeleted(t, cl, blue)
+	verifyDeleted(t, cl, blueGreenPreviewService(instance))
+}
+
+func getRuntimeComponent(t *testing.T, cl client.Client, instance *appstacksv1.RuntimeComponent) *appstacksv1.RuntimeComponent {
+	t.Helper()
+	result := &appstacksv1.RuntimeComponent{}
+	if err := cl.Get(context.TODO(), client.ObjectKeyFromObject(instance), result); err != nil {
+		t.Fatal(err)
+	}
+	return result
+}
+
+func getServiceSelectorColor(t *testing.T, cl client.Client, svc *corev1.Service) string {
+	t.Helper()
+	result := &corev1.Service{}
+	if err := cl.Get(context.TODO(), client.ObjectKeyFromObject(svc), result); err != nil {
+		t.Fatal(err)
+	}
+	return result.Spec.Selector[utils.ColorLabel]
+}
@@ -0,0 +1,177 @@
+/*
+
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	appstacksv1 "github.com/application-stacks/runtime-component-operator/api/v1"
+	"github.com/application-stacks/runtime-component-operator/common"
+	appstacksutils "github.com/application-stacks/runtime-component-operator/utils"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// reconcileCanary rolls out a new application image to the canary Deployment of the instance, step by step, and
+// returns the image to keep in the stable Deployment. An empty image means that the stable Deployment gets the image
+// of the spec, either because there is no rollout in progress or because the rollout was promoted.
+func (r *RuntimeComponentReconciler) reconcileCanary(instance *appstacksv1.RuntimeComponent) (string, error) {
+	canaryDeploy := &appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Name: instance.Name + "-canary", Namespace: instance.Namespace}}
+
+	var canary *appstacksv1.RuntimeComponentCanary
+	if instance.Spec.Deployment != nil {
+		canary = instance.Spec.Deployment.Canary
+	}
+	if canary == nil {
+		instance.Status.Canary = nil
+		return "", r.DeleteResource(canaryDeploy)
+	}
+
+	stable := &appsv1.Deployment{}
+	err := r.GetClient().Get(context.TODO(), types.NamespacedName{Name: instance.Name, Namespace: instance.Namespace}, stable)
+	if kerrors.IsNotFound(err) {
+		// The first image is deployed without a canary
+		instance.Status.Canary = nil
+		return "", r.DeleteResource(canaryDeploy)
+	} else if err != nil {
+		return "", err
+	}
+
+	stableImage := appstacksutils.GetAppContainerImage(&stable.Spec.Template)
+	image := instance.Status.ImageReference
+	if instance.Status.Canary == nil {
+		instance.Status.Canary = &appstacksv1.RuntimeComponentCanaryStatus{}
+	}
+	status := instance.Status.Canary
+
+	if image == stableImage || image == status.RolledBackImage {
+		if status.Image != "" {
+			r.setCanaryCondition(instance, corev1.ConditionFalse, "Aborted", "The canary rollout of "+status.Image+" was aborted because the application image changed to "+image+".")
+		}
+		status.Image, status.CurrentStep, status.StepStartTime = "", 0, nil
+		if image == stableImage {
+			// A rolled back image is rolled out again once the application image was changed
+			status.RolledBackImage = ""
+		}
+		return stableImage, r.DeleteResource(canaryDeploy)
+	}
+
+	now := metav1.Now()
+	if status.Image != image {
+		status.Image, status.CurrentStep, status.StepStartTime = image, 0, &now
+		r.GetRecorder().Event(instance, "Normal", "CanaryStarted", "Started the canary rollout of "+image+".")
+	}
+	if int(status.CurrentStep) >= len(canary.Steps) {
+		// The steps were removed during the rollout
+		return r.promoteCanary(instance, canaryDeploy)
+	}
+	step := canary.Steps[status.CurrentStep]
+	stepMsg := fmt.Sprintf("Step %d of %d: ", status.CurrentStep+1, len(canary.Steps))
+
+	stableReplicas := int32(1)
+	if stable.Spec.Replicas != nil {
+		stableReplicas = *stable.Spec.Replicas
+	}
+	err = r.CreateOrUpdate(canaryDeploy, instance, func() error {
+		appstacksutils.CustomizeCanaryDeployment(canaryDeploy, instance, appstacksutils.GetCanaryReplicas(stableReplicas, step.Weight))
+		return appstacksutils.CustomizePodWithSVCCertificate(&canaryDeploy.Spec.Template, instance, r.GetClient())
+	})
+	if err != nil {
+		return stableImage, err
+	}
+
+	deadline := appstacksutils.GetCanaryProgressDeadline(canary)
+	elapsed := time.Since(status.StepStartTime.Time)
+	if !appstacksutils.IsDeploymentReady(canaryDeploy) {
+		if elapsed > deadline {
+			return stableImage, r.rollbackCanary(instance, canaryDeploy, "ProgressDeadlineExceeded",
+				fmt.Sprintf("The canary pods of %s were not ready after %s.", image, deadline))
+		}
+		r.setCanaryCondition(instance, corev1.ConditionTrue, "CanaryNotReady", stepMsg+"waiting for the canary pods of "+image+" to be ready.")
+		return stableImage, nil
+	}
+
+	var pause time.Duration
+	if step.Pause != nil {
+		pause = step.Pause.Duration
+	}
+	trafficMsg := fmt.Sprintf("%sthe canary pods of %s receive %d%% of the traffic.", stepMsg, image, step.Weight)
+	if elapsed < pause {
+		r.setCanaryCondition(instance, corev1.ConditionTrue, "Progressing", trafficMsg)
+		return stableImage, nil
+	}
+
+	if analysis := canary.Analysis; analysis != nil {
+		value, err := appstacksutils.QueryPrometheus(context.TODO(), analysis.PrometheusURL, analysis.Query)
+		if err != nil {
+			if elapsed > pause+deadline {
+				return stableImage, r.rollbackCanary(instance, canaryDeploy, "AnalysisFailed",
+					fmt.Sprintf("The analysis of the canary pods of %s could not be evaluated: %v", image, err))
+			}
+			r.setCanaryCondition(instance, corev1.ConditionTrue, "AnalysisPending", trafficMsg+" The analysis could not be evaluated: "+err.Error())
+			return stableImage, nil
+		}
+		maxValue, _ := strconv.ParseFloat(analysis.MaxValue, 64)
+		if value > maxValue {
+			return stableImage, r.rollbackCanary(instance, canaryDeploy, "AnalysisFailed",
+				fmt.Sprintf("The analysis of the canary pods of %s returned %g, which is greater than %s.", image, value, analysis.MaxValue))
+		}
+	}
+
+	status.CurrentStep++
+	status.StepStartTime = &now
+	if int(status.CurrentStep) >= len(canary.Steps) {
+		return r.promoteCanary(instance, canaryDeploy)
+	}
+	r.GetRecorder().Event(instance, "Normal", "CanaryStepCompleted", fmt.Sprintf("Step %d of %d of the canary rollout of %s is completed.", status.CurrentStep, len(canary.Steps), image))
+	r.setCanaryCondition(instance, corev1.ConditionTrue, "Progressing", fmt.Sprintf("Step %d of %d: the canary pods of %s receive %d%% of the traffic.",
+		status.CurrentStep+1, len(canary.Steps), image, canary.Steps[status.CurrentStep].Weight))
+	return stableImage, nil
+}
+
+// promoteCanary deletes the canary Deployment, so that the stable Deployment gets the image of the spec
+func (r *RuntimeComponentReconciler) promoteCanary(instance *appstacksv1.RuntimeComponent, canaryDeploy *appsv1.Deployment) (string, error) {
+	status := instance.Status.Canary
+	msg := "The canary rollout of " + status.Image + " was promoted."
+	status.Image, status.CurrentStep, status.StepStartTime = "", 0, nil
+	r.setCanaryCondition(instance, corev1.ConditionFalse, "Promoted", msg)
+	r.GetRecorder().Event(instance, "Normal", "CanaryPromoted", msg)
+	return "", r.DeleteResource(canaryDeploy)
+}
+
+// rollbackCanary deletes the canary Deployment and keeps the stable Deployment until the application image changes
+func (r *RuntimeComponentReconciler) rollbackCanary(instance *appstacksv1.RuntimeComponent, canaryDeploy *appsv1.Deployment, reason string, msg string) error {
+	status := instance.Status.Canary
+	status.RolledBackImage = status.Image
+	status.Image, status.CurrentStep, status.StepStartTime = "", 0, nil
+	msg = msg + " The canary rollout was rolled back."
+	r.setCanaryCondition(instance, corev1.ConditionFalse, reason, msg)
+	r.GetRecorder().Event(instance, "Warning", "CanaryRolledBack", msg)
+	return r.DeleteResource(canaryDeploy)
+}
+
+func (r *RuntimeComponentReconciler) setCanaryCondition(instance *appstacksv1.RuntimeComponent, status corev1.ConditionStatus, reason string, msg string) {
+	condition := instance.Status.NewCondition(common.StatusConditionTypeCanaryProgressing)
+	condition.SetConditionFields(msg, reason, status)
+	instance.Status.SetCondition(condition)
+}
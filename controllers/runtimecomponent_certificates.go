@@ -0,0 +1,143 @@
+/*
+
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"crypto/x509"
+	"fmt"
+	"time"
+
+	appstacksv1 "github.com/application-stacks/runtime-component-operator/api/v1"
+	"github.com/application-stacks/runtime-component-operator/common"
+	appstacksutils "github.com/application-stacks/runtime-component-operator/utils"
+	certmanagerv1 "github.com/jetstack/cert-manager/pkg/apis/certmanager/v1"
+	certmanagermetav1 "github.com/jetstack/cert-manager/pkg/apis/meta/v1"
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+// checkCertificates parses the certificates of the service and of the Route or Ingress of the instance and reports
+// the earliest expiry in the CertificatesReady condition. A warning event is recorded when the certificates fail to
+// renew, expire, or cross one of the expiry warning thresholds of the operator configuration.
+func (r *RuntimeComponentReconciler) checkCertificates(instance *appstacksv1.RuntimeComponent, config *common.OperatorConfig) {
+	var names []string
+	for _, ref := range []string{common.StatusReferenceCertSecretName, common.StatusReferenceRouteCertSecretName} {
+		if name := instance.Status.References[ref]; name != "" && !appstacksutils.ContainsString(names, name) {
+			names = append(names, name)
+		}
+	}
+	if len(names) == 0 {
+		delete(instance.Status.References, common.StatusReferenceCertExpiryWarning)
+		removeCondition(&instance.Status, appstacksv1.StatusConditionTypeCertificatesReady)
+		return
+	}
+
+	certManagerSupported, _ := r.IsGroupVersionSupported(certmanagerv1.SchemeGroupVersion.String(), "Certificate")
+	now := time.Now()
+	var earliest *x509.Certificate
+	var earliestSecret, reason, msg string
+	for _, name := range names {
+		secret := &corev1.Secret{}
+		err := r.GetClient().Get(context.TODO(), types.NamespacedName{Name: name, Namespace: instance.Namespace}, secret)
+		if kerrors.IsNotFound(err) {
+			reason, msg = "SecretNotFound", fmt.Sprintf("Secret %q was not found.", name)
+			break
+		} else if err != nil {
+			reason, msg = "SecretNotFound", fmt.Sprintf("Failed to get Secret %q: %v", name, err)
+			break
+		}
+		cert, err := appstacksutils.ParseSecretCertificate(secret)
+		if err != nil {
+			reason, msg = "InvalidCertificate", err.Error()
+			break
+		}
+		if earliest == nil || cert.NotAfter.Before(earliest.NotAfter) {
+			earliest, earliestSecret = cert, name
+		}
+
+		// A cert-manager Certificate that is not ready failed to issue or renew the certificate of its Secret
+		if certManagerSupported && reason == "" {
+			certificate := &certmanagerv1.Certificate{}
+			if err := r.GetClient().Get(context.TODO(), types.NamespacedName{Name: name, Namespace: instance.Namespace}, certificate); err == nil {
+				for _, c := range certificate.Status.Conditions {
+					if c.Type == certmanagerv1.CertificateConditionReady && c.Status == certmanagermetav1.ConditionFalse {
+						reason, msg = "RenewalFailed", fmt.Sprintf("Certificate %q is not ready: %s", name, c.Message)
+					}
+				}
+			}
+		}
+	}
+	if reason == "" && now.After(earliest.NotAfter) {
+		reason, msg = "Expired", fmt.Sprintf("The certificate in Secret %q expired at %s.", earliestSecret, earliest.NotAfter.UTC().Format(time.RFC3339))
+	}
+
+	oldCondition := instance.Status.GetCondition(common.StatusConditionTypeCertificatesReady)
+	condition := instance.Status.NewCondition(common.StatusConditionTypeCertificatesReady)
+	if reason != "" {
+		if reason != "SecretNotFound" && (oldCondition == nil || oldCondition.GetReason() != reason) {
+			r.GetRecorder().Event(instance, "Warning", "Certificate"+reason, msg)
+		}
+		condition.SetConditionFields(msg, reason, corev1.ConditionFalse)
+		instance.Status.SetCondition(condition)
+		return
+	}
+
+	msg = fmt.Sprintf("The earliest certificate, in Secret %q, expires at %s.", earliestSecret, earliest.NotAfter.UTC().Format(time.RFC3339))
+	reason = "Valid"
+	if threshold := appstacksutils.GetCertExpiryWarningThreshold(earliest.NotAfter.Sub(now), config.CertExpiryWarningThresholds); threshold > 0 {
+		reason = "ExpiringSoon"
+		if instance.Status.References[common.StatusReferenceCertExpiryWarning] != threshold.String() {
+			r.GetRecorder().Event(instance, "Warning", "CertificateExpiring", fmt.Sprintf("The certificate in Secret %q expires in less than %s, at %s.", earliestSecret, threshold, earliest.NotAfter.UTC().Format(time.RFC3339)))
+			instance.Status.SetReference(common.StatusReferenceCertExpiryWarning, threshold.String())
+		}
+	} else {
+		delete(instance.Status.References, common.StatusReferenceCertExpiryWarning)
+	}
+	condition.SetConditionFields(msg, reason, corev1.ConditionTrue)
+	instance.Status.SetCondition(condition)
+}
+
+// removeCondition removes the condition of the given type from the status
+func removeCondition(s *appstacksv1.RuntimeComponentStatus, conditionType appstacksv1.StatusConditionType) {
+	for i := range s.Conditions {
+		if s.Conditions[i].Type == conditionType {
+			s.Conditions = append(s.Conditions[:i], s.Conditions[i+1:]...)
+			return
+		}
+	}
+}
+
+// certificateSecretRequests returns the RuntimeComponents of the namespace of the Secret that reference it as the
+// Secret of their service certificate or of their Route or Ingress certificate
+func certificateSecretRequests(c client.Client, secret client.Object) []reconcile.Request {
+	components := &appstacksv1.RuntimeComponentList{}
+	if err := c.List(context.Background(), components, client.InNamespace(secret.GetNamespace())); err != nil {
+		return nil
+	}
+	var requests []reconcile.Request
+	for i := range components.Items {
+		refs := components.Items[i].Status.References
+		if refs[common.StatusReferenceCertSecretName] == secret.GetName() || refs[common.StatusReferenceRouteCertSecretName] == secret.GetName() {
+			requests = append(requests, reconcile.Request{NamespacedName: types.NamespacedName{Name: components.Items[i].Name, Namespace: secret.GetNamespace()}})
+		}
+	}
+	return requests
+}
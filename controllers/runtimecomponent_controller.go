@@ -20,13 +20,15 @@ import (
 	"context"
 	"fmt"
 	"os"
+	"reflect"
 	"strings"
+	"time"
 
 	"github.com/application-stacks/runtime-component-operator/common"
 	"github.com/pkg/errors"
 
-	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 	"sigs.k8s.io/controller-runtime/pkg/source"
 
@@ -39,7 +41,8 @@ import (
 
 	ctrl "sigs.k8s.io/controller-runtime"
 
-	appstacksv1beta2 "github.com/application-stacks/runtime-component-operator/api/v1beta2"
+	appstacksv1 "github.com/application-stacks/runtime-component-operator/api/v1"
+	certmanagerv1 "github.com/jetstack/cert-manager/pkg/apis/certmanager/v1"
 	imagev1 "github.com/openshift/api/image/v1"
 	routev1 "github.com/openshift/api/route/v1"
 	"github.com/openshift/library-go/pkg/image/imageutil"
@@ -48,8 +51,10 @@ import (
 	autoscalingv1 "k8s.io/api/autoscaling/v1"
 	corev1 "k8s.io/api/core/v1"
 	networkingv1 "k8s.io/api/networking/v1"
+	policyv1 "k8s.io/api/policy/v1"
 	kerrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/types"
 	servingv1 "knative.dev/serving/pkg/apis/serving/v1"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -58,20 +63,26 @@ import (
 // RuntimeComponentReconciler reconciles a RuntimeComponent object
 type RuntimeComponentReconciler struct {
 	appstacksutils.ReconcilerBase
-	Log             logr.Logger
-	watchNamespaces []string
+	Log    logr.Logger
+	Config *appstacksutils.OperatorConfigWatcher
 }
 
 // +kubebuilder:rbac:groups=rc.app.stacks,resources=runtimecomponents;runtimecomponents/status;runtimecomponents/finalizers,verbs=get;list;watch;create;update;delete,namespace=runtime-component-operator
 // +kubebuilder:rbac:groups=apps,resources=deployments;statefulsets,verbs=get;list;watch;create;update;delete,namespace=runtime-component-operator
 // +kubebuilder:rbac:groups=apps,resources=deployments/finalizers;statefulsets,verbs=update,namespace=runtime-component-operator
 // +kubebuilder:rbac:groups=core,resources=services;secrets;serviceaccounts;configmaps,verbs=get;list;watch;create;update;delete,namespace=runtime-component-operator
+// +kubebuilder:rbac:groups=core,resources=pods,verbs=get;list;watch;delete,namespace=runtime-component-operator
 // +kubebuilder:rbac:groups=autoscaling,resources=horizontalpodautoscalers,verbs=get;list;watch;create;update;delete,namespace=runtime-component-operator
 // +kubebuilder:rbac:groups=networking.k8s.io,resources=ingresses;networkpolicies,verbs=get;list;watch;create;update;delete,namespace=runtime-component-operator
 // +kubebuilder:rbac:groups=route.openshift.io,resources=routes;routes/custom-host,verbs=get;list;watch;create;update;delete,namespace=runtime-component-operator
 // +kubebuilder:rbac:groups=image.openshift.io,resources=imagestreams;imagestreamtags,verbs=get;list;watch,namespace=runtime-component-operator
 // +kubebuilder:rbac:groups=serving.knative.dev,resources=services,verbs=get;list;watch;create;update;delete,namespace=runtime-component-operator
 // +kubebuilder:rbac:groups=monitoring.coreos.com,resources=servicemonitors,verbs=get;list;watch;create;update;delete,namespace=runtime-component-operator
+// +kubebuilder:rbac:groups=keda.sh,resources=scaledobjects,verbs=get;list;watch;create;update;delete,namespace=runtime-component-operator
+// +kubebuilder:rbac:groups=autoscaling.k8s.io,resources=verticalpodautoscalers,verbs=get;list;watch;create;update;delete,namespace=runtime-component-operator
+// +kubebuilder:rbac:groups=policy,resources=poddisruptionbudgets,verbs=get;list;watch;create;update;delete,namespace=runtime-component-operator
+// +kubebuilder:rbac:groups=gateway.networking.k8s.io,resources=httproutes,verbs=get;list;watch;create;update;delete,namespace=runtime-component-operator
+// +kubebuilder:rbac:groups=gateway.networking.k8s.io,resources=gateways,verbs=get
 // +kubebuilder:rbac:groups=cert-manager.io,resources=certificates;issuers,verbs=get;list;watch;create;update;delete,namespace=runtime-component-operator
 
 // Reconcile is part of the main kubernetes reconciliation loop which aims to
@@ -80,60 +91,42 @@ func (r *RuntimeComponentReconciler) Reconcile(ctx context.Context, req ctrl.Req
 
 	reqLogger := r.Log.WithValues("Request.Namespace", req.Namespace, "Request.Name", req.Name)
 	reqLogger.Info("Reconciling RuntimeComponent")
+	reconcileStart := time.Now()
 
-	ns, err := appstacksutils.GetOperatorNamespace()
-	// When running the operator locally, `ns` will be empty string
-	if ns == "" {
-		// Since this method can be called directly from unit test, populate `watchNamespaces`.
-		if r.watchNamespaces == nil {
-			r.watchNamespaces, err = appstacksutils.GetWatchNamespaces()
-			if err != nil {
-				reqLogger.Error(err, "Error getting watch namespace")
-				return reconcile.Result{}, err
-			}
-		}
-		// If the operator is running locally, use the first namespace in the `watchNamespaces`
-		// `watchNamespaces` must have at least one item
-		ns = r.watchNamespaces[0]
-	}
-
-	configMap, err := r.GetOpConfigMap("runtime-component-operator", ns)
-	if err != nil {
-		reqLogger.Info("Failed to find runtime-component-operator config map")
-		common.Config = common.DefaultOpConfig()
-		configMap = &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "runtime-component-operator", Namespace: ns}}
-		configMap.Data = common.Config
-	} else {
-		common.Config.LoadFromConfigMap(configMap)
-	}
-
-	_, err = controllerutil.CreateOrUpdate(context.TODO(), r.GetClient(), configMap, func() error {
-		configMap.Data = common.Config
-		return nil
-	})
-
-	if err != nil {
-		reqLogger.Info("Failed to update runtime-component-operator config map")
-	}
+	// The configuration of the operator stays the same for the whole reconciliation
+	config := r.Config.Get()
 
 	// Fetch the RuntimeComponent instance
-	instance := &appstacksv1beta2.RuntimeComponent{}
+	instance := &appstacksv1.RuntimeComponent{}
 	var ba common.BaseComponent = instance
-	err = r.GetClient().Get(context.TODO(), req.NamespacedName, instance)
+	err := r.GetClient().Get(context.TODO(), req.NamespacedName, instance)
 	if err != nil {
 		if kerrors.IsNotFound(err) {
 			// Request object not found, could have been deleted after reconcile request.
 			// Owned objects are automatically garbage collected. For additional cleanup logic use finalizers.
 			// Return and don't requeue
+			appstacksutils.DeleteComponentMetrics(req.Namespace, req.Name)
 			return reconcile.Result{}, nil
 		}
 		// Error reading the object - requeue the request.
 		return reconcile.Result{}, err
 	}
+	defer func() {
+		appstacksutils.ObserveReconcileDuration(req.Namespace, req.Name, time.Since(reconcileStart))
+	}()
 
 	// initialize the RuntimeComponent instance
 	instance.Initialize()
 	_, err = appstacksutils.Validate(instance)
+	if err == nil {
+		err = appstacksutils.ValidateCanary(instance)
+	}
+	if err == nil {
+		err = appstacksutils.ValidateBlueGreen(instance)
+	}
+	if err == nil {
+		err = appstacksutils.ValidateAutoRollback(instance)
+	}
 	// If there's any validation error, don't bother with requeuing
 	if err != nil {
 		reqLogger.Error(err, "Error validating RuntimeComponent")
@@ -231,34 +224,19 @@ func (r *RuntimeComponentReconciler) Reconcile(ctx context.Context, req ctrl.Req
 	}
 
 	if instance.Spec.CreateKnativeService != nil && *instance.Spec.CreateKnativeService {
-		// Clean up non-Knative resources
-		resources := []client.Object{
-			&corev1.Service{ObjectMeta: defaultMeta},
-			&corev1.Service{ObjectMeta: metav1.ObjectMeta{Name: instance.Name + "-headless", Namespace: instance.Namespace}},
-			&appsv1.Deployment{ObjectMeta: defaultMeta},
-			&appsv1.StatefulSet{ObjectMeta: defaultMeta},
-			&autoscalingv1.HorizontalPodAutoscaler{ObjectMeta: defaultMeta},
-		}
-		err = r.DeleteResources(resources)
+		err = r.deleteNonKnativeResources(instance, defaultMeta)
 		if err != nil {
 			reqLogger.Error(err, "Failed to clean up non-Knative resources")
 			return r.ManageError(err, common.StatusConditionTypeReconciled, instance)
 		}
 
-		if ok, _ := r.IsGroupVersionSupported(networkingv1.SchemeGroupVersion.String(), "Ingress"); ok {
-			r.DeleteResource(&networkingv1.Ingress{ObjectMeta: defaultMeta})
-		}
-
-		if r.IsOpenShift() {
-			route := &routev1.Route{ObjectMeta: defaultMeta}
-			err = r.DeleteResource(route)
+		if isKnativeSupported {
+			err = r.reconcileTrustBundle(instance)
 			if err != nil {
-				reqLogger.Error(err, "Failed to clean up non-Knative resource Route")
+				reqLogger.Error(err, "Failed to reconcile the trust bundle ConfigMap")
 				return r.ManageError(err, common.StatusConditionTypeReconciled, instance)
 			}
-		}
 
-		if isKnativeSupported {
 			ksvc := &servingv1.Service{ObjectMeta: defaultMeta}
 			err = r.CreateOrUpdate(ksvc, instance, func() error {
 				appstacksutils.CustomizeKnativeService(ksvc, instance)
@@ -269,11 +247,13 @@ func (r *RuntimeComponentReconciler) Reconcile(ctx context.Context, req ctrl.Req
 				reqLogger.Error(err, "Failed to reconcile Knative Service")
 				return r.ManageError(err, common.StatusConditionTypeReconciled, instance)
 			}
+			reportKnativeEndpoints(instance, ksvc)
 			return r.ManageSuccess(common.StatusConditionTypeReconciled, instance)
 		}
 		return r.ManageError(errors.New("failed to reconcile Knative service as operator could not find Knative CRDs"), common.StatusConditionTypeReconciled, instance)
 	}
 
+	reportKnativeEndpoints(instance, nil)
 	if isKnativeSupported {
 		ksvc := &servingv1.Service{ObjectMeta: defaultMeta}
 		err = r.DeleteResource(ksvc)
@@ -283,7 +263,7 @@ func (r *RuntimeComponentReconciler) Reconcile(ctx context.Context, req ctrl.Req
 		}
 	}
 
-	useCertmanager, err := r.GenerateSvcCertSecret(ba, "rco", "Runtime Component Operator", "runtime-component-operator")
+	useCertmanager, err := r.GenerateSvcCertSecret(ba, "rco", "Runtime Component Operator", "runtime-component-operator", config)
 	if err != nil {
 		reqLogger.Error(err, "Failed to reconcile CertManager Certificate")
 		return r.ManageError(err, common.StatusConditionTypeReconciled, instance)
@@ -291,10 +271,30 @@ func (r *RuntimeComponentReconciler) Reconcile(ctx context.Context, req ctrl.Req
 	if ba.GetService().GetCertificateSecretRef() != nil {
 		ba.GetStatus().SetReference(common.StatusReferenceCertSecretName, *ba.GetService().GetCertificateSecretRef())
 	}
+	if useCertmanager {
+		err = r.publishTrustBundle(instance, "rco", "runtime-component-operator")
+		if err != nil {
+			reqLogger.Error(err, "Failed to publish the trust bundle of the namespace")
+			return r.ManageError(err, common.StatusConditionTypeReconciled, instance)
+		}
+	}
+	err = r.reconcileTrustBundle(instance)
+	if err != nil {
+		reqLogger.Error(err, "Failed to reconcile the trust bundle ConfigMap")
+		return r.ManageError(err, common.StatusConditionTypeReconciled, instance)
+	}
+	err = r.reconcileMTLS(instance, "rco", config)
+	if err != nil {
+		reqLogger.Error(err, "Failed to reconcile mutual TLS")
+		return r.ManageError(err, common.StatusConditionTypeReconciled, instance)
+	}
 
 	svc := &corev1.Service{ObjectMeta: defaultMeta}
 	err = r.CreateOrUpdate(svc, instance, func() error {
 		appstacksutils.CustomizeService(svc, ba)
+		if appstacksutils.IsBlueGreenEnabled(instance) && instance.Status.BlueGreen != nil && instance.Status.BlueGreen.ActiveColor != "" {
+			svc.Spec.Selector[appstacksutils.ColorLabel] = instance.Status.BlueGreen.ActiveColor
+		}
 		svc.Annotations = appstacksutils.MergeMaps(svc.Annotations, instance.Spec.Service.Annotations)
 		if !useCertmanager && r.IsOpenShift() {
 			appstacksutils.AddOCPCertAnnotation(ba, svc)
@@ -322,6 +322,10 @@ func (r *RuntimeComponentReconciler) Reconcile(ctx context.Context, req ctrl.Req
 			reqLogger.Error(err, "Failed to reconcile network policy")
 			return r.ManageError(err, common.StatusConditionTypeReconciled, instance)
 		}
+		if appstacksutils.IsMTLSNetworkPolicyAllowingAll(r.IsOpenShift(), instance) {
+			r.GetRecorder().Event(instance, "Warning", "AllowedCallersNotEnforced",
+				"Network policy "+networkPolicy.Name+" allows the traffic from all the pods because the component is exposed and the ingress controller cannot be selected on Kubernetes. Only the mutual TLS authentication restricts the callers.")
+		}
 	} else {
 		if err := r.DeleteResource(networkPolicy); err != nil {
 			reqLogger.Error(err, "Failed to delete network policy")
@@ -335,9 +339,14 @@ func (r *RuntimeComponentReconciler) Reconcile(ctx context.Context, req ctrl.Req
 	}
 
 	if instance.Spec.StatefulSet != nil {
-		// Delete Deployment if exists
+		// Delete Deployments if exist
 		deploy := &appsv1.Deployment{ObjectMeta: defaultMeta}
-		err = r.DeleteResource(deploy)
+		canaryDeploy := &appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Name: instance.Name + "-canary", Namespace: instance.Namespace}}
+		err = r.DeleteResources([]client.Object{deploy, canaryDeploy})
+		instance.Status.Canary = nil
+		if err == nil {
+			err = r.deleteBlueGreen(instance)
+		}
 
 		if err != nil {
 			reqLogger.Error(err, "Failed to delete Deployment")
@@ -356,9 +365,18 @@ func (r *RuntimeComponentReconciler) Reconcile(ctx context.Context, req ctrl.Req
 		}
 
 		statefulSet := &appsv1.StatefulSet{ObjectMeta: defaultMeta}
+		rollbackImage, err := r.reconcileAutoRollback(instance, statefulSet)
+		if err != nil {
+			reqLogger.Error(err, "Failed to reconcile the rollback of the StatefulSet")
+			return r.ManageError(err, common.StatusConditionTypeReconciled, instance)
+		}
 		err = r.CreateOrUpdate(statefulSet, instance, func() error {
 			appstacksutils.CustomizeStatefulSet(statefulSet, instance)
 			appstacksutils.CustomizePodSpec(&statefulSet.Spec.Template, instance)
+			if rollbackImage != "" {
+				// The image of the spec failed to roll out
+				appstacksutils.SetAppContainerImage(&statefulSet.Spec.Template, rollbackImage)
+			}
 			if err := appstacksutils.CustomizePodWithSVCCertificate(&statefulSet.Spec.Template, instance, r.GetClient()); err != nil {
 				return err
 			}
@@ -387,34 +405,103 @@ func (r *RuntimeComponentReconciler) Reconcile(ctx context.Context, req ctrl.Req
 			reqLogger.Error(err, "Failed to delete headless Service")
 			return r.ManageError(err, common.StatusConditionTypeReconciled, instance)
 		}
-		deploy := &appsv1.Deployment{ObjectMeta: defaultMeta}
-		err = r.CreateOrUpdate(deploy, instance, func() error {
-			appstacksutils.CustomizeDeployment(deploy, instance)
-			appstacksutils.CustomizePodSpec(&deploy.Spec.Template, instance)
-			if err := appstacksutils.CustomizePodWithSVCCertificate(&deploy.Spec.Template, instance, r.GetClient()); err != nil {
-				return err
+		if appstacksutils.IsBlueGreenEnabled(instance) {
+			instance.Status.Canary, instance.Status.Rollout = nil, nil
+			err = r.DeleteResource(&appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Name: instance.Name + "-canary", Namespace: instance.Namespace}})
+			if err == nil {
+				err = r.reconcileBlueGreen(instance)
 			}
+			if err != nil {
+				reqLogger.Error(err, "Failed to reconcile blue/green Deployments")
+				return r.ManageError(err, common.StatusConditionTypeReconciled, instance)
+			}
+		} else {
+			stableImage, err := r.reconcileCanary(instance)
+			if err != nil {
+				reqLogger.Error(err, "Failed to reconcile canary Deployment")
+				return r.ManageError(err, common.StatusConditionTypeReconciled, instance)
+			}
+			deploy := &appsv1.Deployment{ObjectMeta: defaultMeta}
+			rollbackImage, err := r.reconcileAutoRollback(instance, deploy)
+			if err != nil {
+				reqLogger.Error(err, "Failed to reconcile the rollback of the Deployment")
+				return r.ManageError(err, common.StatusConditionTypeReconciled, instance)
+			}
+			err = r.CreateOrUpdate(deploy, instance, func() error {
+				appstacksutils.CustomizeDeployment(deploy, instance)
+				appstacksutils.CustomizePodSpec(&deploy.Spec.Template, instance)
+				if stableImage != "" {
+					// The image of the spec is rolled out to the canary Deployment
+					appstacksutils.SetAppContainerImage(&deploy.Spec.Template, stableImage)
+				} else if rollbackImage != "" {
+					// The image of the spec failed to roll out
+					appstacksutils.SetAppContainerImage(&deploy.Spec.Template, rollbackImage)
+				}
+				if err := appstacksutils.CustomizePodWithSVCCertificate(&deploy.Spec.Template, instance, r.GetClient()); err != nil {
+					return err
+				}
+				return nil
+			})
+			if err != nil {
+				reqLogger.Error(err, "Failed to reconcile Deployment")
+				return r.ManageError(err, common.StatusConditionTypeReconciled, instance)
+			}
+			// The pods of the blue/green Deployments keep receiving the traffic until the Deployment is ready
+			if instance.Status.BlueGreen != nil && appstacksutils.IsDeploymentReady(deploy) {
+				err = r.deleteBlueGreen(instance)
+				if err != nil {
+					reqLogger.Error(err, "Failed to delete blue/green Deployments")
+					return r.ManageError(err, common.StatusConditionTypeReconciled, instance)
+				}
+			}
+		}
+	}
+
+	// A ScaledObject manages its own HorizontalPodAutoscaler, so only one of them is kept
+	scaledObject := &unstructured.Unstructured{}
+	scaledObject.SetGroupVersionKind(appstacksutils.ScaledObjectGVK)
+	scaledObject.SetName(defaultMeta.Name)
+	scaledObject.SetNamespace(defaultMeta.Namespace)
+	kedaSupported, err := r.IsGroupVersionSupported(appstacksutils.ScaledObjectGVK.GroupVersion().String(), appstacksutils.ScaledObjectGVK.Kind)
+	if err != nil {
+		reqLogger.Error(err, fmt.Sprintf("Failed to check if %s is supported", appstacksutils.ScaledObjectGVK.GroupVersion().String()))
+		return r.ManageError(err, common.StatusConditionTypeReconciled, instance)
+	}
+	if instance.Spec.EventDrivenAutoscaling != nil {
+		if !kedaSupported {
+			err = errors.New("failed to reconcile the ScaledObject: KEDA is not installed, the " + appstacksutils.ScaledObjectGVK.GroupVersion().String() + " ScaledObject kind is not available")
+			reqLogger.Error(err, "Failed to reconcile ScaledObject")
+			return r.ManageError(err, common.StatusConditionTypeReconciled, instance)
+		}
+		hpa := &autoscalingv1.HorizontalPodAutoscaler{ObjectMeta: defaultMeta}
+		err = r.DeleteResource(hpa)
+		if err != nil {
+			reqLogger.Error(err, "Failed to delete HorizontalPodAutoscaler")
+			return r.ManageError(err, common.StatusConditionTypeReconciled, instance)
+		}
+		err = r.CreateOrUpdate(scaledObject, instance, func() error {
+			appstacksutils.CustomizeScaledObject(scaledObject, instance)
 			return nil
 		})
 		if err != nil {
-			reqLogger.Error(err, "Failed to reconcile Deployment")
+			reqLogger.Error(err, "Failed to reconcile ScaledObject")
+			return r.ManageError(err, common.StatusConditionTypeReconciled, instance)
+		}
+	} else if kedaSupported {
+		err = r.DeleteResource(scaledObject)
+		if err != nil {
+			reqLogger.Error(err, "Failed to delete ScaledObject")
 			return r.ManageError(err, common.StatusConditionTypeReconciled, instance)
 		}
-
 	}
 
 	if instance.Spec.Autoscaling != nil {
-		hpa := &autoscalingv1.HorizontalPodAutoscaler{ObjectMeta: defaultMeta}
-		err = r.CreateOrUpdate(hpa, instance, func() error {
-			appstacksutils.CustomizeHPA(hpa, instance)
-			return nil
-		})
-
+		err = r.CreateOrUpdateHPA(instance, defaultMeta, instance)
 		if err != nil {
 			reqLogger.Error(err, "Failed to reconcile HorizontalPodAutoscaler")
 			return r.ManageError(err, common.StatusConditionTypeReconciled, instance)
 		}
-	} else {
+	} else if instance.Spec.EventDrivenAutoscaling == nil {
 		hpa := &autoscalingv1.HorizontalPodAutoscaler{ObjectMeta: defaultMeta}
 		err = r.DeleteResource(hpa)
 		if err != nil {
@@ -423,18 +510,107 @@ func (r *RuntimeComponentReconciler) Reconcile(ctx context.Context, req ctrl.Req
 		}
 	}
 
+	vpa := &unstructured.Unstructured{}
+	vpa.SetGroupVersionKind(appstacksutils.VerticalPodAutoscalerGVK)
+	vpa.SetName(defaultMeta.Name)
+	vpa.SetNamespace(defaultMeta.Namespace)
+	vpaSupported, err := r.IsGroupVersionSupported(appstacksutils.VerticalPodAutoscalerGVK.GroupVersion().String(), appstacksutils.VerticalPodAutoscalerGVK.Kind)
+	if err != nil {
+		reqLogger.Error(err, fmt.Sprintf("Failed to check if %s is supported", appstacksutils.VerticalPodAutoscalerGVK.GroupVersion().String()))
+		return r.ManageError(err, common.StatusConditionTypeReconciled, instance)
+	}
+	if instance.Spec.VerticalAutoscaling != nil {
+		if !vpaSupported {
+			err = errors.New("failed to reconcile the VerticalPodAutoscaler: the " + appstacksutils.VerticalPodAutoscalerGVK.GroupVersion().String() + " VerticalPodAutoscaler kind is not available")
+			reqLogger.Error(err, "Failed to reconcile VerticalPodAutoscaler")
+			return r.ManageError(err, common.StatusConditionTypeReconciled, instance)
+		}
+		err = r.CreateOrUpdate(vpa, instance, func() error {
+			appstacksutils.CustomizeVPA(vpa, instance)
+			return nil
+		})
+		if err != nil {
+			reqLogger.Error(err, "Failed to reconcile VerticalPodAutoscaler")
+			return r.ManageError(err, common.StatusConditionTypeReconciled, instance)
+		}
+	} else if vpaSupported {
+		err = r.DeleteResource(vpa)
+		if err != nil {
+			reqLogger.Error(err, "Failed to delete VerticalPodAutoscaler")
+			return r.ManageError(err, common.StatusConditionTypeReconciled, instance)
+		}
+	}
+
+	if ok, err := r.IsGroupVersionSupported(policyv1.SchemeGroupVersion.String(), "PodDisruptionBudget"); err != nil {
+		reqLogger.Error(err, fmt.Sprintf("Failed to check if %s is supported", policyv1.SchemeGroupVersion.String()))
+		r.ManageError(err, common.StatusConditionTypeReconciled, instance)
+	} else if ok {
+		pdb := &policyv1.PodDisruptionBudget{ObjectMeta: defaultMeta}
+		if appstacksutils.IsDisruptionBudgetEnabled(instance) {
+			err = r.CreateOrUpdate(pdb, instance, func() error {
+				appstacksutils.CustomizePDB(pdb, instance)
+				return nil
+			})
+			if err != nil {
+				reqLogger.Error(err, "Failed to reconcile PodDisruptionBudget")
+				return r.ManageError(err, common.StatusConditionTypeReconciled, instance)
+			}
+		} else {
+			err = r.DeleteResource(pdb)
+			if err != nil {
+				reqLogger.Error(err, "Failed to delete PodDisruptionBudget")
+				return r.ManageError(err, common.StatusConditionTypeReconciled, instance)
+			}
+		}
+	} else if instance.Spec.DisruptionBudget != nil {
+		reqLogger.V(1).Info(fmt.Sprintf("%s is not supported, the PodDisruptionBudget is not created", policyv1.SchemeGroupVersion.String()))
+	}
+
+	httpRoute := &unstructured.Unstructured{}
+	httpRoute.SetGroupVersionKind(appstacksutils.HTTPRouteGVK)
+	httpRoute.SetName(defaultMeta.Name)
+	httpRoute.SetNamespace(defaultMeta.Namespace)
+	gatewaySupported, err := r.IsGroupVersionSupported(appstacksutils.HTTPRouteGVK.GroupVersion().String(), appstacksutils.HTTPRouteGVK.Kind)
+	if err != nil {
+		reqLogger.Error(err, fmt.Sprintf("Failed to check if %s is supported", appstacksutils.HTTPRouteGVK.GroupVersion().String()))
+		return r.ManageError(err, common.StatusConditionTypeReconciled, instance)
+	}
+	if instance.Spec.Gateway != nil && instance.Spec.Expose != nil && *instance.Spec.Expose {
+		if !gatewaySupported {
+			err = errors.New("failed to reconcile the HTTPRoute: the Gateway API is not installed, the " + appstacksutils.HTTPRouteGVK.GroupVersion().String() + " HTTPRoute kind is not available")
+			reqLogger.Error(err, "Failed to reconcile HTTPRoute")
+			return r.ManageError(err, common.StatusConditionTypeReconciled, instance)
+		}
+		err = r.CreateOrUpdate(httpRoute, instance, func() error {
+			appstacksutils.CustomizeHTTPRoute(httpRoute, instance, config.DefaultHostname)
+			return nil
+		})
+		if err != nil {
+			reqLogger.Error(err, "Failed to reconcile HTTPRoute")
+			return r.ManageError(err, common.StatusConditionTypeReconciled, instance)
+		}
+	} else if gatewaySupported {
+		err = r.DeleteResource(httpRoute)
+		if err != nil {
+			reqLogger.Error(err, "Failed to delete HTTPRoute")
+			return r.ManageError(err, common.StatusConditionTypeReconciled, instance)
+		}
+	}
+
+	// The certificate Secret of the Route or Ingress is referenced again below when it is used
+	delete(instance.Status.References, common.StatusReferenceRouteCertSecretName)
 	if ok, err := r.IsGroupVersionSupported(routev1.SchemeGroupVersion.String(), "Route"); err != nil {
 		reqLogger.Error(err, fmt.Sprintf("Failed to check if %s is supported", routev1.SchemeGroupVersion.String()))
 		r.ManageError(err, common.StatusConditionTypeReconciled, instance)
 	} else if ok {
-		if instance.Spec.Expose != nil && *instance.Spec.Expose {
+		if instance.Spec.Expose != nil && *instance.Spec.Expose && instance.Spec.Gateway == nil {
 			route := &routev1.Route{ObjectMeta: defaultMeta}
 			err = r.CreateOrUpdate(route, instance, func() error {
 				key, cert, caCert, destCACert, err := r.GetRouteTLSValues(ba)
 				if err != nil {
 					return err
 				}
-				appstacksutils.CustomizeRoute(route, ba, key, cert, caCert, destCACert)
+				appstacksutils.CustomizeRoute(route, ba, key, cert, caCert, destCACert, config.DefaultHostname)
 
 				return nil
 			})
@@ -442,6 +618,9 @@ func (r *RuntimeComponentReconciler) Reconcile(ctx context.Context, req ctrl.Req
 				reqLogger.Error(err, "Failed to reconcile Route")
 				return r.ManageError(err, common.StatusConditionTypeReconciled, instance)
 			}
+			if rt := ba.GetRoute(); rt != nil && rt.GetCertificateSecretRef() != nil && *rt.GetCertificateSecretRef() != "" {
+				instance.Status.SetReference(common.StatusReferenceRouteCertSecretName, *rt.GetCertificateSecretRef())
+			}
 		} else {
 			route := &routev1.Route{ObjectMeta: defaultMeta}
 			err = r.DeleteResource(route)
@@ -456,16 +635,19 @@ func (r *RuntimeComponentReconciler) Reconcile(ctx context.Context, req ctrl.Req
 			reqLogger.Error(err, fmt.Sprintf("Failed to check if %s is supported", networkingv1.SchemeGroupVersion.String()))
 			r.ManageError(err, common.StatusConditionTypeReconciled, instance)
 		} else if ok {
-			if instance.Spec.Expose != nil && *instance.Spec.Expose {
+			if instance.Spec.Expose != nil && *instance.Spec.Expose && instance.Spec.Gateway == nil {
 				ing := &networkingv1.Ingress{ObjectMeta: defaultMeta}
 				err = r.CreateOrUpdate(ing, instance, func() error {
-					appstacksutils.CustomizeIngress(ing, instance)
+					appstacksutils.CustomizeIngress(ing, instance, config.DefaultHostname)
 					return nil
 				})
 				if err != nil {
 					reqLogger.Error(err, "Failed to reconcile Ingress")
 					return r.ManageError(err, common.StatusConditionTypeReconciled, instance)
 				}
+				if secretName := appstacksutils.GetIngressCertSecretName(instance); secretName != "" {
+					instance.Status.SetReference(common.StatusReferenceRouteCertSecretName, secretName)
+				}
 			} else {
 				ing := &networkingv1.Ingress{ObjectMeta: defaultMeta}
 				err = r.DeleteResource(ing)
@@ -474,6 +656,40 @@ func (r *RuntimeComponentReconciler) Reconcile(ctx context.Context, req ctrl.Req
 					return r.ManageError(err, common.StatusConditionTypeReconciled, instance)
 				}
 			}
+
+			ingressCert := &certmanagerv1.Certificate{ObjectMeta: metav1.ObjectMeta{Name: instance.Name + "-ingress-tls", Namespace: instance.Namespace}}
+			certManagerSupported, err := r.IsGroupVersionSupported(certmanagerv1.SchemeGroupVersion.String(), "Certificate")
+			if err != nil {
+				reqLogger.Error(err, fmt.Sprintf("Failed to check if %s is supported", certmanagerv1.SchemeGroupVersion.String()))
+				return r.ManageError(err, common.StatusConditionTypeReconciled, instance)
+			}
+			if instance.Spec.Expose != nil && *instance.Spec.Expose && instance.Spec.Gateway == nil &&
+				instance.Spec.Route != nil && instance.Spec.Route.CertificateIssuerRef != nil {
+				if !certManagerSupported {
+					err = errors.New("failed to reconcile the Ingress certificate: cert-manager is not installed, the " + certmanagerv1.SchemeGroupVersion.String() + " Certificate kind is not available")
+					reqLogger.Error(err, "Failed to reconcile Ingress Certificate")
+					return r.ManageError(err, common.StatusConditionTypeReconciled, instance)
+				}
+				if appstacksutils.GetIngressHosts(instance, config.DefaultHostname)[0] == "" {
+					err = errors.New("failed to reconcile the Ingress certificate: spec.route.certificateIssuerRef requires spec.route.host, or the " + common.OpConfigDefaultHostname + " of the operator configuration")
+					reqLogger.Error(err, "Failed to reconcile Ingress Certificate")
+					return r.ManageError(err, common.StatusConditionTypeReconciled, instance)
+				}
+				err = r.CreateOrUpdate(ingressCert, instance, func() error {
+					appstacksutils.CustomizeIngressCertificate(ingressCert, instance, config.DefaultHostname)
+					return nil
+				})
+				if err != nil {
+					reqLogger.Error(err, "Failed to reconcile Ingress Certificate")
+					return r.ManageError(err, common.StatusConditionTypeReconciled, instance)
+				}
+			} else if certManagerSupported {
+				err = r.DeleteResource(ingressCert)
+				if err != nil {
+					reqLogger.Error(err, "Failed to delete Ingress Certificate")
+					return r.ManageError(err, common.StatusConditionTypeReconciled, instance)
+				}
+			}
 		}
 	}
 
@@ -504,6 +720,8 @@ func (r *RuntimeComponentReconciler) Reconcile(ctx context.Context, req ctrl.Req
 		reqLogger.V(1).Info(fmt.Sprintf("%s is not supported", prometheusv1.SchemeGroupVersion.String()))
 	}
 
+	r.checkCertificates(instance, config)
+
 	reqLogger.Info("Reconcile RuntimeComponent - completed")
 	return r.ManageSuccess(common.StatusConditionTypeReconciled, instance)
 }
@@ -511,8 +729,8 @@ func (r *RuntimeComponentReconciler) Reconcile(ctx context.Context, req ctrl.Req
 // SetupWithManager initializes reconciler
 func (r *RuntimeComponentReconciler) SetupWithManager(mgr ctrl.Manager) error {
 
-	mgr.GetFieldIndexer().IndexField(context.Background(), &appstacksv1beta2.RuntimeComponent{}, indexFieldImageStreamName, func(obj client.Object) []string {
-		instance := obj.(*appstacksv1beta2.RuntimeComponent)
+	mgr.GetFieldIndexer().IndexField(context.Background(), &appstacksv1.RuntimeComponent{}, indexFieldImageStreamName, func(obj client.Object) []string {
+		instance := obj.(*appstacksv1.RuntimeComponent)
 		image, err := imageutil.ParseDockerImageReference(instance.Spec.ApplicationImage)
 		if err == nil {
 			imageNamespace := image.Namespace
@@ -584,7 +802,7 @@ func (r *RuntimeComponentReconciler) SetupWithManager(mgr ctrl.Manager) error {
 		},
 	}
 
-	b := ctrl.NewControllerManagedBy(mgr).For(&appstacksv1beta2.RuntimeComponent{}, builder.WithPredicates(pred)).
+	b := ctrl.NewControllerManagedBy(mgr).For(&appstacksv1.RuntimeComponent{}, builder.WithPredicates(pred)).
 		Owns(&corev1.Service{}, builder.WithPredicates(predSubResource)).
 		Owns(&corev1.Secret{}, builder.WithPredicates(predSubResource)).
 		Owns(&appsv1.Deployment{}, builder.WithPredicates(predSubResWithGenCheck)).
@@ -607,6 +825,28 @@ func (r *RuntimeComponentReconciler) SetupWithManager(mgr ctrl.Manager) error {
 	if ok {
 		b = b.Owns(&prometheusv1.ServiceMonitor{}, builder.WithPredicates(predSubResource))
 	}
+	ok, _ = r.IsGroupVersionSupported(appstacksutils.ScaledObjectGVK.GroupVersion().String(), appstacksutils.ScaledObjectGVK.Kind)
+	if ok {
+		scaledObject := &unstructured.Unstructured{}
+		scaledObject.SetGroupVersionKind(appstacksutils.ScaledObjectGVK)
+		b = b.Owns(scaledObject, builder.WithPredicates(predSubResource))
+	}
+	ok, _ = r.IsGroupVersionSupported(appstacksutils.VerticalPodAutoscalerGVK.GroupVersion().String(), appstacksutils.VerticalPodAutoscalerGVK.Kind)
+	if ok {
+		vpa := &unstructured.Unstructured{}
+		vpa.SetGroupVersionKind(appstacksutils.VerticalPodAutoscalerGVK)
+		b = b.Owns(vpa, builder.WithPredicates(predSubResource))
+	}
+	ok, _ = r.IsGroupVersionSupported(appstacksutils.HTTPRouteGVK.GroupVersion().String(), appstacksutils.HTTPRouteGVK.Kind)
+	if ok {
+		httpRoute := &unstructured.Unstructured{}
+		httpRoute.SetGroupVersionKind(appstacksutils.HTTPRouteGVK)
+		b = b.Owns(httpRoute, builder.WithPredicates(predSubResource))
+	}
+	ok, _ = r.IsGroupVersionSupported(policyv1.SchemeGroupVersion.String(), "PodDisruptionBudget")
+	if ok {
+		b = b.Owns(&policyv1.PodDisruptionBudget{}, builder.WithPredicates(predSubResource))
+	}
 	ok, _ = r.IsGroupVersionSupported(imagev1.SchemeGroupVersion.String(), "ImageStream")
 	if ok {
 		b = b.Watches(&source.Kind{Type: &imagev1.ImageStream{}}, &EnqueueRequestsForCustomIndexField{
@@ -616,6 +856,60 @@ func (r *RuntimeComponentReconciler) SetupWithManager(mgr ctrl.Manager) error {
 			},
 		})
 	}
+	// Reconcile the RuntimeComponents that mount a trust bundle again when it changes
+	b = b.Watches(&source.Kind{Type: &corev1.ConfigMap{}}, handler.EnqueueRequestsFromMapFunc(func(cm client.Object) []reconcile.Request {
+		return trustBundleRequests(mgr.GetClient(), cm)
+	}), builder.WithPredicates(predicate.Funcs{
+		UpdateFunc: func(e event.UpdateEvent) bool {
+			return isClusterWide || watchNamespacesMap[e.ObjectNew.GetNamespace()]
+		},
+		CreateFunc: func(e event.CreateEvent) bool {
+			return isClusterWide || watchNamespacesMap[e.Object.GetNamespace()]
+		},
+		DeleteFunc: func(e event.DeleteEvent) bool {
+			return false
+		},
+		GenericFunc: func(e event.GenericEvent) bool {
+			return false
+		},
+	}))
+	// Reconcile the RuntimeComponents that use a certificate Secret again when its content changes, so that their
+	// status is updated and their pods are rolled, and the RuntimeComponents that allow a caller again when its client
+	// certificate changes
+	isWatchedSecret := func(secret client.Object) bool {
+		return isClusterWide || watchNamespacesMap[secret.GetNamespace()] || strings.HasSuffix(secret.GetName(), appstacksutils.ClientCertSecretSuffix)
+	}
+	b = b.Watches(&source.Kind{Type: &corev1.Secret{}}, handler.EnqueueRequestsFromMapFunc(func(secret client.Object) []reconcile.Request {
+		return append(certificateSecretRequests(mgr.GetClient(), secret), mtlsCallerRequests(mgr.GetClient(), secret)...)
+	}), builder.WithPredicates(predicate.Funcs{
+		UpdateFunc: func(e event.UpdateEvent) bool {
+			oldSecret, oldOK := e.ObjectOld.(*corev1.Secret)
+			newSecret, newOK := e.ObjectNew.(*corev1.Secret)
+			return oldOK && newOK && isWatchedSecret(newSecret) && !reflect.DeepEqual(oldSecret.Data, newSecret.Data)
+		},
+		CreateFunc: func(e event.CreateEvent) bool {
+			return isWatchedSecret(e.Object)
+		},
+		DeleteFunc: func(e event.DeleteEvent) bool {
+			return false
+		},
+		GenericFunc: func(e event.GenericEvent) bool {
+			return false
+		},
+	}))
+	// Reconcile all RuntimeComponents again when the operator configuration changes
+	b = b.Watches(&source.Channel{Source: r.Config.Changes()}, handler.EnqueueRequestsFromMapFunc(func(client.Object) []reconcile.Request {
+		components := &appstacksv1.RuntimeComponentList{}
+		if err := mgr.GetClient().List(context.Background(), components); err != nil {
+			r.Log.Error(err, "Failed to list RuntimeComponents after a change of the operator configuration")
+			return nil
+		}
+		requests := make([]reconcile.Request, 0, len(components.Items))
+		for i := range components.Items {
+			requests = append(requests, reconcile.Request{NamespacedName: types.NamespacedName{Name: components.Items[i].Name, Namespace: components.Items[i].Namespace}})
+		}
+		return requests
+	}))
 	return b.Complete(r)
 }
 
@@ -0,0 +1,133 @@
+/*
+
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"strings"
+
+	appstacksv1 "github.com/application-stacks/runtime-component-operator/api/v1"
+	"github.com/application-stacks/runtime-component-operator/common"
+	appstacksutils "github.com/application-stacks/runtime-component-operator/utils"
+	routev1 "github.com/openshift/api/route/v1"
+	appsv1 "k8s.io/api/apps/v1"
+	autoscalingv1 "k8s.io/api/autoscaling/v1"
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	policyv1 "k8s.io/api/policy/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	servingv1 "knative.dev/serving/pkg/apis/serving/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const knativeEndpointName = "Knative"
+
+// deleteNonKnativeResources deletes the resources of the instance that are replaced by its Knative service, and
+// resets the status of its rollouts
+func (r *RuntimeComponentReconciler) deleteNonKnativeResources(instance *appstacksv1.RuntimeComponent, defaultMeta metav1.ObjectMeta) error {
+	resources := []client.Object{
+		&corev1.Service{ObjectMeta: defaultMeta},
+		&corev1.Service{ObjectMeta: metav1.ObjectMeta{Name: instance.Name + "-headless", Namespace: instance.Namespace}},
+		&appsv1.Deployment{ObjectMeta: defaultMeta},
+		&appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Name: instance.Name + "-canary", Namespace: instance.Namespace}},
+		&appsv1.StatefulSet{ObjectMeta: defaultMeta},
+		&autoscalingv1.HorizontalPodAutoscaler{ObjectMeta: defaultMeta},
+	}
+	// The PodDisruptionBudget would select the pods of the Knative service
+	if ok, _ := r.IsGroupVersionSupported(policyv1.SchemeGroupVersion.String(), "PodDisruptionBudget"); ok {
+		resources = append(resources, &policyv1.PodDisruptionBudget{ObjectMeta: defaultMeta})
+	}
+	err := r.DeleteResources(append(resources, blueGreenResources(instance)...))
+	instance.Status.BlueGreen = nil
+	instance.Status.Rollout = nil
+	if err != nil {
+		return err
+	}
+
+	if ok, _ := r.IsGroupVersionSupported(networkingv1.SchemeGroupVersion.String(), "Ingress"); ok {
+		r.DeleteResource(&networkingv1.Ingress{ObjectMeta: defaultMeta})
+	}
+
+	if ok, _ := r.IsGroupVersionSupported(appstacksutils.HTTPRouteGVK.GroupVersion().String(), appstacksutils.HTTPRouteGVK.Kind); ok {
+		httpRoute := &unstructured.Unstructured{}
+		httpRoute.SetGroupVersionKind(appstacksutils.HTTPRouteGVK)
+		httpRoute.SetName(defaultMeta.Name)
+		httpRoute.SetNamespace(defaultMeta.Namespace)
+		r.DeleteResource(httpRoute)
+	}
+
+	// The ScaledObject would keep scaling the deleted Deployment or StatefulSet
+	if ok, _ := r.IsGroupVersionSupported(appstacksutils.ScaledObjectGVK.GroupVersion().String(), appstacksutils.ScaledObjectGVK.Kind); ok {
+		scaledObject := &unstructured.Unstructured{}
+		scaledObject.SetGroupVersionKind(appstacksutils.ScaledObjectGVK)
+		scaledObject.SetName(defaultMeta.Name)
+		scaledObject.SetNamespace(defaultMeta.Namespace)
+		if err := r.DeleteResource(scaledObject); err != nil {
+			return err
+		}
+	}
+
+	// The VerticalPodAutoscaler would keep targeting the deleted Deployment or StatefulSet
+	if ok, _ := r.IsGroupVersionSupported(appstacksutils.VerticalPodAutoscalerGVK.GroupVersion().String(), appstacksutils.VerticalPodAutoscalerGVK.Kind); ok {
+		vpa := &unstructured.Unstructured{}
+		vpa.SetGroupVersionKind(appstacksutils.VerticalPodAutoscalerGVK)
+		vpa.SetName(defaultMeta.Name)
+		vpa.SetNamespace(defaultMeta.Namespace)
+		if err := r.DeleteResource(vpa); err != nil {
+			return err
+		}
+	}
+
+	if r.IsOpenShift() {
+		return r.DeleteResource(&routev1.Route{ObjectMeta: defaultMeta})
+	}
+	return nil
+}
+
+// reportKnativeEndpoints reports the URL of the Knative service and the URLs of its tagged revisions in the status
+// endpoints of the instance. A nil Knative service removes the endpoints.
+func reportKnativeEndpoints(instance *appstacksv1.RuntimeComponent, ksvc *servingv1.Service) {
+	scope := common.StatusEndpointScopeInternal
+	if instance.Spec.Expose != nil && *instance.Spec.Expose {
+		scope = common.StatusEndpointScopeExternal
+	}
+
+	var endpoints []common.StatusEndpoint
+	s := &instance.Status
+	if ksvc != nil {
+		if ksvc.Status.URL != nil {
+			endpoints = append(endpoints, s.NewStatusEndpoint(knativeEndpointName).SetStatusEndpointFields(scope, "Application", ksvc.Status.URL.String()))
+		}
+		for _, t := range ksvc.Status.Traffic {
+			if t.Tag != "" && t.URL != nil {
+				endpoints = append(endpoints, s.NewStatusEndpoint(knativeEndpointName+"-"+t.Tag).SetStatusEndpointFields(scope, "Revision", t.URL.String()))
+			}
+		}
+	}
+
+	reported := map[string]bool{}
+	for _, endpoint := range endpoints {
+		reported[endpoint.GetEndpointName()] = true
+		s.SetStatusEndpoint(endpoint)
+	}
+	for i := len(s.Endpoints) - 1; i >= 0; i-- {
+		name := s.Endpoints[i].Name
+		if !reported[name] && (name == knativeEndpointName || strings.HasPrefix(name, knativeEndpointName+"-")) {
+			s.RemoveStatusEndpoint(name)
+		}
+	}
+}
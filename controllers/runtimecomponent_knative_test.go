@@ -0,0 +1,81 @@
+package controllers
+
+import (
+	"context"
+	"testing"
+
+	appstacksv1 "github.com/application-stacks/runtime-component-operator/api/v1"
+	"github.com/application-stacks/runtime-component-operator/utils"
+	policyv1 "k8s.io/api/policy/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	fakediscovery "k8s.io/client-go/discovery/fake"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/rest"
+	coretesting "k8s.io/client-go/testing"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	fakeclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/log/zap"
+)
+
+func TestDeleteNonKnativeResources(t *testing.T) {
+	instance := &appstacksv1.RuntimeComponent{ObjectMeta: metav1.ObjectMeta{Name: "my-app", Namespace: "knative"}}
+	defaultMeta := metav1.ObjectMeta{Name: instance.Name, Namespace: instance.Namespace}
+	pdb := &policyv1.PodDisruptionBudget{ObjectMeta: defaultMeta}
+	scaledObject := &unstructured.Unstructured{}
+	scaledObject.SetGroupVersionKind(utils.ScaledObjectGVK)
+	scaledObject.SetName(instance.Name)
+	scaledObject.SetNamespace(instance.Namespace)
+	vpa := &unstructured.Unstructured{}
+	vpa.SetGroupVersionKind(utils.VerticalPodAutoscalerGVK)
+	vpa.SetName(instance.Name)
+	vpa.SetNamespace(instance.Namespace)
+	r, cl := createRuntimeComponentReconciler(instance, pdb, scaledObject, vpa)
+
+	// The component with a PodDisruptionBudget, a ScaledObject and a VerticalPodAutoscaler is switched to Knative
+	if err := r.deleteNonKnativeResources(instance, defaultMeta); err != nil {
+		t.Fatal(err)
+	}
+	verifyDeleted(t, cl, pdb)
+	verifyDeleted(t, cl, scaledObject)
+	verifyDeleted(t, cl, vpa)
+}
+
+func createRuntimeComponentReconciler(objs ...client.Object) (*RuntimeComponentReconciler, client.Client) {
+	s := runtime.NewScheme()
+	clientgoscheme.AddToScheme(s)
+	appstacksv1.AddToScheme(s)
+	cl := fakeclient.NewClientBuilder().WithScheme(s).WithObjects(objs...).Build()
+	r := &RuntimeComponentReconciler{
+		ReconcilerBase: utils.NewReconcilerBase(cl, cl, s, &rest.Config{}, record.NewFakeRecorder(100)),
+		Log:            zap.New(),
+	}
+	discovery := &fakediscovery.FakeDiscovery{Fake: &coretesting.Fake{}}
+	discovery.Resources = []*metav1.APIResourceList{
+		{
+			GroupVersion: policyv1.SchemeGroupVersion.String(),
+			APIResources: []metav1.APIResource{{Name: "poddisruptionbudgets", Namespaced: true, Kind: "PodDisruptionBudget"}},
+		},
+		{
+			GroupVersion: utils.ScaledObjectGVK.GroupVersion().String(),
+			APIResources: []metav1.APIResource{{Name: "scaledobjects", Namespaced: true, Kind: utils.ScaledObjectGVK.Kind}},
+		},
+		{
+			GroupVersion: utils.VerticalPodAutoscalerGVK.GroupVersion().String(),
+			APIResources: []metav1.APIResource{{Name: "verticalpodautoscalers", Namespaced: true, Kind: utils.VerticalPodAutoscalerGVK.Kind}},
+		},
+	}
+	r.SetDiscoveryClient(discovery)
+	return r, cl
+}
+
+// verifyDeleted checks that the object does not exist anymore
+func verifyDeleted(t *testing.T, cl client.Client, obj client.Object) {
+	t.Helper()
+	if err := cl.Get(context.TODO(), client.ObjectKeyFromObject(obj), obj); !kerrors.IsNotFound(err) {
+		t.Errorf("%T %s was not deleted: %v", obj, obj.GetName(), err)
+	}
+}
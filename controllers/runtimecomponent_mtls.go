@@ -0,0 +1,141 @@
+/*
+
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	appstacksv1 "github.com/application-stacks/runtime-component-operator/api/v1"
+	"github.com/application-stacks/runtime-component-operator/common"
+	appstacksutils "github.com/application-stacks/runtime-component-operator/utils"
+	certmanagerv1 "github.com/jetstack/cert-manager/pkg/apis/certmanager/v1"
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+// reconcileMTLS issues the client certificate of the instance and configures the mutual TLS ConfigMap with the CA
+// certificates and the SPIFFE IDs of its allowed callers. The resource version of the ConfigMap is recorded so that
+// the pods are rolled when it changes.
+func (r *RuntimeComponentReconciler) reconcileMTLS(instance *appstacksv1.RuntimeComponent, prefix string, config *common.OperatorConfig) error {
+	clientCert := &certmanagerv1.Certificate{ObjectMeta: metav1.ObjectMeta{Name: instance.Name + appstacksutils.ClientCertSecretSuffix, Namespace: instance.Namespace}}
+	cm := &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: instance.Name + "-mtls", Namespace: instance.Namespace}}
+
+	certManagerSupported, err := r.IsGroupVersionSupported(certmanagerv1.SchemeGroupVersion.String(), "Certificate")
+	if err != nil {
+		return err
+	}
+	mtls := instance.Spec.MTLS
+	if mtls == nil || len(mtls.AllowedCallers) == 0 {
+		delete(instance.Status.References, common.StatusReferenceMTLSResourceVersion)
+		if err := r.DeleteResource(cm); err != nil {
+			return err
+		}
+	}
+	if mtls == nil {
+		delete(instance.Status.References, common.StatusReferenceClientCertSecretName)
+		if certManagerSupported {
+			return r.DeleteResource(clientCert)
+		}
+		return nil
+	}
+	if !certManagerSupported {
+		return errors.New("mutual TLS requires cert-manager to issue the client certificates")
+	}
+
+	// The client certificate is signed by the issuer of the service certificate
+	issuerRef, _ := appstacksutils.GetSvcCertIssuerRef(instance, prefix, config)
+	err = r.CreateOrUpdate(clientCert, instance, func() error {
+		appstacksutils.CustomizeClientCertificate(clientCert, instance, issuerRef, config)
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	instance.Status.SetReference(common.StatusReferenceClientCertSecretName, clientCert.Spec.SecretName)
+	if len(mtls.AllowedCallers) == 0 {
+		return nil
+	}
+
+	// The server trusts the CA of its own client certificate and the CAs of the client certificates of its callers
+	secrets := []types.NamespacedName{{Name: clientCert.Spec.SecretName, Namespace: instance.Namespace}}
+	var callers []types.NamespacedName
+	for _, caller := range mtls.GetAllowedCallers() {
+		callers = append(callers, types.NamespacedName{
+			Name:      caller.GetName() + appstacksutils.ClientCertSecretSuffix,
+			Namespace: appstacksutils.GetMTLSCallerNamespace(caller, instance.Namespace),
+		})
+	}
+	sort.Slice(callers, func(i, j int) bool { return callers[i].String() < callers[j].String() })
+	var cas [][]byte
+	for _, name := range append(secrets, callers...) {
+		// The callers might be in namespaces that are not watched, so their secrets are not read from the cache
+		secret := &corev1.Secret{}
+		err := r.GetAPIReader().Get(context.TODO(), name, secret)
+		if kerrors.IsNotFound(err) {
+			continue
+		} else if kerrors.IsForbidden(err) {
+			return fmt.Errorf("the operator is not allowed to read the client certificate Secret %s of an allowed caller, the namespace %s must be watched by the operator", name.Name, name.Namespace)
+		} else if err != nil {
+			return err
+		}
+		if len(secret.Data["ca.crt"]) > 0 {
+			cas = append(cas, secret.Data["ca.crt"])
+		}
+	}
+	err = r.CreateOrUpdate(cm, instance, func() error {
+		return appstacksutils.CustomizeMTLSConfigMap(cm, instance, config.MTLSTrustDomain, cas)
+	})
+	if err != nil {
+		return err
+	}
+	instance.Status.SetReference(common.StatusReferenceMTLSResourceVersion, cm.ResourceVersion)
+	return nil
+}
+
+// mtlsCallerRequests returns the RuntimeComponents that allow the component of the client certificate Secret to call them
+func mtlsCallerRequests(c client.Client, secret client.Object) []reconcile.Request {
+	if !strings.HasSuffix(secret.GetName(), appstacksutils.ClientCertSecretSuffix) {
+		return nil
+	}
+	callerName := strings.TrimSuffix(secret.GetName(), appstacksutils.ClientCertSecretSuffix)
+	components := &appstacksv1.RuntimeComponentList{}
+	if err := c.List(context.Background(), components); err != nil {
+		return nil
+	}
+	var requests []reconcile.Request
+	for i := range components.Items {
+		component := &components.Items[i]
+		if component.Spec.MTLS == nil {
+			continue
+		}
+		for _, caller := range component.Spec.MTLS.GetAllowedCallers() {
+			if caller.GetName() == callerName && appstacksutils.GetMTLSCallerNamespace(caller, component.Namespace) == secret.GetNamespace() {
+				requests = append(requests, reconcile.Request{NamespacedName: types.NamespacedName{Name: component.Name, Namespace: component.Namespace}})
+				break
+			}
+		}
+	}
+	return requests
+}
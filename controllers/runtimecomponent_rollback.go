@@ -0,0 +1,148 @@
+/*
+
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	appstacksv1 "github.com/application-stacks/runtime-component-operator/api/v1"
+	"github.com/application-stacks/runtime-component-operator/common"
+	appstacksutils "github.com/application-stacks/runtime-component-operator/utils"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// reconcileAutoRollback records the last healthy pods of the Deployment or StatefulSet of the instance, and returns the
+// image to roll back to when the pods of a new application image fail to become ready. An empty image means that the
+// workload gets the image of the spec.
+func (r *RuntimeComponentReconciler) reconcileAutoRollback(instance *appstacksv1.RuntimeComponent, workload client.Object) (string, error) {
+	err := r.GetClient().Get(context.TODO(), types.NamespacedName{Name: workload.GetName(), Namespace: workload.GetNamespace()}, workload)
+	if kerrors.IsNotFound(err) {
+		return "", nil
+	} else if err != nil {
+		return "", err
+	}
+
+	var template *corev1.PodTemplateSpec
+	var ready bool
+	switch w := workload.(type) {
+	case *appsv1.Deployment:
+		template, ready = &w.Spec.Template, appstacksutils.IsDeploymentReady(w)
+	case *appsv1.StatefulSet:
+		template, ready = &w.Spec.Template, appstacksutils.IsStatefulSetReady(w)
+	default:
+		return "", fmt.Errorf("unsupported workload %T", workload)
+	}
+
+	if instance.Status.Rollout == nil {
+		instance.Status.Rollout = &appstacksv1.RuntimeComponentRolloutStatus{}
+	}
+	status := instance.Status.Rollout
+	image := appstacksutils.GetAppContainerImage(template)
+	hash := appstacksutils.GetPodTemplateRevision(template)
+	now := metav1.Now()
+	if ready {
+		if status.LastHealthyPodTemplateHash != hash || status.LastHealthyImage != image {
+			status.LastHealthyImage, status.LastHealthyPodTemplateHash, status.LastHealthyTime = image, hash, &now
+		}
+		status.PodTemplateHash, status.StartTime = "", nil
+	} else if status.PodTemplateHash != hash {
+		status.PodTemplateHash, status.StartTime = hash, &now
+	}
+
+	autoRollback := instance.Spec.AutoRollback
+	specImage := instance.Status.ImageReference
+	if status.RolledBackImage != "" {
+		if autoRollback != nil && specImage == status.RolledBackImage && status.LastHealthyImage != "" {
+			if _, ok := workload.(*appsv1.StatefulSet); ok && image == status.LastHealthyImage && !ready {
+				// A StatefulSet does not replace the pods of a failed rollout after its template is reverted
+				return status.LastHealthyImage, r.deletePodsWithImage(instance, status.RolledBackImage)
+			}
+			return status.LastHealthyImage, nil
+		}
+		msg := "The rollback of " + status.RolledBackImage + " ended because the application image changed to " + specImage + "."
+		if autoRollback == nil {
+			msg = "The rollback of " + status.RolledBackImage + " ended because the automatic rollback was disabled."
+		}
+		status.RolledBackImage = ""
+		r.setRolledBackCondition(instance, corev1.ConditionFalse, "RollbackEnded", msg)
+	}
+
+	// Only a rollout of a new application image that started from healthy pods is rolled back
+	if autoRollback == nil || ready || status.StartTime == nil || image != specImage ||
+		status.LastHealthyImage == "" || status.LastHealthyImage == specImage {
+		return "", nil
+	}
+
+	reason, msg := "", ""
+	deadline := appstacksutils.GetAutoRollbackProgressDeadline(autoRollback)
+	if time.Since(status.StartTime.Time) > deadline {
+		reason, msg = "ProgressDeadlineExceeded", fmt.Sprintf("The pods of %s were not ready after %s.", specImage, deadline)
+	} else if autoRollback.MaxRestarts != nil {
+		pods, err := r.listPods(instance)
+		if err != nil {
+			return "", err
+		}
+		if restarts := appstacksutils.GetAppContainerRestarts(pods, specImage); restarts >= *autoRollback.MaxRestarts {
+			reason, msg = "TooManyRestarts", fmt.Sprintf("The containers of %s restarted %d times.", specImage, restarts)
+		}
+	}
+	if reason == "" {
+		return "", nil
+	}
+
+	status.RolledBackImage = specImage
+	msg = msg + " Rolled back to " + status.LastHealthyImage + " until the application image changes."
+	r.setRolledBackCondition(instance, corev1.ConditionTrue, reason, msg)
+	r.GetRecorder().Event(instance, "Warning", "RolledBack", msg)
+	return status.LastHealthyImage, nil
+}
+
+func (r *RuntimeComponentReconciler) listPods(instance *appstacksv1.RuntimeComponent) ([]corev1.Pod, error) {
+	pods := &corev1.PodList{}
+	err := r.GetClient().List(context.TODO(), pods, client.InNamespace(instance.Namespace),
+		client.MatchingLabels{"app.kubernetes.io/instance": instance.Name})
+	return pods.Items, err
+}
+
+// deletePodsWithImage deletes the pods of the instance whose application container runs the given image
+func (r *RuntimeComponentReconciler) deletePodsWithImage(instance *appstacksv1.RuntimeComponent, image string) error {
+	pods, err := r.listPods(instance)
+	if err != nil {
+		return err
+	}
+	for i := range pods {
+		if len(pods[i].Spec.Containers) > 0 && appstacksutils.GetAppContainer(pods[i].Spec.Containers).Image == image {
+			if err := r.DeleteResource(&pods[i]); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func (r *RuntimeComponentReconciler) setRolledBackCondition(instance *appstacksv1.RuntimeComponent, status corev1.ConditionStatus, reason string, msg string) {
+	condition := instance.Status.NewCondition(common.StatusConditionTypeRolledBack)
+	condition.SetConditionFields(msg, reason, status)
+	instance.Status.SetCondition(condition)
+}
@@ -0,0 +1,108 @@
+/*
+
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	appstacksv1 "github.com/application-stacks/runtime-component-operator/api/v1"
+	"github.com/application-stacks/runtime-component-operator/common"
+	appstacksutils "github.com/application-stacks/runtime-component-operator/utils"
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+// publishTrustBundle adds the CA certificate of the operator CA issuer and the CA certificate of the service
+// certificate of the instance to the trust bundle ConfigMap of the namespace
+func (r *RuntimeComponentReconciler) publishTrustBundle(instance *appstacksv1.RuntimeComponent, prefix string, operatorName string) error {
+	var cas [][]byte
+	for _, name := range []string{prefix + "-ca-tls", instance.Status.References[common.StatusReferenceCertSecretName]} {
+		if name == "" {
+			continue
+		}
+		secret := &corev1.Secret{}
+		err := r.GetClient().Get(context.TODO(), types.NamespacedName{Name: name, Namespace: instance.Namespace}, secret)
+		if kerrors.IsNotFound(err) {
+			continue
+		} else if err != nil {
+			return err
+		}
+		if len(secret.Data["ca.crt"]) > 0 {
+			cas = append(cas, secret.Data["ca.crt"])
+		}
+	}
+	if len(cas) == 0 {
+		return nil
+	}
+
+	bundle := &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: prefix + "-trust-bundle", Namespace: instance.Namespace}}
+	return r.CreateOrUpdate(bundle, nil, func() error {
+		bundle.Labels = appstacksutils.MergeMaps(bundle.Labels, map[string]string{"app.kubernetes.io/managed-by": operatorName})
+		if bundle.Data == nil {
+			bundle.Data = map[string]string{}
+		}
+		bundle.Data[appstacksutils.TrustBundleKey] = string(appstacksutils.MergeTrustBundle([]byte(bundle.Data[appstacksutils.TrustBundleKey]), time.Now(), cas...))
+		return nil
+	})
+}
+
+// reconcileTrustBundle copies the trust bundle selected by the instance to its own ConfigMap, which is mounted in the
+// pods, and records the resource version of this ConfigMap so that the pods are rolled when the bundle changes
+func (r *RuntimeComponentReconciler) reconcileTrustBundle(instance *appstacksv1.RuntimeComponent) error {
+	cm := &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: instance.Name + "-trust-bundle", Namespace: instance.Namespace}}
+	if instance.Spec.TrustBundle == nil {
+		delete(instance.Status.References, common.StatusReferenceTrustBundleResourceVersion)
+		return r.DeleteResource(cm)
+	}
+
+	trustBundle := instance.Spec.TrustBundle
+	source := &corev1.ConfigMap{}
+	err := r.GetClient().Get(context.TODO(), types.NamespacedName{Name: trustBundle.GetConfigMapName(), Namespace: instance.Namespace}, source)
+	if err != nil {
+		return fmt.Errorf("failed to get the %q trust bundle ConfigMap: %w", trustBundle.GetConfigMapName(), err)
+	}
+	err = r.CreateOrUpdate(cm, instance, func() error {
+		return appstacksutils.CustomizeTrustBundleConfigMap(cm, instance, []byte(source.Data[trustBundle.GetKey()]))
+	})
+	if err != nil {
+		return err
+	}
+	instance.Status.SetReference(common.StatusReferenceTrustBundleResourceVersion, cm.ResourceVersion)
+	return nil
+}
+
+// trustBundleRequests returns the RuntimeComponents of the namespace of the ConfigMap that mount it as their trust bundle
+func trustBundleRequests(c client.Client, cm client.Object) []reconcile.Request {
+	components := &appstacksv1.RuntimeComponentList{}
+	if err := c.List(context.Background(), components, client.InNamespace(cm.GetNamespace())); err != nil {
+		return nil
+	}
+	var requests []reconcile.Request
+	for i := range components.Items {
+		trustBundle := components.Items[i].Spec.TrustBundle
+		if trustBundle != nil && trustBundle.GetConfigMapName() == cm.GetName() {
+			requests = append(requests, reconcile.Request{NamespacedName: types.NamespacedName{Name: components.Items[i].Name, Namespace: cm.GetNamespace()}})
+		}
+	}
+	return requests
+}
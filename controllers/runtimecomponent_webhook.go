@@ -0,0 +1,98 @@
+/*
+
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+
+	appstacksv1 "github.com/application-stacks/runtime-component-operator/api/v1"
+	appstacksutils "github.com/application-stacks/runtime-component-operator/utils"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+// +kubebuilder:webhook:path=/mutate-rc-app-stacks-v1-runtimecomponent,mutating=true,failurePolicy=fail,sideEffects=None,groups=rc.app.stacks,resources=runtimecomponents,verbs=create;update,versions=v1,matchPolicy=Equivalent,name=mruntimecomponent.rc.app.stacks,admissionReviewVersions=v1
+// +kubebuilder:webhook:path=/validate-rc-app-stacks-v1-runtimecomponent,mutating=false,failurePolicy=fail,sideEffects=None,groups=rc.app.stacks,resources=runtimecomponents,verbs=create;update,versions=v1,matchPolicy=Equivalent,name=vruntimecomponent.rc.app.stacks,admissionReviewVersions=v1
+
+// RuntimeComponentWebhook defaults and validates RuntimeComponent objects on admission,
+// so that invalid specs are rejected by the API server instead of failing in Reconcile
+type RuntimeComponentWebhook struct{}
+
+var _ admission.CustomDefaulter = &RuntimeComponentWebhook{}
+var _ admission.CustomValidator = &RuntimeComponentWebhook{}
+
+// SetupWebhookWithManager registers the defaulting and validating webhooks with the manager
+func (w *RuntimeComponentWebhook) SetupWebhookWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewWebhookManagedBy(mgr).
+		For(&appstacksv1.RuntimeComponent{}).
+		WithDefaulter(w).
+		WithValidator(w).
+		Complete()
+}
+
+// Default applies the same defaults that the reconciler applies through Initialize
+func (w *RuntimeComponentWebhook) Default(ctx context.Context, obj runtime.Object) error {
+	instance, err := toRuntimeComponent(obj)
+	if err != nil {
+		return err
+	}
+	instance.Initialize()
+	return nil
+}
+
+// ValidateCreate validates a RuntimeComponent on creation
+func (w *RuntimeComponentWebhook) ValidateCreate(ctx context.Context, obj runtime.Object) error {
+	return validateRuntimeComponent(obj)
+}
+
+// ValidateUpdate validates a RuntimeComponent on update
+func (w *RuntimeComponentWebhook) ValidateUpdate(ctx context.Context, oldObj, newObj runtime.Object) error {
+	return validateRuntimeComponent(newObj)
+}
+
+// ValidateDelete allows every deletion
+func (w *RuntimeComponentWebhook) ValidateDelete(ctx context.Context, obj runtime.Object) error {
+	return nil
+}
+
+func validateRuntimeComponent(obj runtime.Object) error {
+	instance, err := toRuntimeComponent(obj)
+	if err != nil {
+		return err
+	}
+	if _, err = appstacksutils.Validate(instance); err != nil {
+		return err
+	}
+	if err = appstacksutils.ValidateCanary(instance); err != nil {
+		return err
+	}
+	if err = appstacksutils.ValidateBlueGreen(instance); err != nil {
+		return err
+	}
+	return appstacksutils.ValidateAutoRollback(instance)
+}
+
+func toRuntimeComponent(obj runtime.Object) (*appstacksv1.RuntimeComponent, error) {
+	instance, ok := obj.(*appstacksv1.RuntimeComponent)
+	if !ok {
+		return nil, kerrors.NewBadRequest(fmt.Sprintf("expected a RuntimeComponent but got a %T", obj))
+	}
+	return instance, nil
+}
@@ -18,12 +18,18 @@ package controllers
 
 import (
 	"context"
+	stdErrors "errors"
+	"fmt"
 	"math"
 	"os"
+	"sort"
+	"sync"
 	"time"
 
 	"github.com/go-logr/logr"
 	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/rest"
@@ -31,15 +37,28 @@ import (
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
 	"sigs.k8s.io/controller-runtime/pkg/predicate"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
 
-	appstacksv1beta2 "github.com/application-stacks/runtime-component-operator/api/v1beta2"
+	appstacksv1 "github.com/application-stacks/runtime-component-operator/api/v1"
 	"github.com/application-stacks/runtime-component-operator/utils"
 	corev1 "k8s.io/api/core/v1"
 )
 
+var (
+	// operationRetryBackoff is the delay before the first retry of a failed command
+	operationRetryBackoff = 10 * time.Second
+	// operationMaxRetryBackoff is the maximum delay between retries of a failed command
+	operationMaxRetryBackoff = 5 * time.Minute
+
+	// runCommandInContainer runs the command of a RuntimeOperation in a container, and is replaced in tests
+	runCommandInContainer = utils.RunCommandInContainer
+)
+
 // RuntimeOperationReconciler reconciles a RuntimeOperation object
 type RuntimeOperationReconciler struct {
 	client.Client
@@ -47,53 +66,131 @@ type RuntimeOperationReconciler struct {
 	Scheme     *runtime.Scheme
 	Recorder   record.EventRecorder
 	RestConfig *rest.Config
+	Config     *utils.OperatorConfigWatcher
+
+	// commands running in the background, by RuntimeOperation
+	runs      map[types.NamespacedName]*operationRun
+	runsMutex sync.Mutex
+	runEvents chan event.GenericEvent
 }
 
 // +kubebuilder:rbac:groups=rc.app.stacks,resources=runtimeoperations;runtimeoperations/status;runtimeoperations/finalizers,verbs=get;list;watch;create;update;delete,namespace=runtime-component-operator
 // +kubebuilder:rbac:groups=core,resources=pods;pods/exec,verbs=get;list;watch;create;update;delete,namespace=runtime-component-operator
+// +kubebuilder:rbac:groups=core,resources=configmaps;secrets,verbs=get;list;watch;create;update;delete,namespace=runtime-component-operator
 
 func (r *RuntimeOperationReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
 	reqLogger := r.Log.WithValues("Request.Namespace", req.Namespace, "Request.Name", req.Name)
 	reqLogger.Info("Reconciling RuntimeOperation")
 
 	// Fetch the RuntimeOperation instance
-	instance := &appstacksv1beta2.RuntimeOperation{}
+	instance := &appstacksv1.RuntimeOperation{}
 	err := r.Client.Get(context.TODO(), req.NamespacedName, instance)
 	if err != nil {
 		if errors.IsNotFound(err) {
 			// Request object not found, could have been deleted after reconcile request.
-			// Owned objects are automatically garbage collected. For additional cleanup logic use finalizers.
+			// Owned objects are automatically garbage collected. Stop the command if it is still running.
 			// Return and don't requeue
+			if run := r.removeRun(req.NamespacedName); run != nil {
+				run.cancel()
+			}
 			return reconcile.Result{}, nil
 		}
 		// Error reading the object - requeue the request.
 		return reconcile.Result{}, err
 	}
 
-	//do not reconcile if the RuntimeOperation already completed
-	oc := appstacksv1beta2.GetOperationCondition(instance.Status.Conditions, appstacksv1beta2.OperationStatusConditionTypeCompleted)
-	if oc != nil && oc.Status == corev1.ConditionTrue {
+	//handle the command running in the background. The run is only forgotten once its outcome is saved in the status,
+	//so that a failed status update is retried with the same results.
+	if run := r.getRun(req.NamespacedName); run != nil {
+		var result reconcile.Result
+		if instance.Spec.IsCancelled() {
+			run.cancel()
+			result, err = r.completeOperation(instance, corev1.ConditionFalse, appstacksv1.OperationStatusReasonCancelled, "RuntimeOperation was cancelled")
+		} else {
+			results, done := run.getResults()
+			if !done {
+				return reconcile.Result{}, nil
+			}
+			result, err = r.finishOperation(instance, run, results)
+		}
+		if err != nil {
+			return result, err
+		}
+		r.removeRun(req.NamespacedName)
+		return result, nil
+	}
+
+	//do not reconcile if the RuntimeOperation already completed, and delete it once its TTL expired
+	oc := appstacksv1.GetOperationCondition(instance.Status.Conditions, appstacksv1.OperationStatusConditionTypeCompleted)
+	if oc != nil {
+		expiry, hasTTL := r.operationExpiry(instance, oc)
+		if hasTTL && !time.Now().Before(expiry) {
+			return r.deleteFinishedOperation(instance)
+		}
 		message := "RuntimeOperation '" + instance.Name + "' in namespace '" + req.Namespace + "' already completed. Create another RuntimeOperation instance to execute the command."
-		r.Log.Info(message)
-		r.Recorder.Event(instance, "Warning", "ProcessingError", message)
+		if instance.Generation == instance.Status.ObservedGeneration {
+			r.Log.V(1).Info(message)
+		} else {
+			//only warn once about a change of the spec of a completed RuntimeOperation
+			r.Log.Info(message)
+			r.Recorder.Event(instance, "Warning", "ProcessingError", message)
+			instance.Status.ObservedGeneration = instance.Generation
+			if err := r.Client.Status().Update(context.TODO(), instance); err != nil {
+				return reconcile.Result{}, err
+			}
+		}
+		if hasTTL {
+			return reconcile.Result{RequeueAfter: time.Until(expiry)}, nil
+		}
 		return reconcile.Result{}, err
 	}
 
+	//cancel the RuntimeOperation if it has not completed yet
+	if instance.Spec.IsCancelled() {
+		return r.completeOperation(instance, corev1.ConditionFalse, appstacksv1.OperationStatusReasonCancelled, "RuntimeOperation was cancelled")
+	}
+
+	//fail the RuntimeOperation if it did not complete before the timeout
+	if deadline, ok := operationDeadline(instance); ok && !time.Now().Before(deadline) {
+		return r.completeOperation(instance, corev1.ConditionFalse, appstacksv1.OperationStatusReasonTimedOut, "RuntimeOperation did not complete within "+instance.Spec.Timeout.Duration.String())
+	}
+
 	//do not reconcile if the RuntimeOperation already started
-	oc = appstacksv1beta2.GetOperationCondition(instance.Status.Conditions, appstacksv1beta2.OperationStatusConditionTypeStarted)
+	oc = appstacksv1.GetOperationCondition(instance.Status.Conditions, appstacksv1.OperationStatusConditionTypeStarted)
 	if oc != nil && oc.Status == corev1.ConditionTrue {
 		message := "RuntimeOperation '" + instance.Name + "' in namespace '" + req.Namespace + "' already started and it can not be modified. Create another RuntimeOperation instance to execute the command."
 		r.Log.Info(message)
 		r.Recorder.Event(instance, "Warning", "ProcessingError", message)
+		// The command is no longer tracked, e.g. after a restart of the operator, so fail the operation at its deadline
+		if deadline, ok := operationDeadline(instance); ok {
+			return reconcile.Result{RequeueAfter: time.Until(deadline)}, nil
+		}
 		return reconcile.Result{}, err
 	}
 
-	//check if Pod exists and is in running state
-	pod := &corev1.Pod{}
-	err = r.Client.Get(context.TODO(), types.NamespacedName{Name: instance.Spec.PodName, Namespace: req.Namespace}, pod)
-	if err != nil || pod.Status.Phase != corev1.PodRunning {
+	if err := instance.Spec.Validate(); err != nil {
+		message := "RuntimeOperation '" + instance.Name + "' in namespace '" + req.Namespace + "' is invalid: " + err.Error()
+		r.Log.Info(message)
+		r.Recorder.Event(instance, "Warning", "ProcessingError", message)
+		c := appstacksv1.OperationStatusCondition{
+			Type:    appstacksv1.OperationStatusConditionTypeStarted,
+			Status:  corev1.ConditionFalse,
+			Reason:  "Error",
+			Message: message,
+		}
+		instance.Status.Conditions = appstacksv1.SetOperationCondition(instance.Status.Conditions, c)
+		r.Client.Status().Update(context.TODO(), instance)
+		return reconcile.Result{}, nil
+	}
+
+	//check if the target Pods exist and are in running state
+	pods, err := r.getTargetPods(instance)
+	if err != nil || len(pods) == 0 {
 		//handle error
 		message := "Failed to find pod '" + instance.Spec.PodName + "' in namespace '" + req.Namespace + "'"
+		if instance.Spec.PodName == "" {
+			message = "Failed to find pods " + targetDescription(instance) + " in namespace '" + req.Namespace + "'"
+		}
 		if err == nil {
 			message = message + " in running state"
 		}
@@ -105,55 +202,380 @@ func (r *RuntimeOperationReconciler) Reconcile(ctx context.Context, req ctrl.Req
 		containerName = instance.Spec.ContainerName
 	}
 
-	//check if the specified container exists in the Pod
-	foundContainer := false
-	containerList := pod.Spec.Containers
-	for i := 0; i < len(containerList); i++ {
-		if containerList[i].Name == containerName {
-			foundContainer = true
-			break
+	//check if the specified container exists in the Pods
+	for _, pod := range pods {
+		foundContainer := false
+		containerList := pod.Spec.Containers
+		for i := 0; i < len(containerList); i++ {
+			if containerList[i].Name == containerName {
+				foundContainer = true
+				break
+			}
+		}
+		if !foundContainer {
+			message := "Failed to find container '" + containerName + "' in pod '" + pod.Name + "' in namespace '" + req.Namespace + "'"
+			return handleStartErrorAndRequeue(r, instance, nil, message)
 		}
-	}
-	if !foundContainer {
-		message := "Failed to find container '" + containerName + "' in pod '" + instance.Spec.PodName + "' in namespace '" + req.Namespace + "'"
-		return handleStartErrorAndRequeue(r, instance, nil, message)
 	}
 
-	c := appstacksv1beta2.OperationStatusCondition{
-		Type:   appstacksv1beta2.OperationStatusConditionTypeStarted,
+	c := appstacksv1.OperationStatusCondition{
+		Type:   appstacksv1.OperationStatusConditionTypeStarted,
 		Status: corev1.ConditionTrue,
 	}
 
-	instance.Status.Conditions = appstacksv1beta2.SetOperationCondition(instance.Status.Conditions, c)
-	r.Client.Status().Update(context.TODO(), instance)
+	instance.Status.Conditions = appstacksv1.SetOperationCondition(instance.Status.Conditions, c)
+	// The command only runs once the operation is recorded as started, so that it does not run twice on a conflict
+	if err := r.Client.Status().Update(context.TODO(), instance); err != nil {
+		return reconcile.Result{}, err
+	}
 
-	_, err = utils.ExecuteCommandInContainer(r.RestConfig, pod.Name, pod.Namespace, containerName, instance.Spec.Command)
-	if err != nil {
-		//handle error
-		r.Log.Error(err, "Execute command failed", "RuntimeOperation name", instance.Name, "command", instance.Spec.Command)
-		r.Recorder.Event(instance, "Warning", "ProcessingError", err.Error())
-		c = appstacksv1beta2.OperationStatusCondition{
-			Type:    appstacksv1beta2.OperationStatusConditionTypeCompleted,
-			Status:  corev1.ConditionFalse,
-			Reason:  "Error",
-			Message: err.Error(),
+	r.startRun(instance, pods, containerName)
+	return reconcile.Result{}, nil
+}
+
+// finishOperation records the results of the command on the pods and completes the RuntimeOperation
+func (r *RuntimeOperationReconciler) finishOperation(instance *appstacksv1.RuntimeOperation, run *operationRun, results []podCommandResult) (reconcile.Result, error) {
+	r.setOutputStatus(instance, results)
+
+	if stdErrors.Is(run.ctx.Err(), context.DeadlineExceeded) {
+		return r.completeOperation(instance, corev1.ConditionFalse, appstacksv1.OperationStatusReasonTimedOut, "RuntimeOperation did not complete within "+instance.Spec.Timeout.Duration.String())
+	}
+
+	failed := 0
+	var failure string
+	for _, result := range results {
+		if message := result.failureMessage(); message != "" {
+			failed++
+			failure = message
+			r.Log.Error(result.err, "Execute command failed", "RuntimeOperation name", instance.Name, "pod", result.podName, "command", instance.Spec.Command)
+			r.Recorder.Event(instance, "Warning", "ProcessingError", message)
 		}
-		instance.Status.Conditions = appstacksv1beta2.SetOperationCondition(instance.Status.Conditions, c)
-		r.Client.Status().Update(context.TODO(), instance)
-		return reconcile.Result{}, nil
+	}
 
+	if failed == 0 {
+		return r.completeOperation(instance, corev1.ConditionTrue, "", "")
+	}
+	if len(results) > 1 {
+		failure = fmt.Sprintf("Command failed on %d of %d pods", failed, len(results))
 	}
+	return r.completeOperation(instance, corev1.ConditionFalse, appstacksv1.OperationStatusReasonError, failure)
+}
 
-	c = appstacksv1beta2.OperationStatusCondition{
-		Type:   appstacksv1beta2.OperationStatusConditionTypeCompleted,
-		Status: corev1.ConditionTrue,
+// completeOperation sets the Completed condition of the RuntimeOperation
+func (r *RuntimeOperationReconciler) completeOperation(instance *appstacksv1.RuntimeOperation, status corev1.ConditionStatus, reason, message string) (reconcile.Result, error) {
+	if reason == appstacksv1.OperationStatusReasonTimedOut || reason == appstacksv1.OperationStatusReasonCancelled {
+		r.Log.Info(message, "RuntimeOperation name", instance.Name)
+		r.Recorder.Event(instance, "Warning", reason, message)
 	}
 
-	instance.Status.Conditions = appstacksv1beta2.SetOperationCondition(instance.Status.Conditions, c)
-	r.Client.Status().Update(context.TODO(), instance)
+	c := appstacksv1.OperationStatusCondition{
+		Type:    appstacksv1.OperationStatusConditionTypeCompleted,
+		Status:  status,
+		Reason:  reason,
+		Message: message,
+	}
+	instance.Status.Conditions = appstacksv1.SetOperationCondition(instance.Status.Conditions, c)
+	instance.Status.ObservedGeneration = instance.Generation
+	err := r.Client.Status().Update(context.TODO(), instance)
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+	utils.CountOperationResult(instance.Namespace, status, reason)
+
+	// Reconcile again to delete the RuntimeOperation when its TTL expires
+	oc := appstacksv1.GetOperationCondition(instance.Status.Conditions, appstacksv1.OperationStatusConditionTypeCompleted)
+	if expiry, ok := r.operationExpiry(instance, oc); ok {
+		return reconcile.Result{RequeueAfter: time.Until(expiry)}, nil
+	}
+	return reconcile.Result{}, nil
+}
+
+// operationExpiry returns the time at which the completed RuntimeOperation is deleted, if a TTL applies to it.
+// The TTL set in the spec takes precedence over the default of the operator ConfigMap.
+func (r *RuntimeOperationReconciler) operationExpiry(instance *appstacksv1.RuntimeOperation, completed *appstacksv1.OperationStatusCondition) (time.Time, bool) {
+	succeeded := completed.Status == corev1.ConditionTrue
+	if ttl := instance.Spec.GetTTLSecondsAfterFinished(succeeded); ttl != nil {
+		return completed.LastUpdateTime.Add(time.Duration(*ttl) * time.Second), true
+	}
+
+	ttl := r.Config.Get().OperationTTLSecondsAfterFailure
+	if succeeded {
+		ttl = r.Config.Get().OperationTTLSecondsAfterSuccess
+	}
+	if ttl == nil {
+		return time.Time{}, false
+	}
+	return completed.LastUpdateTime.Add(time.Duration(*ttl) * time.Second), true
+}
+
+// deleteFinishedOperation deletes the RuntimeOperation after its TTL expired, along with the resources it owns
+func (r *RuntimeOperationReconciler) deleteFinishedOperation(instance *appstacksv1.RuntimeOperation) (reconcile.Result, error) {
+	r.Log.Info("Deleting RuntimeOperation after its TTL expired", "RuntimeOperation name", instance.Name, "namespace", instance.Namespace)
+	err := r.Client.Delete(context.TODO(), instance, client.PropagationPolicy(metav1.DeletePropagationBackground))
+	if err != nil && !errors.IsNotFound(err) {
+		return reconcile.Result{}, err
+	}
 	return reconcile.Result{}, nil
 }
 
+// operationDeadline returns the time by which the RuntimeOperation must complete, if spec.timeout is set
+func operationDeadline(instance *appstacksv1.RuntimeOperation) (time.Time, bool) {
+	if instance.Spec.Timeout == nil {
+		return time.Time{}, false
+	}
+	return instance.CreationTimestamp.Add(instance.Spec.Timeout.Duration), true
+}
+
+// operationRun tracks the command of a RuntimeOperation running in the background
+type operationRun struct {
+	ctx     context.Context
+	cancel  context.CancelFunc
+	mutex   sync.Mutex
+	done    bool
+	results []podCommandResult
+}
+
+func (run *operationRun) getResults() ([]podCommandResult, bool) {
+	run.mutex.Lock()
+	defer run.mutex.Unlock()
+	return run.results, run.done
+}
+
+func (run *operationRun) setResults(results []podCommandResult) {
+	run.mutex.Lock()
+	defer run.mutex.Unlock()
+	run.results = results
+	run.done = true
+}
+
+func (r *RuntimeOperationReconciler) getRun(key types.NamespacedName) *operationRun {
+	r.runsMutex.Lock()
+	defer r.runsMutex.Unlock()
+	return r.runs[key]
+}
+
+func (r *RuntimeOperationReconciler) removeRun(key types.NamespacedName) *operationRun {
+	r.runsMutex.Lock()
+	defer r.runsMutex.Unlock()
+	run := r.runs[key]
+	delete(r.runs, key)
+	return run
+}
+
+// startRun runs the command of the RuntimeOperation on the pods in the background. The RuntimeOperation is
+// reconciled again when the command has completed on all pods.
+func (r *RuntimeOperationReconciler) startRun(instance *appstacksv1.RuntimeOperation, pods []corev1.Pod, containerName string) {
+	key := types.NamespacedName{Name: instance.Name, Namespace: instance.Namespace}
+	run := &operationRun{}
+	if deadline, ok := operationDeadline(instance); ok {
+		run.ctx, run.cancel = context.WithDeadline(context.Background(), deadline)
+	} else {
+		run.ctx, run.cancel = context.WithCancel(context.Background())
+	}
+
+	r.runsMutex.Lock()
+	r.runs[key] = run
+	r.runsMutex.Unlock()
+
+	op := instance.DeepCopy()
+	go func() {
+		run.setResults(r.runCommandOnPods(run.ctx, op, pods, containerName))
+		if r.getRun(key) == run {
+			r.runEvents <- event.GenericEvent{Object: op}
+		}
+	}()
+}
+
+// podCommandResult is the result of running the command of a RuntimeOperation on a pod
+type podCommandResult struct {
+	podName  string
+	output   *utils.CommandOutput
+	err      error
+	attempts int
+}
+
+// failureMessage returns why the command failed on the pod, or an empty string if it succeeded
+func (res *podCommandResult) failureMessage() string {
+	if res.err != nil {
+		return res.err.Error()
+	}
+	if res.output.ExitCode != 0 {
+		return fmt.Sprintf("Command exited with code %d on pod '%s'", res.output.ExitCode, res.podName)
+	}
+	return ""
+}
+
+// getTargetPods returns the running pods targeted by the RuntimeOperation, sorted by name
+func (r *RuntimeOperationReconciler) getTargetPods(instance *appstacksv1.RuntimeOperation) ([]corev1.Pod, error) {
+	if instance.Spec.PodName != "" {
+		pod := &corev1.Pod{}
+		err := r.Client.Get(context.TODO(), types.NamespacedName{Name: instance.Spec.PodName, Namespace: instance.Namespace}, pod)
+		if err != nil || pod.Status.Phase != corev1.PodRunning {
+			return nil, err
+		}
+		return []corev1.Pod{*pod}, nil
+	}
+
+	selector := labels.SelectorFromSet(labels.Set{"app.kubernetes.io/instance": instance.Spec.ComponentName})
+	if instance.Spec.Selector != nil {
+		var err error
+		if selector, err = metav1.LabelSelectorAsSelector(instance.Spec.Selector); err != nil {
+			return nil, err
+		}
+	}
+
+	podList := &corev1.PodList{}
+	if err := r.Client.List(context.TODO(), podList, client.InNamespace(instance.Namespace), client.MatchingLabelsSelector{Selector: selector}); err != nil {
+		return nil, err
+	}
+
+	var pods []corev1.Pod
+	for _, pod := range podList.Items {
+		if pod.Status.Phase == corev1.PodRunning && pod.DeletionTimestamp == nil {
+			pods = append(pods, pod)
+		}
+	}
+	sort.Slice(pods, func(i, j int) bool { return pods[i].Name < pods[j].Name })
+	return pods, nil
+}
+
+// targetDescription describes the pods targeted by the RuntimeOperation for messages
+func targetDescription(instance *appstacksv1.RuntimeOperation) string {
+	if instance.Spec.ComponentName != "" {
+		return "of component '" + instance.Spec.ComponentName + "'"
+	}
+	return "matching selector '" + metav1.FormatLabelSelector(instance.Spec.Selector) + "'"
+}
+
+// runCommandOnPods runs the command of the RuntimeOperation on the pods, at most spec.concurrency pods at a time.
+// The command is retried on a pod where it fails, up to spec.retries times.
+func (r *RuntimeOperationReconciler) runCommandOnPods(ctx context.Context, instance *appstacksv1.RuntimeOperation, pods []corev1.Pod, containerName string) []podCommandResult {
+	results := make([]podCommandResult, len(pods))
+	sem := make(chan struct{}, instance.Spec.GetConcurrency())
+	var wg sync.WaitGroup
+	for i := range pods {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(i int) {
+			defer func() {
+				<-sem
+				wg.Done()
+			}()
+			results[i] = r.runCommandOnPod(ctx, instance, &pods[i], containerName)
+		}(i)
+	}
+	wg.Wait()
+	return results
+}
+
+// runCommandOnPod runs the command of the RuntimeOperation on the pod, retrying with an exponential backoff when it cannot
+// be run. A command that exits with a non-zero code ran, so it is not retried.
+func (r *RuntimeOperationReconciler) runCommandOnPod(ctx context.Context, instance *appstacksv1.RuntimeOperation, pod *corev1.Pod, containerName string) podCommandResult {
+	result := podCommandResult{podName: pod.Name}
+	backoff := operationRetryBackoff
+	for {
+		if ctx.Err() != nil {
+			if result.attempts == 0 {
+				result.err = fmt.Errorf("Stopped running command: %v ; Error: %w", instance.Spec.Command, ctx.Err())
+			}
+			return result
+		}
+
+		result.output, result.err = runCommandInContainer(ctx, r.RestConfig, pod.Name, pod.Namespace, containerName, instance.Spec.Command)
+		result.attempts++
+		if result.err == nil || ctx.Err() != nil || result.attempts > instance.Spec.GetRetries() {
+			return result
+		}
+
+		r.Recorder.Event(instance, "Warning", "Retrying", fmt.Sprintf("%v. Retrying in %v (attempt %d of %d)", result.err, backoff, result.attempts+1, instance.Spec.GetRetries()+1))
+		select {
+		case <-ctx.Done():
+		case <-time.After(backoff):
+		}
+		if backoff *= 2; backoff > operationMaxRetryBackoff {
+			backoff = operationMaxRetryBackoff
+		}
+	}
+}
+
+// setOutputStatus records the exit code and the tail of the command output of each pod in the status. When requested, the
+// full output is stored in a ConfigMap or Secret owned by the RuntimeOperation.
+func (r *RuntimeOperationReconciler) setOutputStatus(instance *appstacksv1.RuntimeOperation, results []podCommandResult) {
+	instance.Status.Pods = nil
+	for _, result := range results {
+		ps := appstacksv1.OperationPodStatus{PodName: result.podName, Completed: corev1.ConditionTrue, Attempts: int32(result.attempts)}
+		if message := result.failureMessage(); message != "" {
+			ps.Completed = corev1.ConditionFalse
+			if result.err != nil {
+				ps.Message = message
+			}
+		}
+		if result.output != nil {
+			exitCode := int32(result.output.ExitCode)
+			ps.ExitCode = &exitCode
+			ps.Stdout = utils.TailOutput(result.output.Stdout, appstacksv1.OperationPodOutputTailLimit)
+			ps.Stderr = utils.TailOutput(result.output.Stderr, appstacksv1.OperationPodOutputTailLimit)
+		}
+		instance.Status.Pods = append(instance.Status.Pods, ps)
+	}
+
+	// An operation on a single pod also reports its output at the top level of the status
+	if len(results) == 1 && results[0].output != nil {
+		output := results[0].output
+		exitCode := int32(output.ExitCode)
+		instance.Status.ExitCode = &exitCode
+		instance.Status.Stdout = utils.TailOutput(output.Stdout, appstacksv1.OperationOutputTailLimit)
+		instance.Status.Stderr = utils.TailOutput(output.Stderr, appstacksv1.OperationOutputTailLimit)
+	}
+
+	if instance.Spec.Output == nil {
+		return
+	}
+
+	// Share the size limit of the resource between the pods
+	limit := appstacksv1.OperationOutputStoreLimit / len(results)
+	data := map[string]string{}
+	for _, result := range results {
+		if result.output == nil {
+			continue
+		}
+		prefix := ""
+		if len(results) > 1 {
+			prefix = result.podName + "."
+		}
+		data[prefix+"stdout"] = utils.TailOutput(result.output.Stdout, limit)
+		data[prefix+"stderr"] = utils.TailOutput(result.output.Stderr, limit)
+	}
+	meta := metav1.ObjectMeta{Name: instance.Name + "-output", Namespace: instance.Namespace}
+
+	var err error
+	kind := instance.Spec.Output.Kind
+	if kind == "Secret" {
+		secret := &corev1.Secret{ObjectMeta: meta}
+		_, err = controllerutil.CreateOrUpdate(context.TODO(), r.Client, secret, func() error {
+			secret.Data = map[string][]byte{}
+			for k, v := range data {
+				secret.Data[k] = []byte(v)
+			}
+			return controllerutil.SetControllerReference(instance, secret, r.Scheme)
+		})
+	} else {
+		kind = "ConfigMap"
+		cm := &corev1.ConfigMap{ObjectMeta: meta}
+		_, err = controllerutil.CreateOrUpdate(context.TODO(), r.Client, cm, func() error {
+			cm.Data = data
+			return controllerutil.SetControllerReference(instance, cm, r.Scheme)
+		})
+	}
+	if err != nil {
+		r.Log.Error(err, "Failed to store the command output", "RuntimeOperation name", instance.Name)
+		r.Recorder.Event(instance, "Warning", "ProcessingError", "Failed to store the command output: "+err.Error())
+		return
+	}
+
+	instance.Status.OutputRef = &corev1.TypedLocalObjectReference{Kind: kind, Name: meta.Name}
+}
+
 func (r *RuntimeOperationReconciler) SetupWithManager(mgr ctrl.Manager) error {
 
 	watchNamespaces, err := utils.GetWatchNamespaces()
@@ -186,35 +608,45 @@ func (r *RuntimeOperationReconciler) SetupWithManager(mgr ctrl.Manager) error {
 		},
 	}
 
+	r.runs = make(map[types.NamespacedName]*operationRun)
+	r.runEvents = make(chan event.GenericEvent)
+
 	return ctrl.NewControllerManagedBy(mgr).
-		For(&appstacksv1beta2.RuntimeOperation{}, builder.WithPredicates(pred)).
+		For(&appstacksv1.RuntimeOperation{}, builder.WithPredicates(pred)).
+		Watches(&source.Channel{Source: r.runEvents}, &handler.EnqueueRequestForObject{}).
 		Complete(r)
 }
 
 // handleStartErrorAndRequeue updates OperationStatusConditionTypeStarted and requeues. It doubles the retry interval when the failure is due to same error.
-func handleStartErrorAndRequeue(r *RuntimeOperationReconciler, instance *appstacksv1beta2.RuntimeOperation, err error, message string) (reconcile.Result, error) {
+func handleStartErrorAndRequeue(r *RuntimeOperationReconciler, instance *appstacksv1.RuntimeOperation, err error, message string) (reconcile.Result, error) {
 	r.Log.Error(err, message)
 	r.Recorder.Event(instance, "Warning", "ProcessingError", message)
 
-	c := appstacksv1beta2.OperationStatusCondition{
-		Type:    appstacksv1beta2.OperationStatusConditionTypeStarted,
+	c := appstacksv1.OperationStatusCondition{
+		Type:    appstacksv1.OperationStatusConditionTypeStarted,
 		Status:  corev1.ConditionFalse,
 		Reason:  "Error",
 		Message: message,
 	}
 
 	var retryInterval time.Duration
-	oldCondition := appstacksv1beta2.GetOperationCondition(instance.Status.Conditions, c.Type)
+	oldCondition := appstacksv1.GetOperationCondition(instance.Status.Conditions, c.Type)
 	if oldCondition == nil || oldCondition.LastUpdateTime.Time.IsZero() || oldCondition.Message != c.Message {
 		retryInterval = time.Second
 	} else {
 		retryInterval = time.Now().Sub(oldCondition.LastUpdateTime.Time).Round(time.Second)
 	}
 
-	instance.Status.Conditions = appstacksv1beta2.SetOperationCondition(instance.Status.Conditions, c)
+	requeueAfter := time.Duration(math.Min(float64(retryInterval.Nanoseconds()*2), float64(time.Hour.Nanoseconds()*6)))
+	// Check again at the deadline, to fail the operation in time if it cannot start
+	if deadline, ok := operationDeadline(instance); ok && time.Until(deadline) < requeueAfter {
+		requeueAfter = time.Until(deadline)
+	}
+
+	instance.Status.Conditions = appstacksv1.SetOperationCondition(instance.Status.Conditions, c)
 	r.Client.Status().Update(context.TODO(), instance)
 	return reconcile.Result{
-		RequeueAfter: time.Duration(math.Min(float64(retryInterval.Nanoseconds()*2), float64(time.Hour.Nanoseconds()*6))),
+		RequeueAfter: requeueAfter,
 		Requeue:      true,
 	}, nil
 }
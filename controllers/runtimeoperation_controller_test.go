@@ -0,0 +1,257 @@
+package controllers
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	appstacksv1 "github.com/application-stacks/runtime-component-operator/api/v1"
+	"github.com/application-stacks/runtime-component-operator/utils"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	fakeclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/log/zap"
+)
+
+const operationNamespace = "operations"
+
+func TestRuntimeOperationTimeout(t *testing.T) {
+	op := createRuntimeOperation("timeout")
+	op.CreationTimestamp = metav1.NewTime(time.Now().Add(-2 * time.Minute))
+	op.Spec.Timeout = &metav1.Duration{Duration: time.Minute}
+	r, cl := createRuntimeOperationReconciler(op)
+
+	if _, err := r.Reconcile(context.TODO(), requestFor(op)); err != nil {
+		t.Fatal(err)
+	}
+	verifyCompleted(t, cl, op, corev1.ConditionFalse, appstacksv1.OperationStatusReasonTimedOut)
+}
+
+func TestRuntimeOperationCancel(t *testing.T) {
+	op := createRuntimeOperation("cancel")
+	cancel := true
+	op.Spec.Cancel = &cancel
+	r, cl := createRuntimeOperationReconciler(op)
+	run := trackRun(r, op)
+
+	// The run is kept until the cancellation is saved in the status
+	failing := r.withClient(&failingStatusClient{Client: cl})
+	if _, err := failing.Reconcile(context.TODO(), requestFor(op)); err == nil {
+		t.Error("expected the status update error to be returned")
+	}
+	if run.ctx.Err() == nil {
+		t.Error("the command was not stopped")
+	}
+	if r.getRun(requestFor(op).NamespacedName) == nil {
+		t.Error("the run was forgotten before its status was saved")
+	}
+
+	if _, err := r.Reconcile(context.TODO(), requestFor(op)); err != nil {
+		t.Fatal(err)
+	}
+	verifyCompleted(t, cl, op, corev1.ConditionFalse, appstacksv1.OperationStatusReasonCancelled)
+	if r.getRun(requestFor(op).NamespacedName) != nil {
+		t.Error("the run is still tracked after the operation completed")
+	}
+}
+
+func TestRuntimeOperationKeepsResultsOnStatusError(t *testing.T) {
+	op := createRuntimeOperation("results")
+	r, cl := createRuntimeOperationReconciler(op)
+	run := trackRun(r, op)
+	run.setResults([]podCommandResult{{podName: "my-pod", output: &utils.CommandOutput{Stdout: "done", ExitCode: 0}, attempts: 1}})
+
+	failing := r.withClient(&failingStatusClient{Client: cl})
+	if _, err := failing.Reconcile(context.TODO(), requestFor(op)); err == nil {
+		t.Error("expected the status update error to be returned")
+	}
+	if r.getRun(requestFor(op).NamespacedName) == nil {
+		t.Fatal("the results were lost when the status update failed")
+	}
+
+	if _, err := r.Reconcile(context.TODO(), requestFor(op)); err != nil {
+		t.Fatal(err)
+	}
+	completed := verifyCompleted(t, cl, op, corev1.ConditionTrue, "")
+	if completed.Status.Stdout != "done" {
+		t.Errorf("the output of the command is not recorded: %q", completed.Status.Stdout)
+	}
+}
+
+func TestRuntimeOperationAlreadyCompleted(t *testing.T) {
+	op := createRuntimeOperation("completed")
+	op.Generation = 1
+	r, cl := createRuntimeOperationReconciler(op)
+	recorder := r.Recorder.(*record.FakeRecorder)
+	if _, err := r.completeOperation(op, corev1.ConditionTrue, "", ""); err != nil {
+		t.Fatal(err)
+	}
+
+	// Reconciling the completed RuntimeOperation again, such as after a restart of the operator, is silent
+	if _, err := r.Reconcile(context.TODO(), requestFor(op)); err != nil {
+		t.Fatal(err)
+	}
+	if len(recorder.Events) != 0 {
+		t.Errorf("unexpected event for an unchanged RuntimeOperation: %s", <-recorder.Events)
+	}
+
+	// A change of the spec of the completed RuntimeOperation is reported once
+	completed := verifyCompleted(t, cl, op, corev1.ConditionTrue, "")
+	completed.Spec.Command = []string{"/bin/false"}
+	completed.Generation++
+	if err := cl.Update(context.TODO(), completed); err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; i < 2; i++ {
+		if _, err := r.Reconcile(context.TODO(), requestFor(op)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if len(recorder.Events) != 1 {
+		t.Errorf("expected one event for the change of the completed RuntimeOperation, got %d", len(recorder.Events))
+	}
+}
+
+func TestRuntimeOperationRetry(t *testing.T) {
+	defer func(backoff time.Duration, run func(context.Context, *rest.Config, string, string, string, []string) (*utils.CommandOutput, error)) {
+		operationRetryBackoff, runCommandInContainer = backoff, run
+	}(operationRetryBackoff, runCommandInContainer)
+	operationRetryBackoff = time.Millisecond
+
+	calls, exitCode := 0, 0
+	runCommandInContainer = func(context.Context, *rest.Config, string, string, string, []string) (*utils.CommandOutput, error) {
+		calls++
+		if calls == 1 {
+			return nil, errors.New("connection to the pod failed")
+		}
+		return &utils.CommandOutput{ExitCode: exitCode}, nil
+	}
+	pod := &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "my-pod", Namespace: operationNamespace}}
+
+	op := createRuntimeOperation("retry")
+	retries := int32(2)
+	op.Spec.Retries = &retries
+	r, _ := createRuntimeOperationReconciler(op)
+	result := r.runCommandOnPod(context.TODO(), op, pod, "app")
+	if result.attempts != 2 || result.failureMessage() != "" {
+		t.Errorf("expected the command to succeed on the second attempt, got %d attempts and failure %q", result.attempts, result.failureMessage())
+	}
+
+	calls = 0
+	noRetries := createRuntimeOperation("no-retry")
+	result = r.runCommandOnPod(context.TODO(), noRetries, pod, "app")
+	if result.attempts != 1 || result.failureMessage() == "" {
+		t.Errorf("expected the command to fail without a retry, got %d attempts and failure %q", result.attempts, result.failureMessage())
+	}
+
+	// The command ran and exited with a non-zero code, so running it again could repeat its side effects
+	calls, exitCode = 1, 1
+	result = r.runCommandOnPod(context.TODO(), op, pod, "app")
+	if result.attempts != 1 || result.output == nil || result.output.ExitCode != 1 {
+		t.Errorf("expected the command that exited with a non-zero code not to be retried, got %d attempts and failure %q", result.attempts, result.failureMessage())
+	}
+}
+
+func TestRuntimeOperationStartsAfterStatusUpdate(t *testing.T) {
+	op := createRuntimeOperation("start")
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: op.Spec.PodName, Namespace: operationNamespace},
+		Spec:       corev1.PodSpec{Containers: []corev1.Container{{Name: "app"}}},
+		Status:     corev1.PodStatus{Phase: corev1.PodRunning},
+	}
+	r, cl := createRuntimeOperationReconciler(op, pod)
+
+	// The command is not run until the operation is recorded as started
+	failing := r.withClient(&failingStatusClient{Client: cl})
+	if _, err := failing.Reconcile(context.TODO(), requestFor(op)); err == nil {
+		t.Error("expected the status update error to be returned")
+	}
+	if r.getRun(requestFor(op).NamespacedName) != nil {
+		t.Error("the command was started before the status was saved")
+	}
+}
+
+// failingStatusClient fails all the status updates, such as on a conflict
+type failingStatusClient struct {
+	client.Client
+}
+
+func (c *failingStatusClient) Status() client.StatusWriter {
+	return &failingStatusWriter{}
+}
+
+type failingStatusWriter struct{}
+
+func (w *failingStatusWriter) Update(context.Context, client.Object, ...client.UpdateOption) error {
+	return errors.New("status update failed")
+}
+
+func (w *failingStatusWriter) Patch(context.Context, client.Object, client.Patch, ...client.PatchOption) error {
+	return errors.New("status patch failed")
+}
+
+func createRuntimeOperation(name string) *appstacksv1.RuntimeOperation {
+	return &appstacksv1.RuntimeOperation{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: operationNamespace, CreationTimestamp: metav1.Now()},
+		Spec:       appstacksv1.RuntimeOperationSpec{PodName: "my-pod", Command: []string{"/bin/true"}},
+	}
+}
+
+func createRuntimeOperationReconciler(objs ...client.Object) (*RuntimeOperationReconciler, client.Client) {
+	s := runtime.NewScheme()
+	clientgoscheme.AddToScheme(s)
+	appstacksv1.AddToScheme(s)
+	cl := fakeclient.NewClientBuilder().WithScheme(s).WithObjects(objs...).Build()
+	recorder := record.NewFakeRecorder(100)
+	r := &RuntimeOperationReconciler{
+		Client:   cl,
+		Log:      zap.New(),
+		Scheme:   s,
+		Recorder: recorder,
+		Config:   utils.NewOperatorConfigWatcher(nil, nil, "", recorder),
+		runs:     map[types.NamespacedName]*operationRun{},
+	}
+	return r, cl
+}
+
+// withClient returns a reconciler that shares the runs of r and uses another client
+func (r *RuntimeOperationReconciler) withClient(cl client.Client) *RuntimeOperationReconciler {
+	return &RuntimeOperationReconciler{Client: cl, Log: r.Log, Scheme: r.Scheme, Recorder: r.Recorder, Config: r.Config, runs: r.runs}
+}
+
+// trackRun tracks a command running in the background for the RuntimeOperation, which has started
+func trackRun(r *RuntimeOperationReconciler, op *appstacksv1.RuntimeOperation) *operationRun {
+	run := &operationRun{}
+	run.ctx, run.cancel = context.WithCancel(context.Background())
+	r.runs[requestFor(op).NamespacedName] = run
+	return run
+}
+
+func requestFor(op *appstacksv1.RuntimeOperation) ctrl.Request {
+	return ctrl.Request{NamespacedName: types.NamespacedName{Name: op.Name, Namespace: op.Namespace}}
+}
+
+// verifyCompleted checks the Completed condition of the RuntimeOperation and returns the RuntimeOperation
+func verifyCompleted(t *testing.T, cl client.Client, op *appstacksv1.RuntimeOperation, status corev1.ConditionStatus, reason string) *appstacksv1.RuntimeOperation {
+	t.Helper()
+	result := &appstacksv1.RuntimeOperation{}
+	if err := cl.Get(context.TODO(), requestFor(op).NamespacedName, result); err != nil {
+		t.Fatal(err)
+	}
+	c := appstacksv1.GetOperationCondition(result.Status.Conditions, appstacksv1.OperationStatusConditionTypeCompleted)
+	if c == nil {
+		t.Fatalf("RuntimeOperation %s is not completed", op.Name)
+	}
+	if c.Status != status || c.Reason != reason {
+		t.Errorf("expected the Completed condition to be %s with reason %q, got %s with reason %q", status, reason, c.Status, c.Reason)
+	}
+	return result
+}
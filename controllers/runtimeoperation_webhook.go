@@ -0,0 +1,69 @@
+/*
+
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+
+	appstacksv1 "github.com/application-stacks/runtime-component-operator/api/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+// +kubebuilder:webhook:path=/validate-rc-app-stacks-v1-runtimeoperation,mutating=false,failurePolicy=fail,sideEffects=None,groups=rc.app.stacks,resources=runtimeoperations,verbs=create;update,versions=v1,matchPolicy=Equivalent,name=vruntimeoperation.rc.app.stacks,admissionReviewVersions=v1
+
+// RuntimeOperationWebhook validates RuntimeOperation objects on admission and serves their conversion webhook
+type RuntimeOperationWebhook struct{}
+
+var _ admission.CustomValidator = &RuntimeOperationWebhook{}
+
+// SetupWebhookWithManager registers the validating and conversion webhooks with the manager
+func (w *RuntimeOperationWebhook) SetupWebhookWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewWebhookManagedBy(mgr).
+		For(&appstacksv1.RuntimeOperation{}).
+		WithValidator(w).
+		Complete()
+}
+
+// ValidateCreate validates a RuntimeOperation on creation
+func (w *RuntimeOperationWebhook) ValidateCreate(ctx context.Context, obj runtime.Object) error {
+	return validateRuntimeOperation(obj)
+}
+
+// ValidateUpdate validates a RuntimeOperation on update
+func (w *RuntimeOperationWebhook) ValidateUpdate(ctx context.Context, oldObj, newObj runtime.Object) error {
+	return validateRuntimeOperation(newObj)
+}
+
+// ValidateDelete allows every deletion
+func (w *RuntimeOperationWebhook) ValidateDelete(ctx context.Context, obj runtime.Object) error {
+	return nil
+}
+
+func validateRuntimeOperation(obj runtime.Object) error {
+	instance, ok := obj.(*appstacksv1.RuntimeOperation)
+	if !ok {
+		return kerrors.NewBadRequest(fmt.Sprintf("expected a RuntimeOperation but got a %T", obj))
+	}
+	if err := instance.Spec.Validate(); err != nil {
+		return fmt.Errorf("validation failed: %v", err)
+	}
+	return nil
+}
@@ -0,0 +1,290 @@
+/*
+
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sort"
+	"time"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	appstacksv1 "github.com/application-stacks/runtime-component-operator/api/v1"
+	"github.com/application-stacks/runtime-component-operator/utils"
+)
+
+// maxMissedSchedules bounds the search for the most recent missed schedule time, e.g. after the operator was down
+const maxMissedSchedules = 100000
+
+// RuntimeOperationScheduleReconciler reconciles a RuntimeOperationSchedule object
+type RuntimeOperationScheduleReconciler struct {
+	client.Client
+	Log      logr.Logger
+	Scheme   *runtime.Scheme
+	Recorder record.EventRecorder
+}
+
+// +kubebuilder:rbac:groups=rc.app.stacks,resources=runtimeoperationschedules;runtimeoperationschedules/status;runtimeoperationschedules/finalizers,verbs=get;list;watch;create;update;delete,namespace=runtime-component-operator
+
+func (r *RuntimeOperationScheduleReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	reqLogger := r.Log.WithValues("Request.Namespace", req.Namespace, "Request.Name", req.Name)
+	reqLogger.Info("Reconciling RuntimeOperationSchedule")
+
+	// Fetch the RuntimeOperationSchedule instance
+	instance := &appstacksv1.RuntimeOperationSchedule{}
+	err := r.Client.Get(context.TODO(), req.NamespacedName, instance)
+	if err != nil {
+		if errors.IsNotFound(err) {
+			// Request object not found, could have been deleted after reconcile request.
+			// The RuntimeOperations it created are garbage collected.
+			return reconcile.Result{}, nil
+		}
+		return reconcile.Result{}, err
+	}
+
+	active, err := r.reconcileHistory(instance)
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+
+	schedule, err := utils.ParseCronSchedule(instance.Spec.Schedule)
+	if err != nil {
+		message := "RuntimeOperationSchedule '" + instance.Name + "' in namespace '" + req.Namespace + "' has an invalid schedule: " + err.Error()
+		reqLogger.Info(message)
+		r.Recorder.Event(instance, "Warning", "ProcessingError", message)
+		instance.Status.Message = message
+		return reconcile.Result{}, r.Client.Status().Update(context.TODO(), instance)
+	}
+	instance.Status.Message = ""
+
+	if instance.Spec.IsSuspended() {
+		return reconcile.Result{}, r.Client.Status().Update(context.TODO(), instance)
+	}
+
+	now := time.Now()
+	scheduledTime, nextTime := mostRecentScheduleTime(instance, schedule, now)
+	if scheduledTime.IsZero() {
+		if err := r.Client.Status().Update(context.TODO(), instance); err != nil {
+			return reconcile.Result{}, err
+		}
+		return requeueAt(nextTime, now), nil
+	}
+
+	instance.Status.LastScheduleTime = &metav1.Time{Time: scheduledTime}
+
+	if len(active) > 0 {
+		switch instance.Spec.GetConcurrencyPolicy() {
+		case appstacksv1.OperationConcurrencyPolicyForbid:
+			message := fmt.Sprintf("Skipped the operation scheduled at %s because %d operation(s) are still running", scheduledTime.Format(time.RFC3339), len(active))
+			reqLogger.Info(message)
+			r.Recorder.Event(instance, "Warning", "Skipped", message)
+			if err := r.Client.Status().Update(context.TODO(), instance); err != nil {
+				return reconcile.Result{}, err
+			}
+			return requeueAt(nextTime, now), nil
+		case appstacksv1.OperationConcurrencyPolicyReplace:
+			for _, op := range active {
+				if op.Spec.IsCancelled() {
+					continue
+				}
+				cancel := true
+				op.Spec.Cancel = &cancel
+				if err := r.Client.Update(context.TODO(), op); err != nil && !errors.IsNotFound(err) {
+					return reconcile.Result{}, err
+				}
+				r.Recorder.Event(instance, "Normal", "Replaced", "Cancelled RuntimeOperation '"+op.Name+"' to replace it with the operation scheduled at "+scheduledTime.Format(time.RFC3339))
+			}
+			active = nil
+		}
+	}
+
+	op, err := r.newOperation(instance, scheduledTime)
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+	// The operation already exists when the status of the schedule could not be updated after creating it
+	if err := r.Client.Create(context.TODO(), op); err == nil {
+		r.Recorder.Event(instance, "Normal", "Created", "Created RuntimeOperation '"+op.Name+"'")
+	} else if !errors.IsAlreadyExists(err) {
+		message := "Failed to create RuntimeOperation '" + op.Name + "' in namespace '" + req.Namespace + "'"
+		reqLogger.Error(err, message)
+		r.Recorder.Event(instance, "Warning", "ProcessingError", message+": "+err.Error())
+		return reconcile.Result{}, err
+	}
+
+	instance.Status.Active = append(activeReferences(active), corev1.LocalObjectReference{Name: op.Name})
+	if err := r.Client.Status().Update(context.TODO(), instance); err != nil {
+		return reconcile.Result{}, err
+	}
+	return requeueAt(nextTime, now), nil
+}
+
+// reconcileHistory records the running operations of the schedule in its status and deletes the oldest
+// completed operations beyond the history limits. It returns the running operations.
+func (r *RuntimeOperationScheduleReconciler) reconcileHistory(instance *appstacksv1.RuntimeOperationSchedule) ([]*appstacksv1.RuntimeOperation, error) {
+	ops := &appstacksv1.RuntimeOperationList{}
+	if err := r.Client.List(context.TODO(), ops, client.InNamespace(instance.Namespace), client.MatchingLabels{appstacksv1.OperationScheduleLabel: instance.Name}); err != nil {
+		return nil, err
+	}
+	sort.Slice(ops.Items, func(i, j int) bool {
+		return ops.Items[i].CreationTimestamp.Before(&ops.Items[j].CreationTimestamp)
+	})
+
+	var active, succeeded, failed []*appstacksv1.RuntimeOperation
+	for i := range ops.Items {
+		op := &ops.Items[i]
+		if !metav1.IsControlledBy(op, instance) {
+			continue
+		}
+		c := appstacksv1.GetOperationCondition(op.Status.Conditions, appstacksv1.OperationStatusConditionTypeCompleted)
+		switch {
+		case c == nil:
+			active = append(active, op)
+		case c.Status == corev1.ConditionTrue:
+			succeeded = append(succeeded, op)
+			if instance.Status.LastSuccessfulTime == nil || instance.Status.LastSuccessfulTime.Before(&c.LastUpdateTime) {
+				instance.Status.LastSuccessfulTime = c.LastUpdateTime.DeepCopy()
+			}
+		default:
+			failed = append(failed, op)
+		}
+	}
+
+	if err := r.deleteOldest(succeeded, instance.Spec.GetSuccessfulOperationsHistoryLimit()); err != nil {
+		return nil, err
+	}
+	if err := r.deleteOldest(failed, instance.Spec.GetFailedOperationsHistoryLimit()); err != nil {
+		return nil, err
+	}
+
+	instance.Status.Active = activeReferences(active)
+	return active, nil
+}
+
+// deleteOldest deletes the operations, sorted from oldest to newest, that are beyond the limit
+func (r *RuntimeOperationScheduleReconciler) deleteOldest(ops []*appstacksv1.RuntimeOperation, limit int) error {
+	for i := 0; i < len(ops)-limit; i++ {
+		if err := r.Client.Delete(context.TODO(), ops[i], client.PropagationPolicy(metav1.DeletePropagationBackground)); err != nil && !errors.IsNotFound(err) {
+			return err
+		}
+	}
+	return nil
+}
+
+// newOperation returns the RuntimeOperation to create for the time it is scheduled at
+func (r *RuntimeOperationScheduleReconciler) newOperation(instance *appstacksv1.RuntimeOperationSchedule, scheduledTime time.Time) (*appstacksv1.RuntimeOperation, error) {
+	template := instance.Spec.OperationTemplate.DeepCopy()
+	op := &appstacksv1.RuntimeOperation{
+		ObjectMeta: metav1.ObjectMeta{
+			// Named after the scheduled minute, so that the operation is created only once for each time
+			Name:        fmt.Sprintf("%s-%d", instance.Name, scheduledTime.Unix()/60),
+			Namespace:   instance.Namespace,
+			Labels:      utils.MergeMaps(template.Metadata.Labels, map[string]string{appstacksv1.OperationScheduleLabel: instance.Name}),
+			Annotations: utils.MergeMaps(template.Metadata.Annotations, map[string]string{appstacksv1.OperationScheduledTimeAnnotation: scheduledTime.Format(time.RFC3339)}),
+		},
+		Spec: template.Spec,
+	}
+	if err := controllerutil.SetControllerReference(instance, op, r.Scheme); err != nil {
+		return nil, err
+	}
+	return op, nil
+}
+
+// mostRecentScheduleTime returns the most recent schedule time that is due and was not processed yet, or the zero
+// time if there is none, along with the next schedule time after now. Earlier missed times are not run.
+func mostRecentScheduleTime(instance *appstacksv1.RuntimeOperationSchedule, schedule *utils.CronSchedule, now time.Time) (time.Time, time.Time) {
+	last := instance.CreationTimestamp.Time
+	if instance.Status.LastScheduleTime != nil {
+		last = instance.Status.LastScheduleTime.Time
+	}
+
+	var scheduled time.Time
+	next := schedule.Next(last)
+	for i := 0; i < maxMissedSchedules && !next.IsZero() && !next.After(now); i++ {
+		scheduled = next
+		next = schedule.Next(next)
+	}
+	return scheduled, next
+}
+
+// requeueAt reconciles the schedule again at the next schedule time
+func requeueAt(next, now time.Time) reconcile.Result {
+	if next.IsZero() {
+		return reconcile.Result{}
+	}
+	return reconcile.Result{RequeueAfter: next.Sub(now)}
+}
+
+func activeReferences(ops []*appstacksv1.RuntimeOperation) []corev1.LocalObjectReference {
+	var refs []corev1.LocalObjectReference
+	for _, op := range ops {
+		refs = append(refs, corev1.LocalObjectReference{Name: op.Name})
+	}
+	return refs
+}
+
+func (r *RuntimeOperationScheduleReconciler) SetupWithManager(mgr ctrl.Manager) error {
+
+	watchNamespaces, err := utils.GetWatchNamespaces()
+	if err != nil {
+		r.Log.Error(err, "Failed to get watch namespace")
+		os.Exit(1)
+	}
+
+	watchNamespacesMap := make(map[string]bool)
+	for _, ns := range watchNamespaces {
+		watchNamespacesMap[ns] = true
+	}
+	isClusterWide := len(watchNamespacesMap) == 1 && watchNamespacesMap[""]
+
+	r.Log.V(1).Info("Adding a new controller", "watchNamespaces", watchNamespaces, "isClusterWide", isClusterWide)
+
+	pred := predicate.Funcs{
+		UpdateFunc: func(e event.UpdateEvent) bool {
+			// Ignore updates to CR status in which case metadata.Generation does not change
+			return e.ObjectOld.GetGeneration() != e.ObjectNew.GetGeneration() && (isClusterWide || watchNamespacesMap[e.ObjectOld.GetNamespace()])
+		},
+		CreateFunc: func(e event.CreateEvent) bool {
+			return isClusterWide || watchNamespacesMap[e.Object.GetNamespace()]
+		},
+		DeleteFunc: func(e event.DeleteEvent) bool {
+			return isClusterWide || watchNamespacesMap[e.Object.GetNamespace()]
+		},
+		GenericFunc: func(e event.GenericEvent) bool {
+			return isClusterWide || watchNamespacesMap[e.Object.GetNamespace()]
+		},
+	}
+
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&appstacksv1.RuntimeOperationSchedule{}, builder.WithPredicates(pred)).
+		Owns(&appstacksv1.RuntimeOperation{}).
+		Complete(r)
+}
@@ -0,0 +1,93 @@
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	appstacksv1 "github.com/application-stacks/runtime-component-operator/api/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+)
+
+func TestRuntimeOperationScheduleHistory(t *testing.T) {
+	schedule := &appstacksv1.RuntimeOperationSchedule{
+		ObjectMeta: metav1.ObjectMeta{Name: "nightly", Namespace: operationNamespace, UID: types.UID("nightly-uid")},
+		Spec:       appstacksv1.RuntimeOperationScheduleSpec{Schedule: "0 2 * * *"},
+	}
+	// The reconciler is only used for its scheme, which is shared by all the reconcilers of the tests
+	opr, _ := createRuntimeOperationReconciler()
+
+	older := time.Now().Add(-time.Hour).Truncate(time.Second)
+	newer := time.Now().Add(-time.Minute).Truncate(time.Second)
+	ops := []client.Object{
+		scheduledOperation(t, opr, schedule, "nightly-1", corev1.ConditionTrue, older),
+		scheduledOperation(t, opr, schedule, "nightly-2", corev1.ConditionTrue, newer),
+		scheduledOperation(t, opr, schedule, "nightly-3", corev1.ConditionFalse, time.Now()),
+		scheduledOperation(t, opr, schedule, "nightly-4", "", time.Time{}),
+	}
+	r, _ := createRuntimeOperationReconciler(ops...)
+	sr := &RuntimeOperationScheduleReconciler{Client: r.Client, Log: r.Log, Scheme: r.Scheme, Recorder: r.Recorder}
+
+	active, err := sr.reconcileHistory(schedule)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if schedule.Status.LastSuccessfulTime == nil {
+		t.Fatal("the last successful time is not set")
+	}
+	if !schedule.Status.LastSuccessfulTime.Time.Equal(newer) {
+		t.Errorf("expected the last successful time to be %v, got %v", newer, schedule.Status.LastSuccessfulTime.Time)
+	}
+	if len(active) != 1 || active[0].Name != "nightly-4" {
+		t.Errorf("expected nightly-4 to be the only active operation, got %v", schedule.Status.Active)
+	}
+}
+
+func TestRuntimeOperationScheduleAlreadyCreated(t *testing.T) {
+	schedule := &appstacksv1.RuntimeOperationSchedule{
+		ObjectMeta: metav1.ObjectMeta{Name: "minutely", Namespace: operationNamespace, CreationTimestamp: metav1.NewTime(time.Now().Add(-2 * time.Minute))},
+		Spec:       appstacksv1.RuntimeOperationScheduleSpec{Schedule: "* * * * *"},
+	}
+	// The operations of the current and the next minute exist, such as when the status of the schedule could not be
+	// updated after they were created
+	minute := time.Now().Unix() / 60
+	r, _ := createRuntimeOperationReconciler(schedule,
+		createRuntimeOperation(fmt.Sprintf("%s-%d", schedule.Name, minute)),
+		createRuntimeOperation(fmt.Sprintf("%s-%d", schedule.Name, minute+1)))
+	sr := &RuntimeOperationScheduleReconciler{Client: r.Client, Log: r.Log, Scheme: r.Scheme, Recorder: r.Recorder}
+
+	req := ctrl.Request{NamespacedName: types.NamespacedName{Name: schedule.Name, Namespace: schedule.Namespace}}
+	if _, err := sr.Reconcile(context.TODO(), req); err != nil {
+		t.Fatal(err)
+	}
+	recorder := r.Recorder.(*record.FakeRecorder)
+	if len(recorder.Events) != 0 {
+		t.Errorf("unexpected event for an operation that already exists: %s", <-recorder.Events)
+	}
+}
+
+// scheduledOperation returns a RuntimeOperation created by the schedule. The operation is completed with the given
+// status at the given time, or is still running when the status is empty.
+func scheduledOperation(t *testing.T, r *RuntimeOperationReconciler, schedule *appstacksv1.RuntimeOperationSchedule, name string, status corev1.ConditionStatus, completed time.Time) *appstacksv1.RuntimeOperation {
+	t.Helper()
+	op := createRuntimeOperation(name)
+	op.Labels = map[string]string{appstacksv1.OperationScheduleLabel: schedule.Name}
+	if err := controllerutil.SetControllerReference(schedule, op, r.Scheme); err != nil {
+		t.Fatal(err)
+	}
+	if status != "" {
+		op.Status.Conditions = []appstacksv1.OperationStatusCondition{{
+			Type:           appstacksv1.OperationStatusConditionTypeCompleted,
+			Status:         status,
+			LastUpdateTime: metav1.NewTime(completed),
+		}}
+	}
+	return op
+}
@@ -0,0 +1,73 @@
+/*
+
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+
+	appstacksv1 "github.com/application-stacks/runtime-component-operator/api/v1"
+	"github.com/application-stacks/runtime-component-operator/utils"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+// +kubebuilder:webhook:path=/validate-rc-app-stacks-v1-runtimeoperationschedule,mutating=false,failurePolicy=fail,sideEffects=None,groups=rc.app.stacks,resources=runtimeoperationschedules,verbs=create;update,versions=v1,matchPolicy=Equivalent,name=vruntimeoperationschedule.rc.app.stacks,admissionReviewVersions=v1
+
+// RuntimeOperationScheduleWebhook validates RuntimeOperationSchedule objects on admission
+type RuntimeOperationScheduleWebhook struct{}
+
+var _ admission.CustomValidator = &RuntimeOperationScheduleWebhook{}
+
+// SetupWebhookWithManager registers the validating webhook with the manager
+func (w *RuntimeOperationScheduleWebhook) SetupWebhookWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewWebhookManagedBy(mgr).
+		For(&appstacksv1.RuntimeOperationSchedule{}).
+		WithValidator(w).
+		Complete()
+}
+
+// ValidateCreate validates a RuntimeOperationSchedule on creation
+func (w *RuntimeOperationScheduleWebhook) ValidateCreate(ctx context.Context, obj runtime.Object) error {
+	return validateRuntimeOperationSchedule(obj)
+}
+
+// ValidateUpdate validates a RuntimeOperationSchedule on update
+func (w *RuntimeOperationScheduleWebhook) ValidateUpdate(ctx context.Context, oldObj, newObj runtime.Object) error {
+	return validateRuntimeOperationSchedule(newObj)
+}
+
+// ValidateDelete allows every deletion
+func (w *RuntimeOperationScheduleWebhook) ValidateDelete(ctx context.Context, obj runtime.Object) error {
+	return nil
+}
+
+func validateRuntimeOperationSchedule(obj runtime.Object) error {
+	instance, ok := obj.(*appstacksv1.RuntimeOperationSchedule)
+	if !ok {
+		return kerrors.NewBadRequest(fmt.Sprintf("expected a RuntimeOperationSchedule but got a %T", obj))
+	}
+	if _, err := utils.ParseCronSchedule(instance.Spec.Schedule); err != nil {
+		return fmt.Errorf("validation failed: %v", err)
+	}
+	if err := instance.Spec.OperationTemplate.Spec.Validate(); err != nil {
+		return fmt.Errorf("validation failed: operationTemplate: %v", err)
+	}
+	return nil
+}
@@ -0,0 +1,82 @@
+/*
+
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+
+	appstacksv1 "github.com/application-stacks/runtime-component-operator/api/v1"
+	appstacksutils "github.com/application-stacks/runtime-component-operator/utils"
+	"github.com/go-logr/logr"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+)
+
+// StorageVersionMigrator rewrites the existing RuntimeComponent and RuntimeOperation objects once the
+// operator starts, so that objects persisted in an older API version are stored again in the
+// storage version (v1). After it has run, the older version can be removed from the
+// status.storedVersions of the CRDs.
+type StorageVersionMigrator struct {
+	Reader client.Reader
+	Client client.Client
+	Log    logr.Logger
+}
+
+var _ manager.LeaderElectionRunnable = &StorageVersionMigrator{}
+
+// NeedLeaderElection makes sure that only the leader migrates objects
+func (m *StorageVersionMigrator) NeedLeaderElection() bool {
+	return true
+}
+
+// Start migrates the objects in every watched namespace
+func (m *StorageVersionMigrator) Start(ctx context.Context) error {
+	namespaces, err := appstacksutils.GetWatchNamespaces()
+	if err != nil {
+		m.Log.Error(err, "Error getting watch namespace, storage version migration is skipped")
+		return nil
+	}
+
+	for _, ns := range namespaces {
+		components := &appstacksv1.RuntimeComponentList{}
+		if err := m.Reader.List(ctx, components, client.InNamespace(ns)); err != nil {
+			m.Log.Error(err, "Error listing RuntimeComponents for storage version migration", "namespace", ns)
+		} else {
+			for i := range components.Items {
+				m.migrate(ctx, &components.Items[i])
+			}
+		}
+
+		operations := &appstacksv1.RuntimeOperationList{}
+		if err := m.Reader.List(ctx, operations, client.InNamespace(ns)); err != nil {
+			m.Log.Error(err, "Error listing RuntimeOperations for storage version migration", "namespace", ns)
+		} else {
+			for i := range operations.Items {
+				m.migrate(ctx, &operations.Items[i])
+			}
+		}
+	}
+	return nil
+}
+
+// migrate issues an update without changes, which makes the API server persist the object in the storage version
+func (m *StorageVersionMigrator) migrate(ctx context.Context, obj client.Object) {
+	if err := m.Client.Update(ctx, obj); err != nil && !kerrors.IsConflict(err) && !kerrors.IsNotFound(err) {
+		m.Log.Error(err, "Error migrating object to the storage version", "name", obj.GetName(), "namespace", obj.GetNamespace())
+	}
+}
@@ -17,6 +17,7 @@ limitations under the License.
 package main
 
 import (
+	"context"
 	"flag"
 	"fmt"
 	"os"
@@ -27,8 +28,11 @@ import (
 	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
 	_ "k8s.io/client-go/plugin/pkg/client/auth/gcp"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/healthz"
 	"sigs.k8s.io/controller-runtime/pkg/log/zap"
 
+	appstacksv1 "github.com/application-stacks/runtime-component-operator/api/v1"
 	appstacksv1beta2 "github.com/application-stacks/runtime-component-operator/api/v1beta2"
 	"github.com/application-stacks/runtime-component-operator/controllers"
 	"github.com/application-stacks/runtime-component-operator/utils"
@@ -49,6 +53,7 @@ var (
 func init() {
 	utilruntime.Must(clientgoscheme.AddToScheme(scheme))
 
+	utilruntime.Must(appstacksv1.AddToScheme(scheme))
 	utilruntime.Must(appstacksv1beta2.AddToScheme(scheme))
 
 	utilruntime.Must(routev1.AddToScheme(scheme))
@@ -63,19 +68,35 @@ func init() {
 }
 
 func main() {
-	//var metricsAddr string
+	var metricsAddr string
+	var probeAddr string
 	var enableLeaderElection bool
-	//flag.StringVar(&metricsAddr, "metrics-addr", ":8080", "The address the metric endpoint binds to.")
-	flag.BoolVar(&enableLeaderElection, "enable-leader-election", false,
+	var leaderElectionNamespace string
+	var leaseDuration, renewDeadline, retryPeriod time.Duration
+	flag.StringVar(&metricsAddr, "metrics-addr", ":8080", "The address the metric endpoint binds to. Set to 0 to disable the metric endpoint.")
+	flag.StringVar(&probeAddr, "health-probe-bind-address", envOrDefault("HEALTH_PROBE_BIND_ADDRESS", ":8081"),
+		"The address the health and readiness probe endpoints bind to. Can also be set with the HEALTH_PROBE_BIND_ADDRESS environment variable.")
+	flag.BoolVar(&enableLeaderElection, "enable-leader-election", envOrDefault("ENABLE_LEADER_ELECTION", "false") == "true",
 		"Enable leader election for controller manager. "+
-			"Enabling this will ensure there is only one active controller manager.")
+			"Enabling this will ensure there is only one active controller manager. Can also be set with the ENABLE_LEADER_ELECTION environment variable.")
+	flag.StringVar(&leaderElectionNamespace, "leader-election-namespace", envOrDefault("LEADER_ELECTION_NAMESPACE", os.Getenv("OPERATOR_NAMESPACE")),
+		"The namespace of the leader election lock. Defaults to the namespace of the operator. Can also be set with the LEADER_ELECTION_NAMESPACE environment variable.")
+	// see https://github.com/operator-framework/operator-sdk/issues/1813
+	flag.DurationVar(&leaseDuration, "leader-election-lease-duration", durationEnvOrDefault("LEADER_ELECTION_LEASE_DURATION", 30*time.Second),
+		"The duration that non-leader candidates wait before forcing to acquire the leadership. Can also be set with the LEADER_ELECTION_LEASE_DURATION environment variable.")
+	flag.DurationVar(&renewDeadline, "leader-election-renew-deadline", durationEnvOrDefault("LEADER_ELECTION_RENEW_DEADLINE", 20*time.Second),
+		"The duration that the leader retries to refresh the leadership before giving it up. Can also be set with the LEADER_ELECTION_RENEW_DEADLINE environment variable.")
+	flag.DurationVar(&retryPeriod, "leader-election-retry-period", durationEnvOrDefault("LEADER_ELECTION_RETRY_PERIOD", 5*time.Second),
+		"The duration that the candidates wait between attempts to acquire or renew the leadership. Can also be set with the LEADER_ELECTION_RETRY_PERIOD environment variable.")
 	flag.Parse()
 
 	ctrl.SetLogger(zap.New(zap.UseDevMode(true)))
 
-	// see https://github.com/operator-framework/operator-sdk/issues/1813
-	leaseDuration := 30 * time.Second
-	renewDeadline := 20 * time.Second
+	if enableLeaderElection && (leaseDuration <= renewDeadline || renewDeadline <= retryPeriod || retryPeriod <= 0) {
+		setupLog.Error(fmt.Errorf("lease duration %s, renew deadline %s and retry period %s must be positive and decreasing", leaseDuration, renewDeadline, retryPeriod),
+			"invalid leader election configuration")
+		os.Exit(1)
+	}
 
 	watchNamespace, err := getWatchNamespace()
 	if err != nil {
@@ -83,24 +104,62 @@ func main() {
 			"the manager will watch and manage resources in all Namespaces")
 	}
 
+	// The leader election lock is created in the namespace of the operator, which works whether the operator
+	// watches its own namespace, another namespace or all namespaces
 	mgr, err := ctrl.NewManager(ctrl.GetConfigOrDie(), ctrl.Options{
-		Scheme:             scheme,
-		MetricsBindAddress: "0",
-		Port:               9443,
-		LeaderElection:     enableLeaderElection,
-		LeaderElectionID:   "c407d44e.rc.app.stacks",
-		LeaseDuration:      &leaseDuration,
-		RenewDeadline:      &renewDeadline,
-		Namespace:          watchNamespace,
+		Scheme:                        scheme,
+		MetricsBindAddress:            metricsAddr,
+		HealthProbeBindAddress:        probeAddr,
+		Port:                          9443,
+		LeaderElection:                enableLeaderElection,
+		LeaderElectionID:              "c407d44e.rc.app.stacks",
+		LeaderElectionNamespace:       leaderElectionNamespace,
+		LeaderElectionReleaseOnCancel: true,
+		LeaseDuration:                 &leaseDuration,
+		RenewDeadline:                 &renewDeadline,
+		RetryPeriod:                   &retryPeriod,
+		Namespace:                     watchNamespace,
 	})
 	if err != nil {
 		setupLog.Error(err, "unable to start manager")
 		os.Exit(1)
 	}
 
+	// The operator configuration is read from the ConfigMap in the namespace of the operator or, when running
+	// the operator locally, in the first watched namespace
+	configNamespace, _ := utils.GetOperatorNamespace()
+	if configNamespace == "" {
+		watchNamespaces, err := utils.GetWatchNamespaces()
+		if err != nil || len(watchNamespaces) == 0 {
+			setupLog.Error(err, "unable to get the namespace of the operator configuration")
+			os.Exit(1)
+		}
+		configNamespace = watchNamespaces[0]
+	}
+	// The client reads the ConfigMap before the caches are started
+	configClient, err := client.New(mgr.GetConfig(), client.Options{Scheme: mgr.GetScheme(), Mapper: mgr.GetRESTMapper()})
+	if err != nil {
+		setupLog.Error(err, "unable to create a Kubernetes client")
+		os.Exit(1)
+	}
+	configCache, err := utils.NewOperatorConfigCache(mgr.GetConfig(), mgr.GetScheme(), mgr.GetRESTMapper(), configNamespace)
+	if err != nil {
+		setupLog.Error(err, "unable to create the cache of the operator configuration")
+		os.Exit(1)
+	}
+	operatorConfig := utils.NewOperatorConfigWatcher(configClient, configCache, configNamespace, mgr.GetEventRecorderFor("runtime-component-operator"))
+	if err = operatorConfig.Load(context.Background()); err != nil {
+		setupLog.Error(err, "unable to load the operator configuration, the default configuration is used until the config map can be read")
+	}
+	if err = mgr.Add(operatorConfig); err != nil {
+		setupLog.Error(err, "unable to watch the operator configuration")
+		os.Exit(1)
+	}
+
 	if err = (&controllers.RuntimeComponentReconciler{
 		ReconcilerBase: utils.NewReconcilerBase(mgr.GetAPIReader(), mgr.GetClient(), mgr.GetScheme(), mgr.GetConfig(), mgr.GetEventRecorderFor("runtime-component-operator")),
 		Log:            ctrl.Log.WithName("controllers").WithName("RuntimeComponent"),
+		Config:         operatorConfig,
 	}).SetupWithManager(mgr); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "RuntimeComponent")
 		os.Exit(1)
@@ -111,12 +170,58 @@ func main() {
 		Scheme:     mgr.GetScheme(),
 		Recorder:   mgr.GetEventRecorderFor(""),
 		RestConfig: mgr.GetConfig(),
+		Config:     operatorConfig,
 	}).SetupWithManager(mgr); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "RuntimeOperation")
 		os.Exit(1)
 	}
+	if err = (&controllers.RuntimeOperationScheduleReconciler{
+		Client:   mgr.GetClient(),
+		Log:      ctrl.Log.WithName("controllers").WithName("RuntimeOperationSchedule"),
+		Scheme:   mgr.GetScheme(),
+		Recorder: mgr.GetEventRecorderFor("runtime-component-operator"),
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "RuntimeOperationSchedule")
+		os.Exit(1)
+	}
+	if os.Getenv("ENABLE_WEBHOOKS") != "false" {
+		if err = (&controllers.RuntimeComponentWebhook{}).SetupWebhookWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create webhook", "webhook", "RuntimeComponent")
+			os.Exit(1)
+		}
+		if err = (&controllers.RuntimeOperationWebhook{}).SetupWebhookWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create webhook", "webhook", "RuntimeOperation")
+			os.Exit(1)
+		}
+		if err = (&controllers.RuntimeOperationScheduleWebhook{}).SetupWebhookWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create webhook", "webhook", "RuntimeOperationSchedule")
+			os.Exit(1)
+		}
+	}
+	if err = utils.RegisterResourceMetrics(mgr.GetClient(), "runtime-component-operator"); err != nil {
+		setupLog.Error(err, "unable to register metrics")
+		os.Exit(1)
+	}
+	if err = mgr.Add(&controllers.StorageVersionMigrator{
+		Reader: mgr.GetAPIReader(),
+		Client: mgr.GetClient(),
+		Log:    ctrl.Log.WithName("controllers").WithName("StorageVersionMigrator"),
+	}); err != nil {
+		setupLog.Error(err, "unable to add storage version migrator")
+		os.Exit(1)
+	}
 	// +kubebuilder:scaffold:builder
 
+	// Replicas that are not the leader are ready too, since they serve the webhooks and can take over the leadership
+	if err := mgr.AddHealthzCheck("healthz", healthz.Ping); err != nil {
+		setupLog.Error(err, "unable to set up health check")
+		os.Exit(1)
+	}
+	if err := mgr.AddReadyzCheck("readyz", healthz.Ping); err != nil {
+		setupLog.Error(err, "unable to set up ready check")
+		os.Exit(1)
+	}
+
 	setupLog.Info("starting manager")
 	if err := mgr.Start(ctrl.SetupSignalHandler()); err != nil {
 		setupLog.Error(err, "problem running manager")
@@ -137,3 +242,26 @@ func getWatchNamespace() (string, error) {
 	}
 	return ns, nil
 }
+
+// envOrDefault returns the value of the environment variable, or the default value if it is not set
+func envOrDefault(name string, defaultValue string) string {
+	if value, found := os.LookupEnv(name); found && value != "" {
+		return value
+	}
+	return defaultValue
+}
+
+// durationEnvOrDefault returns the duration set in the environment variable, or the default value if it is not set
+func durationEnvOrDefault(name string, defaultValue time.Duration) time.Duration {
+	value := envOrDefault(name, "")
+	if value == "" {
+		return defaultValue
+	}
+	duration, err := time.ParseDuration(value)
+	if err != nil {
+		// The logger is not set up yet while the flags are defined
+		fmt.Fprintf(os.Stderr, "invalid duration in environment variable %s: %v\n", name, err)
+		os.Exit(1)
+	}
+	return duration
+}
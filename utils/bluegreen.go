@@ -0,0 +1,108 @@
+package utils
+
+import (
+	"encoding/json"
+	"fmt"
+	"hash/fnv"
+	"time"
+
+	appstacksv1 "github.com/application-stacks/runtime-component-operator/api/v1"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// ColorLabel is set on the Deployments of the BlueGreen strategy and their pods
+	ColorLabel = "rc.app.stacks/color"
+	// RevisionAnnotation is set on the Deployments of the BlueGreen strategy to the revision of their pods
+	RevisionAnnotation = "rc.app.stacks/revision"
+	// PromoteAnnotation of a RuntimeComponent promotes the preview revision it is set to
+	PromoteAnnotation = "rc.app.stacks/promote"
+	// RollbackAnnotation of a RuntimeComponent rolls back the active revision it is set to
+	RollbackAnnotation = "rc.app.stacks/rollback"
+
+	BlueGreenStrategy = "BlueGreen"
+	Blue              = "blue"
+	Green             = "green"
+
+	defaultBlueGreenRollbackWindow = 600 * time.Second
+)
+
+// IsBlueGreenEnabled returns true when the BlueGreen strategy is selected for the Deployment of the component
+func IsBlueGreenEnabled(instance *appstacksv1.RuntimeComponent) bool {
+	return instance.Spec.Deployment != nil && instance.Spec.Deployment.Strategy == BlueGreenStrategy
+}
+
+// ValidateBlueGreen validates the BlueGreen strategy of a RuntimeComponent
+func ValidateBlueGreen(instance *appstacksv1.RuntimeComponent) error {
+	if instance.Spec.Deployment == nil || instance.Spec.Deployment.Strategy != BlueGreenStrategy {
+		return nil
+	}
+	if instance.Spec.StatefulSet != nil {
+		return createValidationError(conflictingFieldsMessage("spec.deployment.strategy", "spec.statefulSet"))
+	}
+	if instance.Spec.CreateKnativeService != nil && *instance.Spec.CreateKnativeService {
+		return createValidationError(conflictingFieldsMessage("spec.deployment.strategy", "spec.createKnativeService"))
+	}
+	if instance.Spec.Deployment.Canary != nil {
+		return createValidationError(conflictingFieldsMessage("spec.deployment.strategy", "spec.deployment.canary"))
+	}
+	// The autoscalers target the Deployment named after the component
+	if instance.Spec.Autoscaling != nil {
+		return createValidationError(conflictingFieldsMessage("spec.deployment.strategy", "spec.autoscaling"))
+	}
+	if instance.Spec.EventDrivenAutoscaling != nil {
+		return createValidationError(conflictingFieldsMessage("spec.deployment.strategy", "spec.eventDrivenAutoscaling"))
+	}
+	if instance.Spec.VerticalAutoscaling != nil {
+		return createValidationError(conflictingFieldsMessage("spec.deployment.strategy", "spec.verticalAutoscaling"))
+	}
+	return nil
+}
+
+// GetBlueGreenRollbackWindow returns the time the pods of the previous revision are kept after a promotion
+func GetBlueGreenRollbackWindow(blueGreen *appstacksv1.RuntimeComponentBlueGreen) time.Duration {
+	if blueGreen == nil || blueGreen.RollbackWindowSeconds == nil {
+		return defaultBlueGreenRollbackWindow
+	}
+	return time.Duration(*blueGreen.RollbackWindowSeconds) * time.Second
+}
+
+// IsBlueGreenAutoPromoted returns true when the new pods are promoted as soon as they are ready
+func IsBlueGreenAutoPromoted(blueGreen *appstacksv1.RuntimeComponentBlueGreen) bool {
+	return blueGreen == nil || blueGreen.AutoPromote == nil || *blueGreen.AutoPromote
+}
+
+// GetOtherColor returns the color of the Deployment that is not active
+func GetOtherColor(color string) string {
+	if color == Blue {
+		return Green
+	}
+	return Blue
+}
+
+// GetPodTemplateRevision returns a short hash of the pod template, which identifies the pods of a revision
+func GetPodTemplateRevision(template *corev1.PodTemplateSpec) string {
+	data, _ := json.Marshal(template)
+	h := fnv.New32a()
+	h.Write(data)
+	return fmt.Sprintf("%08x", h.Sum32())
+}
+
+// CustomizeBlueGreenDeployment configures the Deployment of a color, which has the pods of the component with the
+// color label. The pods are selected by the Service of the component when the color is active.
+func CustomizeBlueGreenDeployment(deploy *appsv1.Deployment, instance *appstacksv1.RuntimeComponent, color string) {
+	if deploy.Spec.Selector == nil {
+		deploy.Spec.Selector = &metav1.LabelSelector{
+			MatchLabels: map[string]string{
+				"app.kubernetes.io/instance": instance.Name,
+				ColorLabel:                   color,
+			},
+		}
+	}
+	CustomizeDeployment(deploy, instance)
+	CustomizePodSpec(&deploy.Spec.Template, instance)
+	deploy.Labels[ColorLabel] = color
+	deploy.Spec.Template.Labels[ColorLabel] = color
+}
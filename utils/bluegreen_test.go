@@ -0,0 +1,60 @@
+package utils
+
+import (
+	"testing"
+
+	appstacksv1 "github.com/application-stacks/runtime-component-operator/api/v1"
+	appsv1 "k8s.io/api/apps/v1"
+)
+
+func TestValidateBlueGreen(t *testing.T) {
+	blueGreen := func() *appstacksv1.RuntimeComponentDeployment {
+		return &appstacksv1.RuntimeComponentDeployment{Strategy: BlueGreenStrategy}
+	}
+	canaryDeployment := blueGreen()
+	canaryDeployment.Canary = &appstacksv1.RuntimeComponentCanary{Steps: []appstacksv1.RuntimeComponentCanaryStep{{Weight: 20}}}
+
+	validSpec := appstacksv1.RuntimeComponentSpec{Deployment: blueGreen()}
+	statefulSetSpec := appstacksv1.RuntimeComponentSpec{Deployment: blueGreen(), StatefulSet: statefulSet}
+	canarySpec := appstacksv1.RuntimeComponentSpec{Deployment: canaryDeployment}
+	autoscalingSpec := appstacksv1.RuntimeComponentSpec{Deployment: blueGreen(), Autoscaling: autoscaling}
+
+	testVBG := []Test{
+		{"Valid blue/green", nil, ValidateBlueGreen(createRuntimeComponent(name, namespace, validSpec))},
+		{"Blue/green with StatefulSet", true, ValidateBlueGreen(createRuntimeComponent(name, namespace, statefulSetSpec)) != nil},
+		{"Blue/green with canary", true, ValidateBlueGreen(createRuntimeComponent(name, namespace, canarySpec)) != nil},
+		{"Blue/green with autoscaling", true, ValidateBlueGreen(createRuntimeComponent(name, namespace, autoscalingSpec)) != nil},
+	}
+	verifyTests(testVBG, t)
+}
+
+func TestCustomizeBlueGreenDeployment(t *testing.T) {
+	spec := appstacksv1.RuntimeComponentSpec{ApplicationImage: appImage, Service: service, Deployment: &appstacksv1.RuntimeComponentDeployment{Strategy: BlueGreenStrategy}}
+	runtime := createRuntimeComponent(name, namespace, spec)
+
+	blue, green := &appsv1.Deployment{}, &appsv1.Deployment{}
+	CustomizeBlueGreenDeployment(blue, runtime, Blue)
+	CustomizeBlueGreenDeployment(green, runtime, Green)
+
+	// The revision does not depend on the color
+	desired := &appsv1.Deployment{}
+	CustomizeDeployment(desired, runtime)
+	CustomizePodSpec(&desired.Spec.Template, runtime)
+	revision := GetPodTemplateRevision(&desired.Spec.Template)
+
+	runtime.Status.ImageReference = "my-image:2"
+	updated := &appsv1.Deployment{}
+	CustomizeDeployment(updated, runtime)
+	CustomizePodSpec(&updated.Spec.Template, runtime)
+
+	testCBGD := []Test{
+		{"Blue selector", Blue, blue.Spec.Selector.MatchLabels[ColorLabel]},
+		{"Blue pod label", Blue, blue.Spec.Template.Labels[ColorLabel]},
+		{"Green selector", Green, green.Spec.Selector.MatchLabels[ColorLabel]},
+		{"Instance selector", name, green.Spec.Selector.MatchLabels["app.kubernetes.io/instance"]},
+		{"Other color", Blue, GetOtherColor(Green)},
+		{"Stable revision", revision, GetPodTemplateRevision(&desired.Spec.Template)},
+		{"Revision of new pods", true, revision != GetPodTemplateRevision(&updated.Spec.Template)},
+	}
+	verifyTests(testCBGD, t)
+}
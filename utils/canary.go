@@ -0,0 +1,181 @@
+package utils
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	appstacksv1 "github.com/application-stacks/runtime-component-operator/api/v1"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// CanaryLabel is set on the canary Deployment and its pods, which are selected by the canary Deployment only
+	CanaryLabel = "rc.app.stacks/canary"
+
+	defaultCanaryProgressDeadline = 600 * time.Second
+	prometheusQueryTimeout        = 10 * time.Second
+)
+
+// ValidateCanary validates the canary rollout of a RuntimeComponent
+func ValidateCanary(instance *appstacksv1.RuntimeComponent) error {
+	if instance.Spec.Deployment == nil || instance.Spec.Deployment.Canary == nil {
+		return nil
+	}
+	if instance.Spec.StatefulSet != nil {
+		return createValidationError(conflictingFieldsMessage("spec.deployment.canary", "spec.statefulSet"))
+	}
+	if instance.Spec.CreateKnativeService != nil && *instance.Spec.CreateKnativeService {
+		return createValidationError(conflictingFieldsMessage("spec.deployment.canary", "spec.createKnativeService"))
+	}
+	canary := instance.Spec.Deployment.Canary
+	if len(canary.Steps) == 0 {
+		return createValidationError(requiredFieldMessage("spec.deployment.canary.steps"))
+	}
+	if analysis := canary.Analysis; analysis != nil {
+		if u, err := url.Parse(analysis.PrometheusURL); err != nil || u.Host == "" || (u.Scheme != "http" && u.Scheme != "https") {
+			return createValidationError(fmt.Sprintf("spec.deployment.canary.analysis.prometheusURL '%s' must be an http or https URL", analysis.PrometheusURL))
+		}
+		if _, err := strconv.ParseFloat(analysis.MaxValue, 64); err != nil {
+			return createValidationError(fmt.Sprintf("spec.deployment.canary.analysis.maxValue '%s' must be a number", analysis.MaxValue))
+		}
+	}
+	return nil
+}
+
+// GetCanaryProgressDeadline returns the time for the canary pods to become ready at each step
+func GetCanaryProgressDeadline(canary *appstacksv1.RuntimeComponentCanary) time.Duration {
+	if canary.ProgressDeadlineSeconds == nil {
+		return defaultCanaryProgressDeadline
+	}
+	return time.Duration(*canary.ProgressDeadlineSeconds) * time.Second
+}
+
+// GetCanaryReplicas returns the number of canary pods that receive the weight percentage of the traffic
+// shared with the pods of the stable Deployment. There is at least one canary pod.
+func GetCanaryReplicas(stableReplicas int32, weight int32) int32 {
+	if weight >= 100 {
+		weight = 99
+	}
+	// canary / (stable + canary) = weight / 100, rounded up
+	replicas := (stableReplicas*weight + (100 - weight) - 1) / (100 - weight)
+	if replicas < 1 {
+		replicas = 1
+	}
+	return replicas
+}
+
+// IsDeploymentReady returns true when every pod of the latest generation of the Deployment is ready
+func IsDeploymentReady(deploy *appsv1.Deployment) bool {
+	replicas := int32(1)
+	if deploy.Spec.Replicas != nil {
+		replicas = *deploy.Spec.Replicas
+	}
+	s := deploy.Status
+	return s.ObservedGeneration >= deploy.Generation && s.UpdatedReplicas == replicas && s.ReadyReplicas >= replicas && s.Replicas == replicas
+}
+
+// CustomizeCanaryDeployment configures the canary Deployment of the component, which has the pods of the stable
+// Deployment with the canary label. The pods are selected by the Service of the component.
+func CustomizeCanaryDeployment(deploy *appsv1.Deployment, instance *appstacksv1.RuntimeComponent, replicas int32) {
+	if deploy.Spec.Selector == nil {
+		deploy.Spec.Selector = &metav1.LabelSelector{
+			MatchLabels: map[string]string{
+				"app.kubernetes.io/instance": instance.Name,
+				CanaryLabel:                  "true",
+			},
+		}
+	}
+	CustomizeDeployment(deploy, instance)
+	CustomizePodSpec(&deploy.Spec.Template, instance)
+	deploy.Spec.Replicas = &replicas
+	deploy.Labels[CanaryLabel] = "true"
+	deploy.Spec.Template.Labels[CanaryLabel] = "true"
+}
+
+// SetAppContainerImage sets the image of the application container of the pods
+func SetAppContainerImage(pts *corev1.PodTemplateSpec, image string) {
+	if len(pts.Spec.Containers) > 0 {
+		GetAppContainer(pts.Spec.Containers).Image = image
+	}
+}
+
+// GetAppContainerImage returns the image of the application container of the pods
+func GetAppContainerImage(pts *corev1.PodTemplateSpec) string {
+	if len(pts.Spec.Containers) > 0 {
+		return GetAppContainer(pts.Spec.Containers).Image
+	}
+	return ""
+}
+
+// prometheusResponse is the response of the Prometheus query API
+type prometheusResponse struct {
+	Status string `json:"status"`
+	Error  string `json:"error"`
+	Data   struct {
+		ResultType string          `json:"resultType"`
+		Result     json.RawMessage `json:"result"`
+	} `json:"data"`
+}
+
+// QueryPrometheus evaluates a PromQL query that returns a single value, either a scalar or a vector of one sample
+func QueryPrometheus(ctx context.Context, prometheusURL string, query string) (float64, error) {
+	ctx, cancel := context.WithTimeout(ctx, prometheusQueryTimeout)
+	defer cancel()
+
+	endpoint := strings.TrimSuffix(prometheusURL, "/") + "/api/v1/query?" + url.Values{"query": {query}}.Encode()
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, nil)
+	if err != nil {
+		return 0, err
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+
+	result := &prometheusResponse{}
+	if err := json.NewDecoder(resp.Body).Decode(result); err != nil {
+		return 0, fmt.Errorf("unable to decode the response of Prometheus with status %s: %v", resp.Status, err)
+	}
+	if result.Status != "success" {
+		return 0, fmt.Errorf("the Prometheus query failed: %s", result.Error)
+	}
+
+	// A sample is a [timestamp, "value"] pair
+	var sample []interface{}
+	switch result.Data.ResultType {
+	case "scalar":
+		if err := json.Unmarshal(result.Data.Result, &sample); err != nil {
+			return 0, err
+		}
+	case "vector":
+		var vector []struct {
+			Value []interface{} `json:"value"`
+		}
+		if err := json.Unmarshal(result.Data.Result, &vector); err != nil {
+			return 0, err
+		}
+		if len(vector) != 1 {
+			return 0, fmt.Errorf("the Prometheus query returned %d values instead of 1", len(vector))
+		}
+		sample = vector[0].Value
+	default:
+		return 0, fmt.Errorf("the Prometheus query returned a %s instead of a single value", result.Data.ResultType)
+	}
+	if len(sample) != 2 {
+		return 0, fmt.Errorf("the Prometheus query returned an invalid sample")
+	}
+	value, ok := sample[1].(string)
+	if !ok {
+		return 0, fmt.Errorf("the Prometheus query returned an invalid sample")
+	}
+	return strconv.ParseFloat(value, 64)
+}
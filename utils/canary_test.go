@@ -0,0 +1,70 @@
+package utils
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	appstacksv1 "github.com/application-stacks/runtime-component-operator/api/v1"
+)
+
+func TestGetCanaryReplicas(t *testing.T) {
+	testGCR := []Test{
+		{"10% of 9 stable pods", int32(1), GetCanaryReplicas(9, 10)},
+		{"25% of 6 stable pods", int32(2), GetCanaryReplicas(6, 25)},
+		{"50% of 4 stable pods", int32(4), GetCanaryReplicas(4, 50)},
+		{"At least one canary pod", int32(1), GetCanaryReplicas(0, 5)},
+	}
+	verifyTests(testGCR, t)
+}
+
+func TestValidateCanary(t *testing.T) {
+	steps := []appstacksv1.RuntimeComponentCanaryStep{{Weight: 20}}
+	validSpec := appstacksv1.RuntimeComponentSpec{Deployment: &appstacksv1.RuntimeComponentDeployment{Canary: &appstacksv1.RuntimeComponentCanary{Steps: steps,
+		Analysis: &appstacksv1.RuntimeComponentCanaryAnalysis{PrometheusURL: "http://prometheus:9090", Query: "up", MaxValue: "0.5"}}}}
+	statefulSetSpec := appstacksv1.RuntimeComponentSpec{StatefulSet: statefulSet,
+		Deployment: &appstacksv1.RuntimeComponentDeployment{Canary: &appstacksv1.RuntimeComponentCanary{Steps: steps}}}
+	badURLSpec := appstacksv1.RuntimeComponentSpec{Deployment: &appstacksv1.RuntimeComponentDeployment{Canary: &appstacksv1.RuntimeComponentCanary{Steps: steps,
+		Analysis: &appstacksv1.RuntimeComponentCanaryAnalysis{PrometheusURL: "prometheus:9090", Query: "up", MaxValue: "0.5"}}}}
+
+	testVC := []Test{
+		{"Valid canary", nil, ValidateCanary(createRuntimeComponent(name, namespace, validSpec))},
+		{"Canary with StatefulSet", true, ValidateCanary(createRuntimeComponent(name, namespace, statefulSetSpec)) != nil},
+		{"Canary with invalid Prometheus URL", true, ValidateCanary(createRuntimeComponent(name, namespace, badURLSpec)) != nil},
+	}
+	verifyTests(testVC, t)
+}
+
+func TestQueryPrometheus(t *testing.T) {
+	responses := map[string]string{
+		"vector":  `{"status":"success","data":{"resultType":"vector","result":[{"metric":{},"value":[1700000000.1,"0.25"]}]}}`,
+		"scalar":  `{"status":"success","data":{"resultType":"scalar","result":[1700000000.1,"3"]}}`,
+		"empty":   `{"status":"success","data":{"resultType":"vector","result":[]}}`,
+		"invalid": `{"status":"error","errorType":"bad_data","error":"parse error"}`,
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v1/query" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		fmt.Fprint(w, responses[r.URL.Query().Get("query")])
+	}))
+	defer server.Close()
+
+	vector, vectorErr := QueryPrometheus(context.Background(), server.URL, "vector")
+	scalar, scalarErr := QueryPrometheus(context.Background(), server.URL+"/", "scalar")
+	_, emptyErr := QueryPrometheus(context.Background(), server.URL, "empty")
+	_, invalidErr := QueryPrometheus(context.Background(), server.URL, "invalid")
+
+	testQP := []Test{
+		{"Vector value", 0.25, vector},
+		{"Vector error", nil, vectorErr},
+		{"Scalar value", 3.0, scalar},
+		{"Scalar error", nil, scalarErr},
+		{"Empty vector fails", true, emptyErr != nil},
+		{"Failed query fails", true, invalidErr != nil},
+	}
+	verifyTests(testQP, t)
+}
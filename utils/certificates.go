@@ -0,0 +1,48 @@
+package utils
+
+import (
+	"crypto/x509"
+	"fmt"
+	"time"
+
+	"github.com/application-stacks/runtime-component-operator/common"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// GetIngressCertSecretName returns the name of the Secret of the TLS certificate of the Ingress of the component, which
+// is empty when the Ingress does not use TLS
+func GetIngressCertSecretName(ba common.BaseComponent) string {
+	rt := ba.GetRoute()
+	if rt == nil {
+		return ""
+	}
+	if rt.GetCertificateSecretRef() != nil && *rt.GetCertificateSecretRef() != "" {
+		return *rt.GetCertificateSecretRef()
+	}
+	if rt.GetCertificateIssuerName() != "" {
+		return ba.(metav1.Object).GetName() + "-ingress-tls"
+	}
+	return ""
+}
+
+// ParseSecretCertificate returns the leaf certificate in the tls.crt key of a TLS Secret
+func ParseSecretCertificate(secret *corev1.Secret) (*x509.Certificate, error) {
+	certs := ParsePEMCertificates(secret.Data[corev1.TLSCertKey])
+	if len(certs) == 0 {
+		return nil, fmt.Errorf("the %s key of Secret %q does not contain any PEM certificate", corev1.TLSCertKey, secret.Name)
+	}
+	return certs[0], nil
+}
+
+// GetCertExpiryWarningThreshold returns the shortest threshold that is not shorter than the time remaining before the
+// expiry of a certificate, or 0 when the expiry is further away than all the thresholds
+func GetCertExpiryWarningThreshold(remaining time.Duration, thresholds []time.Duration) time.Duration {
+	var crossed time.Duration
+	for _, threshold := range thresholds {
+		if remaining <= threshold && (crossed == 0 || threshold < crossed) {
+			crossed = threshold
+		}
+	}
+	return crossed
+}
@@ -0,0 +1,37 @@
+package utils
+
+import (
+	"testing"
+	"time"
+
+	appstacksv1 "github.com/application-stacks/runtime-component-operator/api/v1"
+	corev1 "k8s.io/api/core/v1"
+)
+
+func TestCertificateExpiry(t *testing.T) {
+	now := time.Now()
+	notAfter := now.Add(48 * time.Hour).Truncate(time.Second)
+	secret := &corev1.Secret{Data: map[string][]byte{corev1.TLSCertKey: createTestCA(t, "leaf", now.Add(-time.Hour), notAfter)}}
+	cert, err := ParseSecretCertificate(secret)
+	_, emptyErr := ParseSecretCertificate(&corev1.Secret{})
+
+	thresholds := []time.Duration{720 * time.Hour, 168 * time.Hour, 24 * time.Hour}
+	issuerRef := &appstacksv1.RuntimeComponentIssuerRef{Name: "letsencrypt"}
+	secretRef := "my-tls"
+
+	testCE := []Test{
+		{"Parse error", nil, err},
+		{"Not after", notAfter.UTC(), cert.NotAfter.UTC()},
+		{"Secret without certificate", true, emptyErr != nil},
+		{"No threshold crossed", time.Duration(0), GetCertExpiryWarningThreshold(1000*time.Hour, thresholds)},
+		{"Longest threshold crossed", 720 * time.Hour, GetCertExpiryWarningThreshold(500*time.Hour, thresholds)},
+		{"Shortest threshold crossed", 24 * time.Hour, GetCertExpiryWarningThreshold(time.Hour, thresholds)},
+		{"Expired certificate", 24 * time.Hour, GetCertExpiryWarningThreshold(-time.Hour, thresholds)},
+		{"Ingress without TLS", "", GetIngressCertSecretName(createRuntimeComponent(name, namespace, appstacksv1.RuntimeComponentSpec{}))},
+		{"Ingress certificate issued by cert-manager", name + "-ingress-tls", GetIngressCertSecretName(createRuntimeComponent(name, namespace,
+			appstacksv1.RuntimeComponentSpec{Route: &appstacksv1.RuntimeComponentRoute{CertificateIssuerRef: issuerRef}}))},
+		{"Ingress certificate secret", secretRef, GetIngressCertSecretName(createRuntimeComponent(name, namespace,
+			appstacksv1.RuntimeComponentSpec{Route: &appstacksv1.RuntimeComponentRoute{CertificateSecretRef: &secretRef}}))},
+	}
+	verifyTests(testCE, t)
+}
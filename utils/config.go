@@ -0,0 +1,265 @@
+package utils
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/application-stacks/runtime-component-operator/common"
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"k8s.io/client-go/rest"
+	toolscache "k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/cache"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+)
+
+// ParseOperatorConfig returns the configuration set in the data of the operator ConfigMap. The values that are
+// missing or invalid are replaced by their default, and the invalid ones are reported in the returned errors.
+func ParseOperatorConfig(data map[string]string) (*common.OperatorConfig, []error) {
+	var errs []error
+	values := common.DefaultOpConfig()
+	for key, value := range data {
+		if value != "" {
+			values[key] = value
+		}
+	}
+
+	defaults := common.DefaultOpConfig()
+	config := &common.OperatorConfig{}
+
+	config.DefaultHostname = values[common.OpConfigDefaultHostname]
+	if config.DefaultHostname != "" {
+		if msgs := validation.IsDNS1123Subdomain(config.DefaultHostname); len(msgs) > 0 {
+			errs = append(errs, fmt.Errorf("invalid value '%s' of %s: %v", config.DefaultHostname, common.OpConfigDefaultHostname, msgs))
+			config.DefaultHostname = defaults[common.OpConfigDefaultHostname]
+		}
+	}
+
+	parseCertDuration := func(key string) time.Duration {
+		// cert-manager does not issue certificates valid for less than an hour
+		duration, err := time.ParseDuration(values[key])
+		if err != nil || duration < time.Hour {
+			errs = append(errs, fmt.Errorf("invalid value '%s' of %s: must be a duration of at least 1h", values[key], key))
+			duration, _ = time.ParseDuration(defaults[key])
+		}
+		return duration
+	}
+	config.CertManagerCACertDuration = parseCertDuration(common.OpConfigCMCADuration)
+	config.CertManagerCertDuration = parseCertDuration(common.OpConfigCMCertDuration)
+
+	config.CertManagerIssuerName = values[common.OpConfigCMIssuerName]
+	config.CertManagerIssuerKind = values[common.OpConfigCMIssuerKind]
+	if config.CertManagerIssuerKind != "Issuer" && config.CertManagerIssuerKind != "ClusterIssuer" {
+		errs = append(errs, fmt.Errorf("invalid value '%s' of %s: must be one of Issuer and ClusterIssuer", config.CertManagerIssuerKind, common.OpConfigCMIssuerKind))
+		config.CertManagerIssuerKind = defaults[common.OpConfigCMIssuerKind]
+	}
+
+	config.MTLSTrustDomain = values[common.OpConfigMTLSTrustDomain]
+	if msgs := validation.IsDNS1123Subdomain(config.MTLSTrustDomain); len(msgs) > 0 {
+		errs = append(errs, fmt.Errorf("invalid value '%s' of %s: %v", config.MTLSTrustDomain, common.OpConfigMTLSTrustDomain, msgs))
+		config.MTLSTrustDomain = defaults[common.OpConfigMTLSTrustDomain]
+	}
+
+	parseThresholds := func(value string) ([]time.Duration, error) {
+		var thresholds []time.Duration
+		for _, item := range strings.Split(value, ",") {
+			threshold, err := time.ParseDuration(strings.TrimSpace(item))
+			if err != nil || threshold <= 0 {
+				return nil, fmt.Errorf("invalid value '%s' of %s: must be a comma-separated list of positive durations", value, common.OpConfigCertExpiryWarningThresholds)
+			}
+			thresholds = append(thresholds, threshold)
+		}
+		sort.Slice(thresholds, func(i, j int) bool { return thresholds[i] > thresholds[j] })
+		return thresholds, nil
+	}
+	thresholds, err := parseThresholds(values[common.OpConfigCertExpiryWarningThresholds])
+	if err != nil {
+		errs = append(errs, err)
+		thresholds, _ = parseThresholds(defaults[common.OpConfigCertExpiryWarningThresholds])
+	}
+	config.CertExpiryWarningThresholds = thresholds
+
+	parseTTL := func(key string) *int32 {
+		if values[key] == "" {
+			return nil
+		}
+		ttl, err := strconv.ParseInt(values[key], 10, 32)
+		if err != nil || ttl < 0 {
+			errs = append(errs, fmt.Errorf("invalid value '%s' of %s: must be a non-negative number of seconds", values[key], key))
+			return nil
+		}
+		result := int32(ttl)
+		return &result
+	}
+	config.OperationTTLSecondsAfterSuccess = parseTTL(common.OpConfigOperationTTLAfterSuccess)
+	config.OperationTTLSecondsAfterFailure = parseTTL(common.OpConfigOperationTTLAfterFailure)
+
+	return config, errs
+}
+
+// OperatorConfigWatcher keeps the configuration of the operator up to date with the operator ConfigMap
+type OperatorConfigWatcher struct {
+	client    client.Client
+	cache     cache.Cache
+	namespace string
+	recorder  record.EventRecorder
+
+	mutex           sync.RWMutex
+	config          *common.OperatorConfig
+	resourceVersion string
+	listeners       []chan event.GenericEvent
+}
+
+var _ manager.LeaderElectionRunnable = &OperatorConfigWatcher{}
+
+// NewOperatorConfigCache returns a cache of the operator ConfigMap in the namespace, which may not be watched by the
+// manager
+func NewOperatorConfigCache(config *rest.Config, scheme *runtime.Scheme, mapper meta.RESTMapper, namespace string) (cache.Cache, error) {
+	return cache.New(config, cache.Options{
+		Scheme:    scheme,
+		Mapper:    mapper,
+		Namespace: namespace,
+		SelectorsByObject: cache.SelectorsByObject{
+			&corev1.ConfigMap{}: {Field: fields.OneTermEqualSelector("metadata.name", common.OpConfigMapName)},
+		},
+	})
+}
+
+// NewOperatorConfigWatcher returns a watcher of the operator ConfigMap in the namespace, which is loaded with the
+// client and watched with the cache. It holds the default configuration until it is loaded.
+func NewOperatorConfigWatcher(c client.Client, configCache cache.Cache, namespace string, recorder record.EventRecorder) *OperatorConfigWatcher {
+	config, _ := ParseOperatorConfig(nil)
+	return &OperatorConfigWatcher{
+		client:    c,
+		cache:     configCache,
+		namespace: namespace,
+		recorder:  recorder,
+		config:    config,
+	}
+}
+
+// Get returns the current configuration, which must not be modified
+func (w *OperatorConfigWatcher) Get() *common.OperatorConfig {
+	w.mutex.RLock()
+	defer w.mutex.RUnlock()
+	return w.config
+}
+
+// Changes returns a channel that receives an event each time the configuration changes. A pending event is not
+// repeated, so the receiver must read the configuration again. It must be called before the watcher starts.
+func (w *OperatorConfigWatcher) Changes() <-chan event.GenericEvent {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+	ch := make(chan event.GenericEvent, 1)
+	w.listeners = append(w.listeners, ch)
+	return ch
+}
+
+// Load reads the operator ConfigMap, and creates it with the default configuration if it does not exist
+func (w *OperatorConfigWatcher) Load(ctx context.Context) error {
+	key := client.ObjectKey{Name: common.OpConfigMapName, Namespace: w.namespace}
+	configMap := &corev1.ConfigMap{}
+	err := w.client.Get(ctx, key, configMap)
+	if kerrors.IsNotFound(err) {
+		configMap = &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Name: common.OpConfigMapName, Namespace: w.namespace},
+			Data:       common.DefaultOpConfig(),
+		}
+		err = w.client.Create(ctx, configMap)
+		if kerrors.IsAlreadyExists(err) {
+			err = w.client.Get(ctx, key, configMap)
+		}
+	}
+	if err != nil {
+		return err
+	}
+	w.update(configMap)
+	return nil
+}
+
+// NeedLeaderElection makes every replica watch the configuration
+func (w *OperatorConfigWatcher) NeedLeaderElection() bool {
+	return false
+}
+
+// Start watches the operator ConfigMap until the context is done
+func (w *OperatorConfigWatcher) Start(ctx context.Context) error {
+	informer, err := w.cache.GetInformer(ctx, &corev1.ConfigMap{})
+	if err != nil {
+		return err
+	}
+	informer.AddEventHandler(toolscache.ResourceEventHandlerFuncs{
+		AddFunc: func(obj interface{}) {
+			if configMap, ok := obj.(*corev1.ConfigMap); ok {
+				w.update(configMap)
+			}
+		},
+		UpdateFunc: func(oldObj, newObj interface{}) {
+			if configMap, ok := newObj.(*corev1.ConfigMap); ok {
+				w.update(configMap)
+			}
+		},
+		DeleteFunc: func(obj interface{}) {
+			log.Info("The " + common.OpConfigMapName + " config map was deleted, using the default configuration")
+			w.update(nil)
+		},
+	})
+	return w.cache.Start(ctx)
+}
+
+// update replaces the configuration with the one of the ConfigMap, or the default configuration if it is nil,
+// and reports the invalid values as warning events on the ConfigMap
+func (w *OperatorConfigWatcher) update(configMap *corev1.ConfigMap) {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+
+	var data map[string]string
+	resourceVersion := ""
+	if configMap != nil {
+		data = configMap.Data
+		resourceVersion = configMap.ResourceVersion
+	}
+	// The same version of the ConfigMap is received once loaded and once watched
+	if resourceVersion != "" && resourceVersion == w.resourceVersion {
+		return
+	}
+	w.resourceVersion = resourceVersion
+
+	config, errs := ParseOperatorConfig(data)
+	for _, err := range errs {
+		log.Info("Invalid operator configuration, using the default value instead", "error", err.Error())
+		if w.recorder != nil && configMap != nil {
+			w.recorder.Event(configMap, "Warning", "InvalidConfiguration", err.Error()+". The default value is used instead.")
+		}
+	}
+
+	if reflect.DeepEqual(config, w.config) {
+		return
+	}
+	w.config = config
+	log.Info("Loaded the operator configuration from the " + common.OpConfigMapName + " config map")
+
+	if configMap == nil {
+		configMap = &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: common.OpConfigMapName, Namespace: w.namespace}}
+	}
+	for _, ch := range w.listeners {
+		select {
+		case ch <- event.GenericEvent{Object: configMap}:
+		default:
+		}
+	}
+}
@@ -0,0 +1,58 @@
+package utils
+
+import (
+	"testing"
+	"time"
+
+	"github.com/application-stacks/runtime-component-operator/common"
+)
+
+func TestParseOperatorConfig(t *testing.T) {
+	defaults, errs := ParseOperatorConfig(nil)
+	ttl := int32(3600)
+	valid, validErrs := ParseOperatorConfig(map[string]string{
+		common.OpConfigDefaultHostname:             "apps.example.com",
+		common.OpConfigCMCertDuration:              "720h",
+		common.OpConfigCMIssuerName:                "cluster-ca",
+		common.OpConfigMTLSTrustDomain:             "prod.example.com",
+		common.OpConfigCertExpiryWarningThresholds: "24h, 336h",
+		common.OpConfigOperationTTLAfterSuccess:    "3600",
+	})
+	invalid, invalidErrs := ParseOperatorConfig(map[string]string{
+		common.OpConfigDefaultHostname:             "Apps_Example",
+		common.OpConfigCMCADuration:                "10m",
+		common.OpConfigCMCertDuration:              "one year",
+		common.OpConfigCMIssuerKind:                "Vault",
+		common.OpConfigMTLSTrustDomain:             "Prod/Example",
+		common.OpConfigCertExpiryWarningThresholds: "30d",
+		common.OpConfigOperationTTLAfterFailure:    "-1",
+	})
+
+	testPOC := []Test{
+		{"Default config errors", 0, len(errs)},
+		{"Default hostname", "", defaults.DefaultHostname},
+		{"Default CA certificate duration", 8766 * time.Hour, defaults.CertManagerCACertDuration},
+		{"Default certificate duration", 2160 * time.Hour, defaults.CertManagerCertDuration},
+		{"Default issuer name", "", defaults.CertManagerIssuerName},
+		{"Default issuer kind", "ClusterIssuer", defaults.CertManagerIssuerKind},
+		{"Default mTLS trust domain", "cluster.local", defaults.MTLSTrustDomain},
+		{"Default certificate expiry warning thresholds", []time.Duration{720 * time.Hour, 168 * time.Hour, 24 * time.Hour}, defaults.CertExpiryWarningThresholds},
+		{"Default operation TTL after success", (*int32)(nil), defaults.OperationTTLSecondsAfterSuccess},
+		{"Valid config errors", 0, len(validErrs)},
+		{"Valid hostname", "apps.example.com", valid.DefaultHostname},
+		{"Valid certificate duration", 720 * time.Hour, valid.CertManagerCertDuration},
+		{"Valid issuer name", "cluster-ca", valid.CertManagerIssuerName},
+		{"Valid mTLS trust domain", "prod.example.com", valid.MTLSTrustDomain},
+		{"Valid certificate expiry warning thresholds", []time.Duration{336 * time.Hour, 24 * time.Hour}, valid.CertExpiryWarningThresholds},
+		{"Valid operation TTL after success", &ttl, valid.OperationTTLSecondsAfterSuccess},
+		{"Invalid config errors", 7, len(invalidErrs)},
+		{"Invalid hostname", "", invalid.DefaultHostname},
+		{"Invalid CA certificate duration", 8766 * time.Hour, invalid.CertManagerCACertDuration},
+		{"Invalid certificate duration", 2160 * time.Hour, invalid.CertManagerCertDuration},
+		{"Invalid issuer kind", "ClusterIssuer", invalid.CertManagerIssuerKind},
+		{"Invalid mTLS trust domain", "cluster.local", invalid.MTLSTrustDomain},
+		{"Invalid certificate expiry warning thresholds", defaults.CertExpiryWarningThresholds, invalid.CertExpiryWarningThresholds},
+		{"Invalid operation TTL after failure", (*int32)(nil), invalid.OperationTTLSecondsAfterFailure},
+	}
+	verifyTests(testPOC, t)
+}
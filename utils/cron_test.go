@@ -0,0 +1,48 @@
+package utils
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseCronSchedule(t *testing.T) {
+	invalid := []string{"", "* * * *", "60 * * * *", "* 24 * * *", "* * 0 * *", "* * * 13 *", "* * * * 8", "5-1 * * * *", "*/0 * * * *", "a * * * *"}
+	for _, expr := range invalid {
+		if _, err := ParseCronSchedule(expr); err == nil {
+			t.Errorf("expected an error when parsing cron expression '%s'", expr)
+		}
+	}
+
+	valid := []string{"* * * * *", "*/15 0-6,22 1 */3 1-5", "0 0 * * 7", "@hourly", " @daily "}
+	for _, expr := range valid {
+		if _, err := ParseCronSchedule(expr); err != nil {
+			t.Errorf("unexpected error when parsing cron expression '%s': %v", expr, err)
+		}
+	}
+}
+
+func TestCronScheduleNext(t *testing.T) {
+	from := time.Date(2022, time.March, 15, 10, 30, 20, 0, time.UTC) // Tuesday
+
+	next := func(expr string) time.Time {
+		s, err := ParseCronSchedule(expr)
+		if err != nil {
+			t.Fatalf("unexpected error when parsing cron expression '%s': %v", expr, err)
+		}
+		return s.Next(from)
+	}
+
+	testCSN := []Test{
+		{"Every minute", time.Date(2022, time.March, 15, 10, 31, 0, 0, time.UTC), next("* * * * *")},
+		{"Every 15 minutes", time.Date(2022, time.March, 15, 10, 45, 0, 0, time.UTC), next("*/15 * * * *")},
+		{"Hourly", time.Date(2022, time.March, 15, 11, 0, 0, 0, time.UTC), next("@hourly")},
+		{"Daily at 2:00", time.Date(2022, time.March, 16, 2, 0, 0, 0, time.UTC), next("0 2 * * *")},
+		{"Sundays as 7", time.Date(2022, time.March, 20, 0, 0, 0, 0, time.UTC), next("0 0 * * 7")},
+		{"Weekdays", time.Date(2022, time.March, 16, 9, 0, 0, 0, time.UTC), next("0 9 * * 1-5")},
+		{"Day of month or day of week", time.Date(2022, time.March, 18, 0, 0, 0, 0, time.UTC), next("0 0 1 * 5")},
+		{"Yearly", time.Date(2023, time.January, 1, 0, 0, 0, 0, time.UTC), next("@yearly")},
+		{"Leap day", time.Date(2024, time.February, 29, 0, 0, 0, 0, time.UTC), next("0 0 29 2 *")},
+		{"Never", time.Time{}, next("0 0 31 2 *")},
+	}
+	verifyTests(testCSN, t)
+}
@@ -0,0 +1,138 @@
+package utils
+
+import (
+	"context"
+	"time"
+
+	appstacksv1 "github.com/application-stacks/runtime-component-operator/api/v1"
+	"github.com/application-stacks/runtime-component-operator/common"
+	certmanagerv1 "github.com/jetstack/cert-manager/pkg/apis/certmanager/v1"
+	"github.com/prometheus/client_golang/prometheus"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+const metricsNamespace = "runtime_component"
+
+// metricsCollectTimeout bounds the time spent listing resources when metrics are scraped
+const metricsCollectTimeout = 10 * time.Second
+
+var (
+	reconcileDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: metricsNamespace,
+		Name:      "reconcile_duration_seconds",
+		Help:      "Duration of the reconciliation of each RuntimeComponent.",
+		Buckets:   []float64{0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10, 30},
+	}, []string{"namespace", "name"})
+
+	componentErrors = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: metricsNamespace,
+		Name:      "errors_total",
+		Help:      "Number of errors reported in the status conditions of RuntimeComponents, by condition type and reason.",
+	}, []string{"condition", "reason"})
+
+	operationResults = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: metricsNamespace,
+		Name:      "operations_total",
+		Help:      "Number of completed RuntimeOperations, by result and reason.",
+	}, []string{"namespace", "result", "reason"})
+)
+
+func init() {
+	metrics.Registry.MustRegister(reconcileDuration, componentErrors, operationResults)
+}
+
+// ObserveReconcileDuration records the duration of the reconciliation of a RuntimeComponent
+func ObserveReconcileDuration(namespace, name string, duration time.Duration) {
+	reconcileDuration.WithLabelValues(namespace, name).Observe(duration.Seconds())
+}
+
+// DeleteComponentMetrics removes the metrics of a RuntimeComponent that was deleted
+func DeleteComponentMetrics(namespace, name string) {
+	reconcileDuration.DeleteLabelValues(namespace, name)
+}
+
+// CountComponentError counts an error reported in a status condition of a RuntimeComponent
+func CountComponentError(conditionType common.StatusConditionType, reason string) {
+	if reason == "" {
+		reason = "Unknown"
+	}
+	componentErrors.WithLabelValues(string(conditionType), reason).Inc()
+}
+
+// CountOperationResult counts a completed RuntimeOperation, which succeeded when status is True
+func CountOperationResult(namespace string, status corev1.ConditionStatus, reason string) {
+	result := "Failed"
+	if status == corev1.ConditionTrue {
+		result = "Succeeded"
+	}
+	operationResults.WithLabelValues(namespace, result, reason).Inc()
+}
+
+// resourceCollector reports metrics about the current state of the resources managed by the operator
+// each time the metrics are scraped
+type resourceCollector struct {
+	reader       client.Reader
+	operatorName string
+	components   *prometheus.Desc
+	certExpiry   *prometheus.Desc
+}
+
+// RegisterResourceMetrics registers the metrics that are computed from the resources read through the reader:
+// the number of RuntimeComponents by Ready status, and the expiry time of the certificates issued by the operator
+func RegisterResourceMetrics(reader client.Reader, operatorName string) error {
+	return metrics.Registry.Register(&resourceCollector{
+		reader:       reader,
+		operatorName: operatorName,
+		components: prometheus.NewDesc(prometheus.BuildFQName(metricsNamespace, "", "components"),
+			"Number of RuntimeComponents, by Ready status.", []string{"namespace", "ready"}, nil),
+		certExpiry: prometheus.NewDesc(prometheus.BuildFQName(metricsNamespace, "", "certificate_expiry_timestamp_seconds"),
+			"Time at which a certificate issued by the operator expires, in seconds since epoch.", []string{"namespace", "certificate"}, nil),
+	})
+}
+
+func (c *resourceCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.components
+	ch <- c.certExpiry
+}
+
+func (c *resourceCollector) Collect(ch chan<- prometheus.Metric) {
+	ctx, cancel := context.WithTimeout(context.Background(), metricsCollectTimeout)
+	defer cancel()
+
+	components := &appstacksv1.RuntimeComponentList{}
+	if err := c.reader.List(ctx, components); err != nil {
+		log.V(1).Info("Unable to list RuntimeComponents for metrics", "error", err.Error())
+	} else {
+		counts := map[string]map[corev1.ConditionStatus]int{}
+		for i := range components.Items {
+			ns := components.Items[i].Namespace
+			if counts[ns] == nil {
+				counts[ns] = map[corev1.ConditionStatus]int{corev1.ConditionTrue: 0, corev1.ConditionFalse: 0, corev1.ConditionUnknown: 0}
+			}
+			ready := corev1.ConditionUnknown
+			if condition := components.Items[i].Status.GetCondition(common.StatusConditionTypeReady); condition != nil && condition.GetStatus() != "" {
+				ready = condition.GetStatus()
+			}
+			counts[ns][ready]++
+		}
+		for ns, byStatus := range counts {
+			for status, count := range byStatus {
+				ch <- prometheus.MustNewConstMetric(c.components, prometheus.GaugeValue, float64(count), ns, string(status))
+			}
+		}
+	}
+
+	// The certificates are only found when cert-manager is installed
+	certs := &certmanagerv1.CertificateList{}
+	if err := c.reader.List(ctx, certs, client.MatchingLabels{"app.kubernetes.io/managed-by": c.operatorName}); err != nil {
+		log.V(1).Info("Unable to list certificates for metrics", "error", err.Error())
+		return
+	}
+	for i := range certs.Items {
+		if notAfter := certs.Items[i].Status.NotAfter; notAfter != nil {
+			ch <- prometheus.MustNewConstMetric(c.certExpiry, prometheus.GaugeValue, float64(notAfter.Unix()), certs.Items[i].Namespace, certs.Items[i].Name)
+		}
+	}
+}
@@ -0,0 +1,190 @@
+package utils
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/application-stacks/runtime-component-operator/common"
+	certmanagerv1 "github.com/jetstack/cert-manager/pkg/apis/certmanager/v1"
+	certmanagermetav1 "github.com/jetstack/cert-manager/pkg/apis/meta/v1"
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// MTLSCAKey is the key of the CA certificates that sign the client certificates of the allowed callers
+	MTLSCAKey = "ca.crt"
+	// MTLSAllowedURISANsKey is the key of the SPIFFE IDs of the allowed callers, one per line
+	MTLSAllowedURISANsKey = "allowed-uri-sans"
+	// MTLSMountPath is the directory of the mutual TLS configuration in the application container
+	MTLSMountPath = "/etc/x509/mtls"
+	// ClientCertMountPath is the directory of the client certificate in the application container
+	ClientCertMountPath = "/etc/x509/client-certs"
+	// ClientCertSecretSuffix is the suffix of the name of the Secret of the client certificate of a component
+	ClientCertSecretSuffix = "-client-tls-cm"
+)
+
+// GetSPIFFEID returns the SPIFFE ID set as the URI SAN of the client certificate of a component
+func GetSPIFFEID(trustDomain string, namespace string, name string) string {
+	return "spiffe://" + trustDomain + "/ns/" + namespace + "/rc/" + name
+}
+
+// GetMTLSCallerNamespace returns the namespace of an allowed caller, which defaults to the namespace of the component
+func GetMTLSCallerNamespace(caller common.BaseComponentMTLSCaller, namespace string) string {
+	if caller.GetNamespace() == "" {
+		return namespace
+	}
+	return caller.GetNamespace()
+}
+
+// CustomizeClientCertificate configures the cert-manager Certificate of the <name>-client-tls-cm secret, which
+// identifies the component with its SPIFFE ID when it calls other components
+func CustomizeClientCertificate(cert *certmanagerv1.Certificate, ba common.BaseComponent, issuerRef certmanagermetav1.ObjectReference, config *common.OperatorConfig) {
+	obj := ba.(metav1.Object)
+	cert.Labels = ba.GetLabels()
+	cert.Spec.CommonName = ""
+	cert.Spec.DNSNames = nil
+	cert.Spec.URIs = []string{GetSPIFFEID(config.MTLSTrustDomain, obj.GetNamespace(), obj.GetName())}
+	cert.Spec.Usages = []certmanagerv1.KeyUsage{certmanagerv1.UsageDigitalSignature, certmanagerv1.UsageKeyEncipherment, certmanagerv1.UsageClientAuth}
+	cert.Spec.IsCA = false
+	cert.Spec.IssuerRef = issuerRef
+	cert.Spec.SecretName = obj.GetName() + ClientCertSecretSuffix
+	cert.Spec.Duration = &metav1.Duration{Duration: config.CertManagerCertDuration}
+}
+
+// CustomizeMTLSConfigMap configures the mutual TLS ConfigMap of a component, which has the CA certificates that sign
+// the client certificates of the allowed callers and their SPIFFE IDs
+func CustomizeMTLSConfigMap(cm *corev1.ConfigMap, ba common.BaseComponent, trustDomain string, cas [][]byte) error {
+	obj := ba.(metav1.Object)
+	bundle := MergeTrustBundle(nil, time.Now(), cas...)
+	if len(bundle) == 0 {
+		return fmt.Errorf("the CA certificates of the allowed callers of %s are not available", obj.GetName())
+	}
+	var sans []string
+	for _, caller := range ba.GetMTLS().GetAllowedCallers() {
+		sans = append(sans, GetSPIFFEID(trustDomain, GetMTLSCallerNamespace(caller, obj.GetNamespace()), caller.GetName()))
+	}
+	sort.Strings(sans)
+	cm.Labels = ba.GetLabels()
+	cm.Annotations = MergeMaps(cm.Annotations, ba.GetAnnotations())
+	cm.Data = map[string]string{
+		MTLSCAKey:             string(bundle),
+		MTLSAllowedURISANsKey: strings.Join(sans, "\n") + "\n",
+	}
+	return nil
+}
+
+// IsMTLSNetworkPolicyAllowingAll returns true when the network policy of a component with allowed callers allows the
+// traffic from all the pods. On Kubernetes, the ingress controller cannot be selected, so the traffic from all the pods
+// is allowed when the component is exposed.
+func IsMTLSNetworkPolicyAllowingAll(isOpenShift bool, ba common.BaseComponent) bool {
+	if ba.GetMTLS() == nil || len(ba.GetMTLS().GetAllowedCallers()) == 0 {
+		return false
+	}
+	return !isOpenShift && ba.GetExpose() != nil && *ba.GetExpose()
+}
+
+// createMTLSNetworkPolicyIngressRule only allows the traffic from the pods of the allowed callers and, when the
+// component is exposed, from the ingress controller. On OpenShift, the traffic from the monitoring stack is allowed too.
+func createMTLSNetworkPolicyIngressRule(isOpenShift bool, isExposed bool, ba common.BaseComponent) networkingv1.NetworkPolicyIngressRule {
+	if IsMTLSNetworkPolicyAllowingAll(isOpenShift, ba) {
+		return createAllowAllNetworkPolicyIngressRule()
+	}
+	rule := networkingv1.NetworkPolicyIngressRule{}
+	if isOpenShift {
+		if isExposed {
+			rule.From = append(rule.From, createOpenShiftRouterNetworkPolicyPeers()...)
+		}
+		rule.From = append(rule.From, createOpenShiftMonitoringNetworkPolicyPeer())
+	}
+	obj := ba.(metav1.Object)
+	for _, caller := range ba.GetMTLS().GetAllowedCallers() {
+		rule.From = append(rule.From, networkingv1.NetworkPolicyPeer{
+			NamespaceSelector: &metav1.LabelSelector{
+				MatchLabels: map[string]string{
+					"kubernetes.io/metadata.name": GetMTLSCallerNamespace(caller, obj.GetNamespace()),
+				},
+			},
+			PodSelector: &metav1.LabelSelector{
+				MatchLabels: map[string]string{
+					common.GetComponentNameLabel(ba): caller.GetName(),
+				},
+			},
+		})
+	}
+	return rule
+}
+
+// customizePodWithMTLS mounts the client certificate and the mutual TLS ConfigMap of the component in the
+// application container. The resource version of the ConfigMap is set in the environment, so that the pods are rolled
+// when the allowed callers change.
+func customizePodWithMTLS(podSpec *corev1.PodSpec, appContainer *corev1.Container, ba common.BaseComponent) {
+	if ba.GetMTLS() == nil {
+		return
+	}
+	obj := ba.(metav1.Object)
+	if secretName := ba.GetStatus().GetReferences()[common.StatusReferenceClientCertSecretName]; secretName != "" {
+		appContainer.Env = append(appContainer.Env, corev1.EnvVar{Name: "CLIENT_TLS_DIR", Value: ClientCertMountPath})
+		podSpec.Volumes = append(podSpec.Volumes, corev1.Volume{
+			Name: "client-certificate",
+			VolumeSource: corev1.VolumeSource{
+				Secret: &corev1.SecretVolumeSource{SecretName: secretName},
+			},
+		})
+		appContainer.VolumeMounts = append(appContainer.VolumeMounts, corev1.VolumeMount{
+			Name:      "client-certificate",
+			MountPath: ClientCertMountPath,
+			ReadOnly:  true,
+		})
+	}
+
+	if len(ba.GetMTLS().GetAllowedCallers()) == 0 {
+		return
+	}
+	appContainer.Env = append(appContainer.Env, corev1.EnvVar{Name: "MTLS_DIR", Value: MTLSMountPath})
+	if rv := ba.GetStatus().GetReferences()[common.StatusReferenceMTLSResourceVersion]; rv != "" {
+		appContainer.Env = append(appContainer.Env, corev1.EnvVar{Name: "MTLS_RESOURCE_VERSION", Value: rv})
+	}
+	podSpec.Volumes = append(podSpec.Volumes, corev1.Volume{
+		Name: "mtls",
+		VolumeSource: corev1.VolumeSource{
+			ConfigMap: &corev1.ConfigMapVolumeSource{
+				LocalObjectReference: corev1.LocalObjectReference{Name: obj.GetName() + "-mtls"},
+			},
+		},
+	})
+	appContainer.VolumeMounts = append(appContainer.VolumeMounts, corev1.VolumeMount{
+		Name:      "mtls",
+		MountPath: MTLSMountPath,
+		ReadOnly:  true,
+	})
+}
+
+// validateMTLS checks that the client certificate of the component can be issued by the operator, and that the
+// client certificates of its callers are in namespaces watched by the operator, where it is allowed to read them
+func validateMTLS(ba common.BaseComponent) error {
+	if ba.GetMTLS() == nil {
+		return nil
+	}
+	if ba.GetCreateKnativeService() != nil && *ba.GetCreateKnativeService() {
+		return createValidationError(conflictingFieldsMessage("spec.createKnativeService", "spec.mtls"))
+	}
+	if ba.GetManageTLS() != nil && !*ba.GetManageTLS() {
+		return createValidationError("spec.mtls requires spec.manageTLS to be enabled")
+	}
+	obj := ba.(metav1.Object)
+	watchNamespaces, err := GetWatchNamespaces()
+	for _, caller := range ba.GetMTLS().GetAllowedCallers() {
+		if caller.GetName() == "" {
+			return createValidationError(requiredFieldMessage("spec.mtls.allowedCallers.name"))
+		}
+		callerNamespace := GetMTLSCallerNamespace(caller, obj.GetNamespace())
+		if err == nil && callerNamespace != obj.GetNamespace() && !IsClusterWide(watchNamespaces) && !ContainsString(watchNamespaces, callerNamespace) {
+			return createValidationError(fmt.Sprintf("the namespace '%s' of the allowed caller '%s' in spec.mtls.allowedCallers is not watched by the operator", callerNamespace, caller.GetName()))
+		}
+	}
+	return nil
+}
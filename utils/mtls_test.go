@@ -0,0 +1,81 @@
+package utils
+
+import (
+	"testing"
+	"time"
+
+	appstacksv1 "github.com/application-stacks/runtime-component-operator/api/v1"
+	"github.com/application-stacks/runtime-component-operator/common"
+	certmanagerv1 "github.com/jetstack/cert-manager/pkg/apis/certmanager/v1"
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+)
+
+func TestCustomizeMTLS(t *testing.T) {
+	now := time.Now()
+	config, _ := ParseOperatorConfig(map[string]string{common.OpConfigMTLSTrustDomain: "example.com"})
+	mtls := &appstacksv1.RuntimeComponentMTLS{AllowedCallers: []appstacksv1.RuntimeComponentMTLSCaller{
+		{Name: "frontend"},
+		{Name: "batch", Namespace: "jobs"},
+	}}
+	runtime := createRuntimeComponent(name, namespace, appstacksv1.RuntimeComponentSpec{Service: service, MTLS: mtls})
+	runtime.Status.SetReference(common.StatusReferenceClientCertSecretName, name+ClientCertSecretSuffix)
+	runtime.Status.SetReference(common.StatusReferenceMTLSResourceVersion, "7")
+
+	issuerRef, _ := GetSvcCertIssuerRef(runtime, "rco", config)
+	cert := &certmanagerv1.Certificate{}
+	CustomizeClientCertificate(cert, runtime, issuerRef, config)
+
+	cm := &corev1.ConfigMap{}
+	err := CustomizeMTLSConfigMap(cm, runtime, config.MTLSTrustDomain, [][]byte{createTestCA(t, "ca", now.Add(-time.Hour), now.Add(time.Hour))})
+	emptyErr := CustomizeMTLSConfigMap(&corev1.ConfigMap{}, runtime, config.MTLSTrustDomain, nil)
+
+	np := &networkingv1.NetworkPolicy{}
+	CustomizeNetworkPolicy(np, false, runtime)
+	peers := np.Spec.Ingress[0].From
+	exposed := true
+	exposedRuntime := createRuntimeComponent(name, namespace, appstacksv1.RuntimeComponentSpec{Service: service, MTLS: mtls, Expose: &exposed})
+	openShiftNP, kubernetesNP := &networkingv1.NetworkPolicy{}, &networkingv1.NetworkPolicy{}
+	CustomizeNetworkPolicy(openShiftNP, true, exposedRuntime)
+	CustomizeNetworkPolicy(kubernetesNP, false, exposedRuntime)
+
+	pts := &corev1.PodTemplateSpec{}
+	CustomizePodSpec(pts, runtime)
+	env := map[string]string{}
+	for _, e := range GetAppContainer(pts.Spec.Containers).Env {
+		env[e.Name] = e.Value
+	}
+
+	knativeRuntime := createRuntimeComponent(name, namespace, appstacksv1.RuntimeComponentSpec{Service: service, MTLS: mtls, CreateKnativeService: &createKNS})
+	_, knativeErr := Validate(knativeRuntime)
+	t.Setenv("WATCH_NAMESPACE", namespace)
+	_, unwatchedErr := Validate(runtime)
+	t.Setenv("WATCH_NAMESPACE", namespace+",jobs")
+	_, watchedErr := Validate(runtime)
+
+	testCM := []Test{
+		{"Client certificate URI SAN", []string{"spiffe://example.com/ns/" + namespace + "/rc/" + name}, cert.Spec.URIs},
+		{"Client certificate usages", []certmanagerv1.KeyUsage{certmanagerv1.UsageDigitalSignature, certmanagerv1.UsageKeyEncipherment, certmanagerv1.UsageClientAuth}, cert.Spec.Usages},
+		{"Client certificate secret", name + "-client-tls-cm", cert.Spec.SecretName},
+		{"ConfigMap error", nil, err},
+		{"ConfigMap without CA certificates", true, emptyErr != nil},
+		{"Allowed URI SANs", "spiffe://example.com/ns/jobs/rc/batch\nspiffe://example.com/ns/" + namespace + "/rc/frontend\n", cm.Data[MTLSAllowedURISANsKey]},
+		{"CA certificates", 1, len(ParsePEMCertificates([]byte(cm.Data[MTLSCAKey])))},
+		{"Only the callers are allowed", 2, len(peers)},
+		{"Callers, the OpenShift router and monitoring are allowed", 5, len(openShiftNP.Spec.Ingress[0].From)},
+		{"OpenShift monitoring is allowed", createOpenShiftMonitoringNetworkPolicyPeer(), openShiftNP.Spec.Ingress[0].From[2]},
+		{"All the traffic is allowed when exposed on Kubernetes", createAllowAllNetworkPolicyIngressRule().From, kubernetesNP.Spec.Ingress[0].From},
+		{"Allowing all the traffic when exposed on Kubernetes", true, IsMTLSNetworkPolicyAllowingAll(false, exposedRuntime)},
+		{"Allowing all the traffic when exposed on OpenShift", false, IsMTLSNetworkPolicyAllowingAll(true, exposedRuntime)},
+		{"Caller namespace", "jobs", peers[len(peers)-1].NamespaceSelector.MatchLabels["kubernetes.io/metadata.name"]},
+		{"Caller pods", "batch", peers[len(peers)-1].PodSelector.MatchLabels[common.GetComponentNameLabel(runtime)]},
+		{"Default caller namespace", namespace, peers[len(peers)-2].NamespaceSelector.MatchLabels["kubernetes.io/metadata.name"]},
+		{"Client certificate directory", ClientCertMountPath, env["CLIENT_TLS_DIR"]},
+		{"mTLS directory", MTLSMountPath, env["MTLS_DIR"]},
+		{"mTLS resource version", "7", env["MTLS_RESOURCE_VERSION"]},
+		{"mTLS with a Knative service", true, knativeErr != nil},
+		{"Caller in a namespace that is not watched", true, unwatchedErr != nil},
+		{"Caller in a watched namespace", nil, watchedErr},
+	}
+	verifyTests(testCM, t)
+}
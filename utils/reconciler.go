@@ -11,9 +11,12 @@ import (
 	certmanagerv1 "github.com/jetstack/cert-manager/pkg/apis/certmanager/v1"
 	certmanagermetav1 "github.com/jetstack/cert-manager/pkg/apis/meta/v1"
 	routev1 "github.com/openshift/api/route/v1"
+	autoscalingv1 "k8s.io/api/autoscaling/v1"
+	autoscalingv2beta2 "k8s.io/api/autoscaling/v2beta2"
 	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/types"
@@ -187,6 +190,7 @@ func (r *ReconcilerBase) ManageError(issue error, conditionType common.StatusCon
 	newCondition.SetMessage(issue.Error())
 	newCondition.SetStatus(corev1.ConditionFalse)
 	s.SetCondition(newCondition)
+	CountComponentError(conditionType, newCondition.GetReason())
 
 	//Check Application status (reconciliation & resource status & endpoint status)
 	r.CheckApplicationStatus(ba)
@@ -279,6 +283,58 @@ func (r *ReconcilerBase) IsGroupVersionSupported(groupVersion string, kind strin
 	return false, nil
 }
 
+// CreateOrUpdateHPA creates or updates the HorizontalPodAutoscaler of the component with the most recent autoscaling
+// API served by the cluster. autoscaling/v1 is only used when neither autoscaling/v2 nor autoscaling/v2beta2 is served,
+// in which case the metrics other than the CPU utilization and the scaling behavior are ignored.
+func (r *ReconcilerBase) CreateOrUpdateHPA(ba common.BaseComponent, meta metav1.ObjectMeta, owner metav1.Object) error {
+	if ok, err := r.IsGroupVersionSupported("autoscaling/v2", "HorizontalPodAutoscaler"); err != nil {
+		return err
+	} else if ok {
+		// The autoscaling/v2 spec is the same as autoscaling/v2beta2, which has Go types
+		hpa := &unstructured.Unstructured{}
+		hpa.SetGroupVersionKind(schema.GroupVersionKind{Group: "autoscaling", Version: "v2", Kind: "HorizontalPodAutoscaler"})
+		hpa.SetName(meta.Name)
+		hpa.SetNamespace(meta.Namespace)
+		return r.CreateOrUpdate(hpa, owner, func() error {
+			typed := &autoscalingv2beta2.HorizontalPodAutoscaler{}
+			if err := runtime.DefaultUnstructuredConverter.FromUnstructured(hpa.Object, typed); err != nil {
+				return err
+			}
+			CustomizeHPAV2(typed, ba)
+			content, err := runtime.DefaultUnstructuredConverter.ToUnstructured(typed)
+			if err != nil {
+				return err
+			}
+			hpa.Object = content
+			hpa.SetAPIVersion("autoscaling/v2")
+			hpa.SetKind("HorizontalPodAutoscaler")
+			return nil
+		})
+	}
+
+	if ok, err := r.IsGroupVersionSupported(autoscalingv2beta2.SchemeGroupVersion.String(), "HorizontalPodAutoscaler"); err != nil {
+		return err
+	} else if ok {
+		hpa := &autoscalingv2beta2.HorizontalPodAutoscaler{ObjectMeta: meta}
+		return r.CreateOrUpdate(hpa, owner, func() error {
+			CustomizeHPAV2(hpa, ba)
+			return nil
+		})
+	}
+
+	autoscaling := ba.GetAutoscaling()
+	if len(autoscaling.GetMetrics()) > 0 || autoscaling.GetBehavior() != nil {
+		obj := ba.(client.Object)
+		r.GetRecorder().Event(obj, "Warning", "AutoscalingV2Unavailable",
+			"The autoscaling/v2 API is not available on the cluster. The metrics and behavior of the autoscaling spec are ignored.")
+	}
+	hpa := &autoscalingv1.HorizontalPodAutoscaler{ObjectMeta: meta}
+	return r.CreateOrUpdate(hpa, owner, func() error {
+		CustomizeHPA(hpa, ba)
+		return nil
+	})
+}
+
 // UpdateStatus updates the fields corresponding to the status subresource for the object
 func (r *ReconcilerBase) UpdateStatus(obj client.Object) error {
 	return r.GetClient().Status().Update(context.Background(), obj)
@@ -341,7 +397,7 @@ func (r *ReconcilerBase) GetRouteTLSValues(ba common.BaseComponent) (key string,
 	return key, cert, ca, destCa, nil
 }
 
-func (r *ReconcilerBase) GenerateSvcCertSecret(ba common.BaseComponent, prefix string, CACommonName string, operatorName string) (bool, error) {
+func (r *ReconcilerBase) GenerateSvcCertSecret(ba common.BaseComponent, prefix string, CACommonName string, operatorName string, config *common.OperatorConfig) (bool, error) {
 
 	delete(ba.GetStatus().GetReferences(), common.StatusReferenceCertSecretName)
 	cleanup := func() {
@@ -383,58 +439,58 @@ func (r *ReconcilerBase) GenerateSvcCertSecret(ba common.BaseComponent, prefix s
 	} else if ok {
 		bao := ba.(metav1.Object)
 
-		issuer := &certmanagerv1.Issuer{ObjectMeta: metav1.ObjectMeta{
-			Name:      prefix + "-self-signed",
-			Namespace: bao.GetNamespace(),
-		}}
-		err = r.CreateOrUpdate(issuer, nil, func() error {
-			issuer.Spec.SelfSigned = &certmanagerv1.SelfSignedIssuer{}
-			issuer.Labels = MergeMaps(issuer.Labels, map[string]string{"app.kubernetes.io/managed-by": operatorName})
-			return nil
-		})
-		if err != nil {
-			return true, err
-		}
-		caCert := &certmanagerv1.Certificate{ObjectMeta: metav1.ObjectMeta{
-			Name:      prefix + "-ca-cert",
-			Namespace: bao.GetNamespace(),
-		}}
-		err = r.CreateOrUpdate(caCert, nil, func() error {
-			caCert.Labels = MergeMaps(caCert.Labels, map[string]string{"app.kubernetes.io/managed-by": operatorName})
-			caCert.Spec.CommonName = CACommonName
-			caCert.Spec.IsCA = true
-			caCert.Spec.SecretName = prefix + "-ca-tls"
-			caCert.Spec.IssuerRef = certmanagermetav1.ObjectReference{
-				Name: prefix + "-self-signed",
+		// The operator creates a self-signed CA in the namespace when no issuer is selected
+		issuerRef, selfSigned := GetSvcCertIssuerRef(ba, prefix, config)
+		if selfSigned {
+			issuer := &certmanagerv1.Issuer{ObjectMeta: metav1.ObjectMeta{
+				Name:      prefix + "-self-signed",
+				Namespace: bao.GetNamespace(),
+			}}
+			err = r.CreateOrUpdate(issuer, nil, func() error {
+				issuer.Spec.SelfSigned = &certmanagerv1.SelfSignedIssuer{}
+				issuer.Labels = MergeMaps(issuer.Labels, map[string]string{"app.kubernetes.io/managed-by": operatorName})
+				return nil
+			})
+			if err != nil {
+				return true, err
 			}
+			caCert := &certmanagerv1.Certificate{ObjectMeta: metav1.ObjectMeta{
+				Name:      prefix + "-ca-cert",
+				Namespace: bao.GetNamespace(),
+			}}
+			err = r.CreateOrUpdate(caCert, nil, func() error {
+				caCert.Labels = MergeMaps(caCert.Labels, map[string]string{"app.kubernetes.io/managed-by": operatorName})
+				caCert.Spec.CommonName = CACommonName
+				caCert.Spec.IsCA = true
+				caCert.Spec.SecretName = prefix + "-ca-tls"
+				caCert.Spec.IssuerRef = certmanagermetav1.ObjectReference{
+					Name: prefix + "-self-signed",
+				}
 
-			duration, err := time.ParseDuration(common.Config[common.OpConfigCMCADuration])
+				caCert.Spec.Duration = &metav1.Duration{Duration: config.CertManagerCACertDuration}
+				return nil
+			})
 			if err != nil {
-				return err
+				return true, err
+			}
+			issuer = &certmanagerv1.Issuer{ObjectMeta: metav1.ObjectMeta{
+				Name:      prefix + "-ca-issuer",
+				Namespace: bao.GetNamespace(),
+			}}
+			err = r.CreateOrUpdate(issuer, nil, func() error {
+				issuer.Labels = MergeMaps(issuer.Labels, map[string]string{"app.kubernetes.io/managed-by": operatorName})
+				issuer.Spec.CA = &certmanagerv1.CAIssuer{}
+				issuer.Spec.CA.SecretName = prefix + "-ca-tls"
+				return nil
+			})
+			if err != nil {
+				return true, err
 			}
-			caCert.Spec.Duration = &metav1.Duration{Duration: duration}
-			return nil
-		})
-		if err != nil {
-			return true, err
-		}
-		issuer = &certmanagerv1.Issuer{ObjectMeta: metav1.ObjectMeta{
-			Name:      prefix + "-ca-issuer",
-			Namespace: bao.GetNamespace(),
-		}}
-		err = r.CreateOrUpdate(issuer, nil, func() error {
-			issuer.Labels = MergeMaps(issuer.Labels, map[string]string{"app.kubernetes.io/managed-by": operatorName})
-			issuer.Spec.CA = &certmanagerv1.CAIssuer{}
-			issuer.Spec.CA.SecretName = prefix + "-ca-tls"
-			return nil
-		})
-		if err != nil {
-			return true, err
-		}
 
-		for i := range issuer.Status.Conditions {
-			if issuer.Status.Conditions[i].Type == certmanagerv1.IssuerConditionReady && issuer.Status.Conditions[i].Status == certmanagermetav1.ConditionFalse {
-				return true, errors.New("Certificate is not ready")
+			for i := range issuer.Status.Conditions {
+				if issuer.Status.Conditions[i].Type == certmanagerv1.IssuerConditionReady && issuer.Status.Conditions[i].Status == certmanagermetav1.ConditionFalse {
+					return true, errors.New("Certificate is not ready")
+				}
 			}
 		}
 
@@ -446,23 +502,7 @@ func (r *ReconcilerBase) GenerateSvcCertSecret(ba common.BaseComponent, prefix s
 		}}
 
 		err = r.CreateOrUpdate(svcCert, bao, func() error {
-			svcCert.Labels = ba.GetLabels()
-
-			svcCert.Spec.CommonName = bao.GetName() + "." + bao.GetNamespace() + ".svc"
-			svcCert.Spec.DNSNames = make([]string, 2)
-			svcCert.Spec.DNSNames[0] = bao.GetName() + "." + bao.GetNamespace() + ".svc"
-			svcCert.Spec.DNSNames[1] = bao.GetName() + "." + bao.GetNamespace() + ".svc.cluster.local"
-			svcCert.Spec.IsCA = false
-			svcCert.Spec.IssuerRef = certmanagermetav1.ObjectReference{
-				Name: prefix + "-ca-issuer",
-			}
-			svcCert.Spec.SecretName = svcCertSecretName
-			duration, err := time.ParseDuration(common.Config[common.OpConfigCMCertDuration])
-			if err != nil {
-				return err
-			}
-			svcCert.Spec.Duration = &metav1.Duration{Duration: duration}
-
+			CustomizeSvcCertificate(svcCert, ba, issuerRef, config)
 			return nil
 		})
 		if err != nil {
@@ -478,6 +518,9 @@ func (r *ReconcilerBase) GenerateSvcCertSecret(ba common.BaseComponent, prefix s
 func (r *ReconcilerBase) GetIngressInfo(ba common.BaseComponent) (host string, path string, protocol string) {
 	mObj := ba.(metav1.Object)
 	protocol = "http"
+	if ba.GetGateway() != nil {
+		return r.getHTTPRouteInfo(ba)
+	}
 	if ok, err := r.IsGroupVersionSupported(routev1.SchemeGroupVersion.String(), "Route"); err != nil {
 		r.ManageError(err, common.StatusConditionTypeReconciled, ba)
 	} else if ok {
@@ -509,3 +552,48 @@ func (r *ReconcilerBase) GetIngressInfo(ba common.BaseComponent) (host string, p
 	}
 	return host, path, protocol
 }
+
+// getHTTPRouteInfo returns the host, path and protocol of the Gateway API HTTPRoute of the component
+func (r *ReconcilerBase) getHTTPRouteInfo(ba common.BaseComponent) (host string, path string, protocol string) {
+	mObj := ba.(metav1.Object)
+	protocol = "http"
+	route := &unstructured.Unstructured{}
+	route.SetGroupVersionKind(HTTPRouteGVK)
+	if err := r.GetClient().Get(context.Background(), types.NamespacedName{Name: mObj.GetName(), Namespace: mObj.GetNamespace()}, route); err != nil {
+		return host, path, protocol
+	}
+	if hostnames, _, _ := unstructured.NestedStringSlice(route.Object, "spec", "hostnames"); len(hostnames) > 0 {
+		host = hostnames[0]
+	}
+	if rules, _, _ := unstructured.NestedSlice(route.Object, "spec", "rules"); len(rules) > 0 {
+		if rule, ok := rules[0].(map[string]interface{}); ok {
+			if matches, _, _ := unstructured.NestedSlice(rule, "matches"); len(matches) > 0 {
+				if match, ok := matches[0].(map[string]interface{}); ok {
+					path, _, _ = unstructured.NestedString(match, "path", "value")
+				}
+			}
+		}
+	}
+
+	for _, p := range ba.GetGateway().GetParentRefs() {
+		gateway := &unstructured.Unstructured{}
+		gateway.SetGroupVersionKind(GatewayGVK)
+		namespace := p.GetNamespace()
+		if namespace == "" {
+			namespace = mObj.GetNamespace()
+		}
+		// The Gateway is usually in another namespace, which may not be watched
+		if err := r.GetAPIReader().Get(context.Background(), types.NamespacedName{Name: p.GetName(), Namespace: namespace}, gateway); err != nil {
+			log.Error(err, "Failed to read the Gateway of the HTTPRoute", "Gateway", p.GetName(), "Namespace", namespace, "HTTPRoute", mObj.GetName())
+			continue
+		}
+		hostname, tls := GetGatewayListenerInfo(gateway, p.GetSectionName())
+		if host == "" {
+			host = hostname
+		}
+		if tls {
+			protocol = "https"
+		}
+	}
+	return host, path, protocol
+}
@@ -0,0 +1,68 @@
+package utils
+
+import (
+	"time"
+
+	appstacksv1 "github.com/application-stacks/runtime-component-operator/api/v1"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+)
+
+const defaultAutoRollbackProgressDeadline = 600 * time.Second
+
+// ValidateAutoRollback validates the automatic rollback of a RuntimeComponent
+func ValidateAutoRollback(instance *appstacksv1.RuntimeComponent) error {
+	if instance.Spec.AutoRollback == nil {
+		return nil
+	}
+	if instance.Spec.CreateKnativeService != nil && *instance.Spec.CreateKnativeService {
+		return createValidationError(conflictingFieldsMessage("spec.autoRollback", "spec.createKnativeService"))
+	}
+	// Canary and blue/green rollouts keep the pods of the current image until the new image is ready
+	if instance.Spec.Deployment != nil && instance.Spec.Deployment.Canary != nil {
+		return createValidationError(conflictingFieldsMessage("spec.autoRollback", "spec.deployment.canary"))
+	}
+	if IsBlueGreenEnabled(instance) {
+		return createValidationError(conflictingFieldsMessage("spec.autoRollback", "spec.deployment.strategy"))
+	}
+	return nil
+}
+
+// GetAutoRollbackProgressDeadline returns the time for the pods of a new image to become ready
+func GetAutoRollbackProgressDeadline(autoRollback *appstacksv1.RuntimeComponentAutoRollback) time.Duration {
+	if autoRollback == nil || autoRollback.ProgressDeadlineSeconds == nil {
+		return defaultAutoRollbackProgressDeadline
+	}
+	return time.Duration(*autoRollback.ProgressDeadlineSeconds) * time.Second
+}
+
+// IsStatefulSetReady returns true when every pod of the latest revision of the StatefulSet is ready
+func IsStatefulSetReady(statefulSet *appsv1.StatefulSet) bool {
+	replicas := int32(1)
+	if statefulSet.Spec.Replicas != nil {
+		replicas = *statefulSet.Spec.Replicas
+	}
+	s := statefulSet.Status
+	return s.ObservedGeneration >= statefulSet.Generation && s.UpdatedReplicas == replicas && s.ReadyReplicas >= replicas &&
+		s.Replicas == replicas && s.CurrentRevision == s.UpdateRevision
+}
+
+// GetAppContainerRestarts returns the number of restarts of the application containers of the pods that run the given image
+func GetAppContainerRestarts(pods []corev1.Pod, image string) int32 {
+	var restarts int32
+	for _, pod := range pods {
+		if len(pod.Spec.Containers) == 0 {
+			continue
+		}
+		appContainer := GetAppContainer(pod.Spec.Containers)
+		if appContainer.Image != image {
+			continue
+		}
+		for _, cs := range pod.Status.ContainerStatuses {
+			if cs.Name == appContainer.Name {
+				restarts += cs.RestartCount
+			}
+		}
+	}
+	return restarts
+}
@@ -0,0 +1,60 @@
+package utils
+
+import (
+	"testing"
+	"time"
+
+	appstacksv1 "github.com/application-stacks/runtime-component-operator/api/v1"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+)
+
+func TestValidateAutoRollback(t *testing.T) {
+	deadline := int32(120)
+	autoRollback := &appstacksv1.RuntimeComponentAutoRollback{ProgressDeadlineSeconds: &deadline}
+	validSpec := appstacksv1.RuntimeComponentSpec{AutoRollback: autoRollback, StatefulSet: statefulSet}
+	knativeSpec := appstacksv1.RuntimeComponentSpec{AutoRollback: autoRollback, CreateKnativeService: &createKNS}
+	blueGreenSpec := appstacksv1.RuntimeComponentSpec{AutoRollback: autoRollback, Deployment: &appstacksv1.RuntimeComponentDeployment{Strategy: BlueGreenStrategy}}
+
+	testVAR := []Test{
+		{"Valid auto rollback", nil, ValidateAutoRollback(createRuntimeComponent(name, namespace, validSpec))},
+		{"Auto rollback with Knative", true, ValidateAutoRollback(createRuntimeComponent(name, namespace, knativeSpec)) != nil},
+		{"Auto rollback with blue/green", true, ValidateAutoRollback(createRuntimeComponent(name, namespace, blueGreenSpec)) != nil},
+		{"Progress deadline", 120 * time.Second, GetAutoRollbackProgressDeadline(autoRollback)},
+		{"Default progress deadline", 600 * time.Second, GetAutoRollbackProgressDeadline(&appstacksv1.RuntimeComponentAutoRollback{})},
+	}
+	verifyTests(testVAR, t)
+}
+
+func TestIsStatefulSetReady(t *testing.T) {
+	replicas := int32(2)
+	ready := &appsv1.StatefulSet{Spec: appsv1.StatefulSetSpec{Replicas: &replicas},
+		Status: appsv1.StatefulSetStatus{Replicas: 2, ReadyReplicas: 2, UpdatedReplicas: 2, CurrentRevision: "a", UpdateRevision: "a"}}
+	updating := ready.DeepCopy()
+	updating.Status.UpdateRevision = "b"
+
+	testISR := []Test{
+		{"Ready StatefulSet", true, IsStatefulSetReady(ready)},
+		{"StatefulSet rolling out", false, IsStatefulSetReady(updating)},
+	}
+	verifyTests(testISR, t)
+}
+
+func TestGetAppContainerRestarts(t *testing.T) {
+	pod := func(image string, restarts int32) corev1.Pod {
+		return corev1.Pod{
+			Spec: corev1.PodSpec{Containers: []corev1.Container{{Name: "sidecar", Image: "sidecar"}, {Name: "app", Image: image}}},
+			Status: corev1.PodStatus{ContainerStatuses: []corev1.ContainerStatus{
+				{Name: "sidecar", RestartCount: 7},
+				{Name: "app", RestartCount: restarts},
+			}},
+		}
+	}
+	pods := []corev1.Pod{pod("my-image:1", 4), pod("my-image:2", 2), pod("my-image:2", 1)}
+
+	testGACR := []Test{
+		{"Restarts of the new image", int32(3), GetAppContainerRestarts(pods, "my-image:2")},
+		{"Restarts of the old image", int32(4), GetAppContainerRestarts(pods, "my-image:1")},
+	}
+	verifyTests(testGACR, t)
+}
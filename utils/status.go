@@ -96,6 +96,9 @@ func (r *ReconcilerBase) setCondition(ba common.BaseComponent, oldCondition comm
 func (r *ReconcilerBase) areReplicasReady(ba common.BaseComponent, c common.StatusCondition) common.StatusCondition {
 	obj := ba.(client.Object)
 	namespacedName := types.NamespacedName{Name: obj.GetName(), Namespace: obj.GetNamespace()}
+	if name := ba.GetStatus().GetReferences()[common.StatusReferenceDeploymentName]; name != "" && ba.GetStatefulSet() == nil {
+		namespacedName.Name = name
+	}
 
 	resourceType, msg, reason := "", "", ""
 	var replicas, readyReplicas, updatedReplicas, readyUpdatedReplicas int32
@@ -105,7 +108,7 @@ func (r *ReconcilerBase) areReplicasReady(ba common.BaseComponent, c common.Stat
 	autoScale := ba.GetAutoscaling()
 
 	// If both are not specified, expected replica is set to 1
-	if expectedReplicas == nil && autoScale == nil {
+	if expectedReplicas == nil && autoScale == nil && ba.GetEventDrivenAutoscaling() == nil {
 		expectedReplicas = &minReplicas
 	}
 
@@ -145,6 +148,27 @@ func (r *ReconcilerBase) areReplicasReady(ba common.BaseComponent, c common.Stat
 	msg = resourceType + " replicas ready: " + strconv.Itoa(int(readyUpdatedReplicas))
 	reason = "MinimumReplicasUnavailable"
 
+	// Check event-driven autoscaling parameters, which allow scaling to zero
+	if eda := ba.GetEventDrivenAutoscaling(); eda != nil {
+		var edaMinReplicas int32
+		if eda.GetMinReplicas() != nil {
+			edaMinReplicas = *eda.GetMinReplicas()
+		}
+		if readyUpdatedReplicas < edaMinReplicas {
+			msg = msg + " < minReplicas: " + strconv.Itoa(int(edaMinReplicas))
+			return c.SetConditionFields(msg, reason, corev1.ConditionFalse)
+		} else if eda.GetMaxReplicas() != nil && replicas > *eda.GetMaxReplicas() {
+			msg = "Replica set is progressing"
+			reason = "ReplicaSetUpdating"
+			return c.SetConditionFields(msg, reason, corev1.ConditionFalse)
+		}
+		if replicas == 0 {
+			msg = resourceType + " is scaled to zero"
+		}
+		reason = "MinimumReplicasAvailable"
+		return c.SetConditionFields(msg, reason, corev1.ConditionTrue)
+	}
+
 	// Check autoscaling parameters
 	if autoScale != nil {
 		autoMinReplicas := autoScale.GetMinReplicas()
@@ -0,0 +1,133 @@
+package utils
+
+import (
+	"bytes"
+	"crypto/sha1"
+	"crypto/x509"
+	"encoding/binary"
+	"encoding/pem"
+	"fmt"
+	"time"
+
+	"github.com/application-stacks/runtime-component-operator/common"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// TrustBundleKey is the key of the PEM certificates in the trust bundle ConfigMaps published by the operator
+	TrustBundleKey = "ca-bundle.crt"
+	// JavaTrustStoreKey is the key of the Java trust store in the trust bundle ConfigMap of a component
+	JavaTrustStoreKey = "truststore.jks"
+	// JavaTrustStorePassword is the password of the Java trust stores, which only contain public certificates
+	JavaTrustStorePassword = "changeit"
+	// TrustBundleMountPath is the directory of the trust bundle in the application container
+	TrustBundleMountPath = "/etc/x509/trust-bundle"
+)
+
+// ParsePEMCertificates returns the certificates of PEM data, ignoring the blocks that are not certificates
+func ParsePEMCertificates(data []byte) []*x509.Certificate {
+	var certs []*x509.Certificate
+	for {
+		var block *pem.Block
+		block, data = pem.Decode(data)
+		if block == nil {
+			return certs
+		}
+		if block.Type != "CERTIFICATE" {
+			continue
+		}
+		if cert, err := x509.ParseCertificate(block.Bytes); err == nil {
+			certs = append(certs, cert)
+		}
+	}
+}
+
+// MergeTrustBundle adds the certificates of the PEM data to the bundle, so that the clients keep trusting a previous
+// CA after it is rotated. The certificates that expired are removed.
+func MergeTrustBundle(bundle []byte, now time.Time, add ...[]byte) []byte {
+	var out bytes.Buffer
+	seen := map[string]bool{}
+	for _, data := range append([][]byte{bundle}, add...) {
+		for _, cert := range ParsePEMCertificates(data) {
+			if seen[string(cert.Raw)] || now.After(cert.NotAfter) {
+				continue
+			}
+			seen[string(cert.Raw)] = true
+			pem.Encode(&out, &pem.Block{Type: "CERTIFICATE", Bytes: cert.Raw})
+		}
+	}
+	return out.Bytes()
+}
+
+// EncodeJavaTrustStore returns a JKS trust store with a trusted certificate entry for each certificate. The entries
+// are dated with the start of the validity of their certificate, so that the same certificates give the same store.
+func EncodeJavaTrustStore(certs []*x509.Certificate, password string) []byte {
+	var buf bytes.Buffer
+	writeUTF := func(s string) {
+		binary.Write(&buf, binary.BigEndian, uint16(len(s)))
+		buf.WriteString(s)
+	}
+	binary.Write(&buf, binary.BigEndian, uint32(0xFEEDFEED))
+	binary.Write(&buf, binary.BigEndian, uint32(2))
+	binary.Write(&buf, binary.BigEndian, uint32(len(certs)))
+	for i, cert := range certs {
+		// Tag of a trusted certificate entry
+		binary.Write(&buf, binary.BigEndian, uint32(2))
+		writeUTF(fmt.Sprintf("ca-%d", i))
+		binary.Write(&buf, binary.BigEndian, cert.NotBefore.UnixNano()/int64(time.Millisecond))
+		writeUTF("X.509")
+		binary.Write(&buf, binary.BigEndian, uint32(len(cert.Raw)))
+		buf.Write(cert.Raw)
+	}
+
+	// The integrity of the store is checked with a SHA-1 digest of the password, a fixed salt and the entries
+	h := sha1.New()
+	for _, c := range password {
+		h.Write([]byte{byte(c >> 8), byte(c)})
+	}
+	h.Write([]byte("Mighty Aphrodite"))
+	h.Write(buf.Bytes())
+	buf.Write(h.Sum(nil))
+	return buf.Bytes()
+}
+
+// CustomizeTrustBundleConfigMap configures the trust bundle ConfigMap of a component, which has the PEM certificates
+// of the trust bundle and the same certificates in a Java trust store
+func CustomizeTrustBundleConfigMap(cm *corev1.ConfigMap, ba common.BaseComponent, bundle []byte) error {
+	certs := ParsePEMCertificates(bundle)
+	if len(certs) == 0 {
+		return fmt.Errorf("the %q key of the %q trust bundle ConfigMap does not contain any PEM certificate", ba.GetTrustBundle().GetKey(), ba.GetTrustBundle().GetConfigMapName())
+	}
+	cm.Labels = ba.GetLabels()
+	cm.Annotations = MergeMaps(cm.Annotations, ba.GetAnnotations())
+	cm.Data = map[string]string{TrustBundleKey: string(MergeTrustBundle(nil, time.Time{}, bundle))}
+	cm.BinaryData = map[string][]byte{JavaTrustStoreKey: EncodeJavaTrustStore(certs, JavaTrustStorePassword)}
+	return nil
+}
+
+// customizePodWithTrustBundle mounts the trust bundle ConfigMap of the component in the application container. The
+// resource version of the ConfigMap is set in the environment, so that the pods are rolled when the bundle changes.
+func customizePodWithTrustBundle(podSpec *corev1.PodSpec, appContainer *corev1.Container, ba common.BaseComponent) {
+	if ba.GetTrustBundle() == nil {
+		return
+	}
+	obj := ba.(metav1.Object)
+	appContainer.Env = append(appContainer.Env, corev1.EnvVar{Name: "TRUST_BUNDLE_DIR", Value: TrustBundleMountPath})
+	if rv := ba.GetStatus().GetReferences()[common.StatusReferenceTrustBundleResourceVersion]; rv != "" {
+		appContainer.Env = append(appContainer.Env, corev1.EnvVar{Name: "TRUST_BUNDLE_RESOURCE_VERSION", Value: rv})
+	}
+	podSpec.Volumes = append(podSpec.Volumes, corev1.Volume{
+		Name: "trust-bundle",
+		VolumeSource: corev1.VolumeSource{
+			ConfigMap: &corev1.ConfigMapVolumeSource{
+				LocalObjectReference: corev1.LocalObjectReference{Name: obj.GetName() + "-trust-bundle"},
+			},
+		},
+	})
+	appContainer.VolumeMounts = append(appContainer.VolumeMounts, corev1.VolumeMount{
+		Name:      "trust-bundle",
+		MountPath: TrustBundleMountPath,
+		ReadOnly:  true,
+	})
+}
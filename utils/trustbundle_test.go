@@ -0,0 +1,110 @@
+package utils
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/binary"
+	"encoding/pem"
+	"math/big"
+	"testing"
+	"time"
+
+	appstacksv1 "github.com/application-stacks/runtime-component-operator/api/v1"
+	"github.com/application-stacks/runtime-component-operator/common"
+	corev1 "k8s.io/api/core/v1"
+)
+
+func TestMergeTrustBundle(t *testing.T) {
+	now := time.Now()
+	oldCA := createTestCA(t, "old-ca", now.Add(-time.Hour), now.Add(24*time.Hour))
+	newCA := createTestCA(t, "new-ca", now.Add(-time.Hour), now.Add(48*time.Hour))
+
+	bundle := MergeTrustBundle(nil, now, oldCA)
+	rotated := MergeTrustBundle(bundle, now, newCA, oldCA)
+	expired := MergeTrustBundle(rotated, now.Add(36*time.Hour))
+
+	testMTB := []Test{
+		{"Initial bundle", 1, len(ParsePEMCertificates(bundle))},
+		{"Rotated bundle keeps the previous CA", 2, len(ParsePEMCertificates(rotated))},
+		{"Expired CA is removed", "new-ca", ParsePEMCertificates(expired)[0].Subject.CommonName},
+		{"Expired bundle", 1, len(ParsePEMCertificates(expired))},
+	}
+	verifyTests(testMTB, t)
+}
+
+func TestEncodeJavaTrustStore(t *testing.T) {
+	now := time.Now()
+	certs := ParsePEMCertificates(MergeTrustBundle(nil, now, createTestCA(t, "ca", now.Add(-time.Hour), now.Add(time.Hour))))
+	store := EncodeJavaTrustStore(certs, JavaTrustStorePassword)
+
+	testEJTS := []Test{
+		{"Magic number", uint32(0xFEEDFEED), binary.BigEndian.Uint32(store[0:4])},
+		{"Version", uint32(2), binary.BigEndian.Uint32(store[4:8])},
+		{"Number of entries", uint32(1), binary.BigEndian.Uint32(store[8:12])},
+		{"Trusted certificate entry", uint32(2), binary.BigEndian.Uint32(store[12:16])},
+		{"Same certificates give the same store", string(store), string(EncodeJavaTrustStore(certs, JavaTrustStorePassword))},
+	}
+	verifyTests(testEJTS, t)
+}
+
+func TestCustomizeTrustBundle(t *testing.T) {
+	now := time.Now()
+	spec := appstacksv1.RuntimeComponentSpec{Service: service, TrustBundle: &appstacksv1.RuntimeComponentTrustBundle{}}
+	runtime := createRuntimeComponent(name, namespace, spec)
+	runtime.Status.SetReference(common.StatusReferenceTrustBundleResourceVersion, "42")
+
+	cm := &corev1.ConfigMap{}
+	err := CustomizeTrustBundleConfigMap(cm, runtime, createTestCA(t, "ca", now.Add(-time.Hour), now.Add(time.Hour)))
+	emptyErr := CustomizeTrustBundleConfigMap(&corev1.ConfigMap{}, runtime, nil)
+
+	pts := &corev1.PodTemplateSpec{}
+	CustomizePodSpec(pts, runtime)
+	appContainer := GetAppContainer(pts.Spec.Containers)
+	env := map[string]string{}
+	for _, e := range appContainer.Env {
+		env[e.Name] = e.Value
+	}
+	var volume *corev1.Volume
+	for i := range pts.Spec.Volumes {
+		if pts.Spec.Volumes[i].Name == "trust-bundle" {
+			volume = &pts.Spec.Volumes[i]
+		}
+	}
+
+	testCTB := []Test{
+		{"ConfigMap error", nil, err},
+		{"Bundle without certificates", true, emptyErr != nil},
+		{"PEM certificates", 1, len(ParsePEMCertificates([]byte(cm.Data[TrustBundleKey])))},
+		{"Java trust store", true, len(cm.BinaryData[JavaTrustStoreKey]) > 0},
+		{"Default ConfigMap name", "rco-trust-bundle", runtime.Spec.TrustBundle.GetConfigMapName()},
+		{"Trust bundle directory", TrustBundleMountPath, env["TRUST_BUNDLE_DIR"]},
+		{"Trust bundle resource version", "42", env["TRUST_BUNDLE_RESOURCE_VERSION"]},
+		{"Trust bundle volume", name + "-trust-bundle", volume.ConfigMap.Name},
+	}
+	verifyTests(testCTB, t)
+}
+
+// createTestCA returns the PEM certificate of a self-signed CA valid between the given times
+func createTestCA(t *testing.T, commonName string, notBefore time.Time, notAfter time.Time) []byte {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: commonName},
+		NotBefore:             notBefore,
+		NotAfter:              notAfter,
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+		KeyUsage:              x509.KeyUsageCertSign,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})
+}
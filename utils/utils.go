@@ -6,32 +6,44 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"net/http"
 	"os"
 	"sort"
 	"strconv"
 	"strings"
+	"unicode/utf8"
 
 	networkingv1 "k8s.io/api/networking/v1"
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/kubernetes/scheme"
 	"k8s.io/client-go/rest"
 	"k8s.io/client-go/tools/remotecommand"
+	"k8s.io/client-go/transport/spdy"
+	utilexec "k8s.io/client-go/util/exec"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	"github.com/application-stacks/runtime-component-operator/common"
 	prometheusv1 "github.com/prometheus-operator/prometheus-operator/pkg/apis/monitoring/v1"
 
-	appstacksv1beta2 "github.com/application-stacks/runtime-component-operator/api/v1beta2"
+	appstacksv1 "github.com/application-stacks/runtime-component-operator/api/v1"
+	certmanagerv1 "github.com/jetstack/cert-manager/pkg/apis/certmanager/v1"
+	certmanagermetav1 "github.com/jetstack/cert-manager/pkg/apis/meta/v1"
 	routev1 "github.com/openshift/api/route/v1"
 	appsv1 "k8s.io/api/apps/v1"
 	autoscalingv1 "k8s.io/api/autoscaling/v1"
+	autoscalingv2beta2 "k8s.io/api/autoscaling/v2beta2"
 	corev1 "k8s.io/api/core/v1"
+	policyv1 "k8s.io/api/policy/v1"
 	"k8s.io/apimachinery/pkg/api/equality"
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/httpstream"
 	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/apimachinery/pkg/util/validation"
 	servingv1 "knative.dev/serving/pkg/apis/serving/v1"
 )
 
@@ -41,8 +53,11 @@ func CustomizeDeployment(deploy *appsv1.Deployment, ba common.BaseComponent) {
 	deploy.Labels = ba.GetLabels()
 	deploy.Annotations = MergeMaps(deploy.Annotations, ba.GetAnnotations())
 
-	if ba.GetAutoscaling() == nil {
+	if ba.GetAutoscaling() == nil && ba.GetEventDrivenAutoscaling() == nil {
 		deploy.Spec.Replicas = ba.GetReplicas()
+	} else if ba.GetAutoscaling() != nil && deploy.Spec.Replicas != nil && *deploy.Spec.Replicas == 0 {
+		// A HorizontalPodAutoscaler does not scale up from zero, which is left by a ScaledObject that was removed
+		deploy.Spec.Replicas = ba.GetAutoscaling().GetMinReplicas()
 	}
 
 	if deploy.Spec.Selector == nil {
@@ -71,8 +86,11 @@ func CustomizeStatefulSet(statefulSet *appsv1.StatefulSet, ba common.BaseCompone
 	statefulSet.Labels = ba.GetLabels()
 	statefulSet.Annotations = MergeMaps(statefulSet.Annotations, ba.GetAnnotations())
 
-	if ba.GetAutoscaling() == nil {
+	if ba.GetAutoscaling() == nil && ba.GetEventDrivenAutoscaling() == nil {
 		statefulSet.Spec.Replicas = ba.GetReplicas()
+	} else if ba.GetAutoscaling() != nil && statefulSet.Spec.Replicas != nil && *statefulSet.Spec.Replicas == 0 {
+		// A HorizontalPodAutoscaler does not scale up from zero, which is left by a ScaledObject that was removed
+		statefulSet.Spec.Replicas = ba.GetAutoscaling().GetMinReplicas()
 	}
 	statefulSet.Spec.ServiceName = obj.GetName() + "-headless"
 	if statefulSet.Spec.Selector == nil {
@@ -97,7 +115,7 @@ func CustomizeStatefulSet(statefulSet *appsv1.StatefulSet, ba common.BaseCompone
 }
 
 // CustomizeRoute ...
-func CustomizeRoute(route *routev1.Route, ba common.BaseComponent, key string, crt string, ca string, destCACert string) {
+func CustomizeRoute(route *routev1.Route, ba common.BaseComponent, key string, crt string, ca string, destCACert string, defaultHostname string) {
 	obj := ba.(metav1.Object)
 	route.Labels = ba.GetLabels()
 	route.Annotations = MergeMaps(route.Annotations, ba.GetAnnotations())
@@ -107,8 +125,8 @@ func CustomizeRoute(route *routev1.Route, ba common.BaseComponent, key string, c
 		route.Annotations = MergeMaps(route.Annotations, rt.GetAnnotations())
 
 		host := rt.GetHost()
-		if host == "" && common.Config[common.OpConfigDefaultHostname] != "" {
-			host = obj.GetName() + "-" + obj.GetNamespace() + "." + common.Config[common.OpConfigDefaultHostname]
+		if host == "" && defaultHostname != "" {
+			host = obj.GetName() + "-" + obj.GetNamespace() + "." + defaultHostname
 		}
 		route.Spec.Host = host
 		route.Spec.Path = rt.GetPath()
@@ -327,6 +345,8 @@ func CustomizeNetworkPolicy(networkPolicy *networkingv1.NetworkPolicy, isOpenShi
 	if config.GetNamespaceLabels() != nil && len(config.GetNamespaceLabels()) == 0 &&
 		config.GetFromLabels() != nil && len(config.GetFromLabels()) == 0 {
 		rule = createAllowAllNetworkPolicyIngressRule()
+	} else if ba.GetMTLS() != nil && len(ba.GetMTLS().GetAllowedCallers()) > 0 {
+		rule = createMTLSNetworkPolicyIngressRule(isOpenShift, isExposed, ba)
 	} else if isOpenShift {
 		rule = createOpenShiftNetworkPolicyIngressRule(ba.GetApplicationName(), networkPolicy.Namespace, isExposed, config)
 	} else {
@@ -342,23 +362,7 @@ func createOpenShiftNetworkPolicyIngressRule(appName string, namespace string, i
 
 	// Add peer to allow traffic from the OpenShift router
 	if isExposed {
-		rule.From = append(rule.From,
-			networkingv1.NetworkPolicyPeer{
-				NamespaceSelector: &metav1.LabelSelector{
-					MatchLabels: map[string]string{
-						"policy-group.network.openshift.io/ingress": "",
-					},
-				},
-			},
-			// Legacy label still required on OCP 4.6
-			networkingv1.NetworkPolicyPeer{
-				NamespaceSelector: &metav1.LabelSelector{
-					MatchLabels: map[string]string{
-						"network.openshift.io/policy-group": "ingress",
-					},
-				},
-			},
-		)
+		rule.From = append(rule.From, createOpenShiftRouterNetworkPolicyPeers()...)
 	}
 
 	rule.From = append(rule.From,
@@ -366,16 +370,40 @@ func createOpenShiftNetworkPolicyIngressRule(appName string, namespace string, i
 		createNetworkPolicyPeer(appName, namespace, config),
 
 		// Add peer to allow traffic from OpenShift monitoring
-		networkingv1.NetworkPolicyPeer{
+		createOpenShiftMonitoringNetworkPolicyPeer(),
+	)
+
+	return rule
+}
+
+func createOpenShiftMonitoringNetworkPolicyPeer() networkingv1.NetworkPolicyPeer {
+	return networkingv1.NetworkPolicyPeer{
+		NamespaceSelector: &metav1.LabelSelector{
+			MatchLabels: map[string]string{
+				"network.openshift.io/policy-group": "monitoring",
+			},
+		},
+	}
+}
+
+func createOpenShiftRouterNetworkPolicyPeers() []networkingv1.NetworkPolicyPeer {
+	return []networkingv1.NetworkPolicyPeer{
+		{
 			NamespaceSelector: &metav1.LabelSelector{
 				MatchLabels: map[string]string{
-					"network.openshift.io/policy-group": "monitoring",
+					"policy-group.network.openshift.io/ingress": "",
 				},
 			},
 		},
-	)
-
-	return rule
+		// Legacy label still required on OCP 4.6
+		{
+			NamespaceSelector: &metav1.LabelSelector{
+				MatchLabels: map[string]string{
+					"network.openshift.io/policy-group": "ingress",
+				},
+			},
+		},
+	}
 }
 
 func createKubernetesNetworkPolicyIngressRule(appName string, namespace string, isExposed bool, config common.BaseComponentNetworkPolicy) networkingv1.NetworkPolicyIngressRule {
@@ -463,6 +491,52 @@ func CustomizeAffinity(affinity *corev1.Affinity, ba common.BaseComponent) {
 	customizeAffinityArchitectures(affinity, affinityConfig)
 }
 
+// CustomizeTopologySpreadConstraints spreads the pods across zones and hosts when possible, unless the defaults
+// are disabled, and adds the constraints of the component. The pods of the component are selected by the
+// constraints that do not have a label selector.
+func CustomizeTopologySpreadConstraints(pts *corev1.PodTemplateSpec, ba common.BaseComponent) {
+	obj := ba.(metav1.Object)
+	selector := &metav1.LabelSelector{
+		MatchLabels: map[string]string{
+			"app.kubernetes.io/instance": obj.GetName(),
+		},
+	}
+
+	var constraints []corev1.TopologySpreadConstraint
+	config := ba.GetTopologySpreadConstraints()
+	if config != nil {
+		constraints = config.GetConstraints()
+	}
+
+	pts.Spec.TopologySpreadConstraints = nil
+	if config == nil || config.GetDisableOperatorDefaults() == nil || !*config.GetDisableOperatorDefaults() {
+		for _, topologyKey := range []string{"topology.kubernetes.io/zone", "kubernetes.io/hostname"} {
+			overridden := false
+			for _, constraint := range constraints {
+				if constraint.TopologyKey == topologyKey {
+					overridden = true
+				}
+			}
+			if !overridden {
+				pts.Spec.TopologySpreadConstraints = append(pts.Spec.TopologySpreadConstraints, corev1.TopologySpreadConstraint{
+					MaxSkew:           1,
+					TopologyKey:       topologyKey,
+					WhenUnsatisfiable: corev1.ScheduleAnyway,
+					LabelSelector:     selector.DeepCopy(),
+				})
+			}
+		}
+	}
+
+	for _, constraint := range constraints {
+		c := *constraint.DeepCopy()
+		if c.LabelSelector == nil {
+			c.LabelSelector = selector.DeepCopy()
+		}
+		pts.Spec.TopologySpreadConstraints = append(pts.Spec.TopologySpreadConstraints, c)
+	}
+}
+
 // isCustomAffinityDefined returns true if everything but .spec.affinity.architecture is not defined.
 func isCustomAffinityDefined(affinityConfig common.BaseComponentAffinity) bool {
 	return affinityConfig != nil &&
@@ -679,6 +753,9 @@ func CustomizePodSpec(pts *corev1.PodTemplateSpec, ba common.BaseComponent) {
 		appContainer.Env = append(appContainer.Env, corev1.EnvVar{Name: "SA_RESOURCE_VERSION", Value: saRV})
 	}
 
+	customizePodWithTrustBundle(&pts.Spec, &appContainer, ba)
+	customizePodWithMTLS(&pts.Spec, &appContainer, ba)
+
 	pts.Spec.Containers = append([]corev1.Container{appContainer}, ba.GetSidecarContainers()...)
 
 	if ba.GetServiceAccountName() != nil && *ba.GetServiceAccountName() != "" {
@@ -691,6 +768,7 @@ func CustomizePodSpec(pts *corev1.PodTemplateSpec, ba common.BaseComponent) {
 
 	pts.Spec.Affinity = &corev1.Affinity{}
 	CustomizeAffinity(pts.Spec.Affinity, ba)
+	CustomizeTopologySpreadConstraints(pts, ba)
 }
 
 // CustomizePersistence ...
@@ -846,8 +924,13 @@ func CustomizeKnativeService(ksvc *servingv1.Service, ba common.BaseComponent) {
 	}
 
 	ksvc.Spec.Template.Spec.Containers[0].Image = ba.GetStatus().GetImageReference()
-	// Knative sets its own resource constraints
-	// ksvc.Spec.Template.Spec.Containers[0].Resources = *cr.Spec.ResourceConstraints
+	ksvc.Spec.Template.Spec.Containers[0].Resources = corev1.ResourceRequirements{}
+	if kn := ba.GetKnative(); kn != nil && kn.GetResources() != nil {
+		ksvc.Spec.Template.Spec.Containers[0].Resources = *kn.GetResources()
+	} else if ba.GetResourceConstraints() != nil {
+		ksvc.Spec.Template.Spec.Containers[0].Resources = *ba.GetResourceConstraints()
+	}
+	ksvc.Spec.Template.Spec.Containers[0].SecurityContext = getKnativeSecurityContext(ba)
 
 	CustomizeProbes(&ksvc.Spec.Template.Spec.Containers[0], ba)
 
@@ -857,6 +940,7 @@ func CustomizeKnativeService(ksvc *servingv1.Service, ba common.BaseComponent) {
 
 	ksvc.Spec.Template.Spec.Containers[0].VolumeMounts = ba.GetVolumeMounts()
 	ksvc.Spec.Template.Spec.Volumes = ba.GetVolumes()
+	customizePodWithTrustBundle(&ksvc.Spec.Template.Spec.PodSpec, &ksvc.Spec.Template.Spec.Containers[0], ba)
 
 	if ba.GetServiceAccountName() != nil && *ba.GetServiceAccountName() != "" {
 		ksvc.Spec.Template.Spec.ServiceAccountName = *ba.GetServiceAccountName()
@@ -890,6 +974,148 @@ func CustomizeKnativeService(ksvc *servingv1.Service, ba common.BaseComponent) {
 			ksvc.Spec.Template.Spec.Containers[0].StartupProbe.TCPSocket.Port = intstr.IntOrString{}
 		}
 	}
+
+	// Sidecar containers must not expose ports, as Knative routes the requests to the port of the application container
+	ksvc.Spec.Template.Spec.Containers = append(ksvc.Spec.Template.Spec.Containers[:1], ba.GetSidecarContainers()...)
+	ksvc.Spec.Template.Spec.InitContainers = ba.GetInitContainers()
+
+	customizeKnativeAutoscaling(ksvc, ba)
+	customizeKnativeTraffic(ksvc, ba)
+}
+
+const (
+	knativeMinScaleAnnotation                      = "autoscaling.knative.dev/min-scale"
+	knativeMaxScaleAnnotation                      = "autoscaling.knative.dev/max-scale"
+	knativeTargetUtilizationPercentageAnnotation   = "autoscaling.knative.dev/target-utilization-percentage"
+	knativeScaleToZeroPodRetentionPeriodAnnotation = "autoscaling.knative.dev/scale-to-zero-pod-retention-period"
+)
+
+// customizeKnativeAutoscaling sets the autoscaling annotations and the container concurrency of the revision template.
+// The scale bounds default to the replicas of .spec.autoscaling.
+func customizeKnativeAutoscaling(ksvc *servingv1.Service, ba common.BaseComponent) {
+	annotations := ksvc.Spec.Template.ObjectMeta.Annotations
+	if annotations == nil {
+		annotations = map[string]string{}
+	}
+	for _, key := range []string{knativeMinScaleAnnotation, knativeMaxScaleAnnotation,
+		knativeTargetUtilizationPercentageAnnotation, knativeScaleToZeroPodRetentionPeriodAnnotation} {
+		if _, ok := ba.GetAnnotations()[key]; !ok {
+			delete(annotations, key)
+		}
+	}
+
+	var minScale, maxScale *int32
+	if as := ba.GetAutoscaling(); as != nil {
+		minScale = as.GetMinReplicas()
+		if as.GetMaxReplicas() != 0 {
+			maxReplicas := as.GetMaxReplicas()
+			maxScale = &maxReplicas
+		}
+	}
+	ksvc.Spec.Template.Spec.ContainerConcurrency = nil
+	if kn := ba.GetKnative(); kn != nil {
+		if kn.GetMinScale() != nil {
+			minScale = kn.GetMinScale()
+		}
+		if kn.GetMaxScale() != nil {
+			maxScale = kn.GetMaxScale()
+		}
+		if kn.GetTargetUtilizationPercentage() != nil {
+			annotations[knativeTargetUtilizationPercentageAnnotation] = strconv.Itoa(int(*kn.GetTargetUtilizationPercentage()))
+		}
+		if kn.GetScaleToZeroPodRetentionPeriod() != nil {
+			annotations[knativeScaleToZeroPodRetentionPeriodAnnotation] = kn.GetScaleToZeroPodRetentionPeriod().Duration.String()
+		}
+		ksvc.Spec.Template.Spec.ContainerConcurrency = kn.GetContainerConcurrency()
+	}
+	if minScale != nil {
+		annotations[knativeMinScaleAnnotation] = strconv.Itoa(int(*minScale))
+	}
+	if maxScale != nil {
+		annotations[knativeMaxScaleAnnotation] = strconv.Itoa(int(*maxScale))
+	}
+	ksvc.Spec.Template.ObjectMeta.Annotations = annotations
+}
+
+// customizeKnativeTraffic names the revision of the template and splits the traffic between the revisions.
+// Without a traffic block, Knative sends all the traffic to the latest ready revision.
+func customizeKnativeTraffic(ksvc *servingv1.Service, ba common.BaseComponent) {
+	obj := ba.(metav1.Object)
+	kn := ba.GetKnative()
+	ksvc.Spec.Template.ObjectMeta.Name = ""
+	ksvc.Spec.Traffic = nil
+	if kn == nil {
+		return
+	}
+	if kn.GetRevisionName() != "" {
+		ksvc.Spec.Template.ObjectMeta.Name = GetKnativeRevisionName(obj.GetName(), kn.GetRevisionName())
+	}
+	for _, t := range kn.GetTraffic() {
+		latestRevision := t.GetRevisionName() == ""
+		target := servingv1.TrafficTarget{Tag: t.GetTag(), Percent: t.GetPercent(), LatestRevision: &latestRevision}
+		if !latestRevision {
+			target.RevisionName = GetKnativeRevisionName(obj.GetName(), t.GetRevisionName())
+		}
+		ksvc.Spec.Traffic = append(ksvc.Spec.Traffic, target)
+	}
+}
+
+// GetKnativeRevisionName returns the name of a revision of the Knative service, which is prefixed with the name of the service
+func GetKnativeRevisionName(serviceName string, revisionName string) string {
+	return serviceName + "-" + revisionName
+}
+
+// getKnativeSecurityContext returns the security context of the application container without the fields that Knative rejects
+func getKnativeSecurityContext(ba common.BaseComponent) *corev1.SecurityContext {
+	secContext := getSecurityContext(ba).DeepCopy()
+	secContext.Privileged = nil
+	secContext.SELinuxOptions = nil
+	secContext.WindowsOptions = nil
+	secContext.ProcMount = nil
+	return secContext
+}
+
+// validateKnative checks the Knative settings of the component
+func validateKnative(ba common.BaseComponent) error {
+	if ba.GetCreateKnativeService() != nil && *ba.GetCreateKnativeService() {
+		for _, c := range ba.GetSidecarContainers() {
+			if len(c.Ports) > 0 {
+				return createValidationError(fmt.Sprintf("the sidecar container '%s' must not declare ports in a Knative service, which only exposes the port of the application container", c.Name))
+			}
+		}
+	}
+	kn := ba.GetKnative()
+	if kn == nil {
+		return nil
+	}
+	if ba.GetCreateKnativeService() == nil || !*ba.GetCreateKnativeService() {
+		return createValidationError(requiredFieldMessage("spec.createKnativeService"))
+	}
+	if kn.GetMinScale() != nil && kn.GetMaxScale() != nil && *kn.GetMaxScale() != 0 && *kn.GetMinScale() > *kn.GetMaxScale() {
+		return createValidationError(fmt.Sprintf("spec.knative.maxScale (%d) must not be less than spec.knative.minScale (%d)", *kn.GetMaxScale(), *kn.GetMinScale()))
+	}
+	traffic := kn.GetTraffic()
+	if len(traffic) == 0 {
+		return nil
+	}
+	var total int64
+	tags := map[string]bool{}
+	for _, t := range traffic {
+		if t.GetPercent() != nil {
+			total += *t.GetPercent()
+		}
+		if t.GetTag() == "" {
+			continue
+		}
+		if tags[t.GetTag()] {
+			return createValidationError(fmt.Sprintf("the tag '%s' is set on more than one entry of spec.knative.traffic", t.GetTag()))
+		}
+		tags[t.GetTag()] = true
+	}
+	if total != 100 {
+		return createValidationError(fmt.Sprintf("the percentages of spec.knative.traffic must add up to 100, but add up to %d", total))
+	}
+	return nil
 }
 
 // CustomizeHPA ...
@@ -912,6 +1138,258 @@ func CustomizeHPA(hpa *autoscalingv1.HorizontalPodAutoscaler, ba common.BaseComp
 	}
 }
 
+// CustomizeHPAV2 configures an autoscaling/v2beta2 HorizontalPodAutoscaler, which has the same spec as autoscaling/v2.
+// The target CPU utilization is added to the metrics of the autoscaling spec.
+func CustomizeHPAV2(hpa *autoscalingv2beta2.HorizontalPodAutoscaler, ba common.BaseComponent) {
+	obj := ba.(metav1.Object)
+	hpa.Labels = ba.GetLabels()
+	hpa.Annotations = MergeMaps(hpa.Annotations, ba.GetAnnotations())
+
+	autoscaling := ba.GetAutoscaling()
+	hpa.Spec.MaxReplicas = autoscaling.GetMaxReplicas()
+	hpa.Spec.MinReplicas = autoscaling.GetMinReplicas()
+
+	hpa.Spec.Metrics = nil
+	if autoscaling.GetTargetCPUUtilizationPercentage() != nil {
+		hpa.Spec.Metrics = append(hpa.Spec.Metrics, autoscalingv2beta2.MetricSpec{
+			Type: autoscalingv2beta2.ResourceMetricSourceType,
+			Resource: &autoscalingv2beta2.ResourceMetricSource{
+				Name: corev1.ResourceCPU,
+				Target: autoscalingv2beta2.MetricTarget{
+					Type:               autoscalingv2beta2.UtilizationMetricType,
+					AverageUtilization: autoscaling.GetTargetCPUUtilizationPercentage(),
+				},
+			},
+		})
+	}
+	for _, metric := range autoscaling.GetMetrics() {
+		hpa.Spec.Metrics = append(hpa.Spec.Metrics, *metric.DeepCopy())
+	}
+	hpa.Spec.Behavior = nil
+	if autoscaling.GetBehavior() != nil {
+		hpa.Spec.Behavior = autoscaling.GetBehavior().DeepCopy()
+	}
+
+	hpa.Spec.ScaleTargetRef.Name = obj.GetName()
+	hpa.Spec.ScaleTargetRef.APIVersion = "apps/v1"
+
+	if ba.GetStatefulSet() != nil {
+		hpa.Spec.ScaleTargetRef.Kind = "StatefulSet"
+	} else {
+		hpa.Spec.ScaleTargetRef.Kind = "Deployment"
+	}
+}
+
+// ScaledObjectGVK is the kind of the KEDA ScaledObjects, which are handled as unstructured objects
+var ScaledObjectGVK = schema.GroupVersionKind{Group: "keda.sh", Version: "v1alpha1", Kind: "ScaledObject"}
+
+// CustomizeScaledObject configures a KEDA ScaledObject that scales the Deployment or StatefulSet of the component
+func CustomizeScaledObject(so *unstructured.Unstructured, ba common.BaseComponent) {
+	obj := ba.(metav1.Object)
+	so.SetLabels(ba.GetLabels())
+	so.SetAnnotations(MergeMaps(so.GetAnnotations(), ba.GetAnnotations()))
+
+	kind := "Deployment"
+	if ba.GetStatefulSet() != nil {
+		kind = "StatefulSet"
+	}
+	spec := map[string]interface{}{
+		"scaleTargetRef": map[string]interface{}{
+			"apiVersion": "apps/v1",
+			"kind":       kind,
+			"name":       obj.GetName(),
+		},
+	}
+
+	eda := ba.GetEventDrivenAutoscaling()
+	setInt := func(field string, value *int32) {
+		if value != nil {
+			spec[field] = int64(*value)
+		}
+	}
+	setInt("minReplicaCount", eda.GetMinReplicas())
+	setInt("maxReplicaCount", eda.GetMaxReplicas())
+	setInt("pollingInterval", eda.GetPollingInterval())
+	setInt("cooldownPeriod", eda.GetCooldownPeriod())
+
+	triggers := []interface{}{}
+	for _, t := range eda.GetTriggers() {
+		metadata := map[string]interface{}{}
+		for k, v := range t.GetMetadata() {
+			metadata[k] = v
+		}
+		trigger := map[string]interface{}{
+			"type":     t.GetType(),
+			"metadata": metadata,
+		}
+		if t.GetName() != "" {
+			trigger["name"] = t.GetName()
+		}
+		if t.GetAuthenticationRef() != "" {
+			trigger["authenticationRef"] = map[string]interface{}{
+				"name": t.GetAuthenticationRef(),
+				"kind": t.GetAuthenticationKind(),
+			}
+		}
+		triggers = append(triggers, trigger)
+	}
+	spec["triggers"] = triggers
+
+	so.Object["spec"] = spec
+}
+
+// VerticalPodAutoscalerGVK is the kind of the VerticalPodAutoscalers, which are handled as unstructured objects
+var VerticalPodAutoscalerGVK = schema.GroupVersionKind{Group: "autoscaling.k8s.io", Version: "v1", Kind: "VerticalPodAutoscaler"}
+
+// CustomizeVPA configures a VerticalPodAutoscaler that recommends the resources of the application container.
+// The resources of the other containers are left as they are.
+func CustomizeVPA(vpa *unstructured.Unstructured, ba common.BaseComponent) {
+	obj := ba.(metav1.Object)
+	vpa.SetLabels(ba.GetLabels())
+	vpa.SetAnnotations(MergeMaps(vpa.GetAnnotations(), ba.GetAnnotations()))
+
+	kind := "Deployment"
+	if ba.GetStatefulSet() != nil {
+		kind = "StatefulSet"
+	}
+
+	va := ba.GetVerticalAutoscaling()
+	updateMode := "Auto"
+	if va.GetUpdateMode() != nil {
+		updateMode = *va.GetUpdateMode()
+	}
+
+	resourceList := func(resources corev1.ResourceList) map[string]interface{} {
+		list := map[string]interface{}{}
+		for name, quantity := range resources {
+			list[string(name)] = quantity.String()
+		}
+		return list
+	}
+	appPolicy := map[string]interface{}{
+		"containerName": "app",
+	}
+	if len(va.GetMinAllowed()) > 0 {
+		appPolicy["minAllowed"] = resourceList(va.GetMinAllowed())
+	}
+	if len(va.GetMaxAllowed()) > 0 {
+		appPolicy["maxAllowed"] = resourceList(va.GetMaxAllowed())
+	}
+	if len(va.GetControlledResources()) > 0 {
+		controlledResources := []interface{}{}
+		for _, name := range va.GetControlledResources() {
+			controlledResources = append(controlledResources, string(name))
+		}
+		appPolicy["controlledResources"] = controlledResources
+	}
+	if va.GetControlledValues() != nil {
+		appPolicy["controlledValues"] = *va.GetControlledValues()
+	}
+
+	vpa.Object["spec"] = map[string]interface{}{
+		"targetRef": map[string]interface{}{
+			"apiVersion": "apps/v1",
+			"kind":       kind,
+			"name":       obj.GetName(),
+		},
+		"updatePolicy": map[string]interface{}{
+			"updateMode": updateMode,
+		},
+		"resourcePolicy": map[string]interface{}{
+			"containerPolicies": []interface{}{
+				appPolicy,
+				map[string]interface{}{"containerName": "*", "mode": "Off"},
+			},
+		},
+	}
+}
+
+// getHorizontallyScaledResources returns the resources whose utilization scales the pods horizontally
+func getHorizontallyScaledResources(ba common.BaseComponent) map[corev1.ResourceName]bool {
+	resources := map[corev1.ResourceName]bool{}
+	if as := ba.GetAutoscaling(); as != nil {
+		// Without a target and metrics, the HorizontalPodAutoscaler defaults to a target CPU utilization
+		if as.GetTargetCPUUtilizationPercentage() != nil || len(as.GetMetrics()) == 0 {
+			resources[corev1.ResourceCPU] = true
+		}
+		for _, metric := range as.GetMetrics() {
+			if metric.Resource != nil {
+				resources[metric.Resource.Name] = true
+			}
+			if metric.ContainerResource != nil {
+				resources[metric.ContainerResource.Name] = true
+			}
+		}
+	}
+	if eda := ba.GetEventDrivenAutoscaling(); eda != nil {
+		for _, trigger := range eda.GetTriggers() {
+			if trigger.GetType() == "cpu" || trigger.GetType() == "memory" {
+				resources[corev1.ResourceName(trigger.GetType())] = true
+			}
+		}
+	}
+	return resources
+}
+
+// IsDisruptionBudgetEnabled returns whether a PodDisruptionBudget is created for the component
+func IsDisruptionBudgetEnabled(ba common.BaseComponent) bool {
+	if ba.GetCreateKnativeService() != nil && *ba.GetCreateKnativeService() {
+		return false
+	}
+	if db := ba.GetDisruptionBudget(); db != nil {
+		if db.GetEnabled() != nil {
+			return *db.GetEnabled()
+		}
+		if db.GetMinAvailable() != nil || db.GetMaxUnavailable() != nil {
+			return true
+		}
+	}
+
+	// A single pod would not be evicted with the default budget
+	var replicas int32 = 1
+	if as := ba.GetAutoscaling(); as != nil {
+		if as.GetMinReplicas() != nil {
+			replicas = *as.GetMinReplicas()
+		}
+	} else if eda := ba.GetEventDrivenAutoscaling(); eda != nil {
+		replicas = 0
+		if eda.GetMinReplicas() != nil {
+			replicas = *eda.GetMinReplicas()
+		}
+	} else if ba.GetReplicas() != nil {
+		replicas = *ba.GetReplicas()
+	}
+	return replicas > 1
+}
+
+// CustomizePDB configures a PodDisruptionBudget for the pods of the component. At most one pod is unavailable
+// when neither minAvailable nor maxUnavailable is set.
+func CustomizePDB(pdb *policyv1.PodDisruptionBudget, ba common.BaseComponent) {
+	obj := ba.(metav1.Object)
+	pdb.Labels = ba.GetLabels()
+	pdb.Annotations = MergeMaps(pdb.Annotations, ba.GetAnnotations())
+
+	pdb.Spec.Selector = &metav1.LabelSelector{
+		MatchLabels: map[string]string{
+			"app.kubernetes.io/instance": obj.GetName(),
+		},
+	}
+
+	pdb.Spec.MinAvailable = nil
+	pdb.Spec.MaxUnavailable = nil
+	db := ba.GetDisruptionBudget()
+	if db != nil && db.GetMinAvailable() != nil {
+		minAvailable := *db.GetMinAvailable()
+		pdb.Spec.MinAvailable = &minAvailable
+	} else if db != nil && db.GetMaxUnavailable() != nil {
+		maxUnavailable := *db.GetMaxUnavailable()
+		pdb.Spec.MaxUnavailable = &maxUnavailable
+	} else {
+		maxUnavailable := intstr.FromInt(1)
+		pdb.Spec.MaxUnavailable = &maxUnavailable
+	}
+}
+
 // Validate if the BaseComponent is valid
 func Validate(ba common.BaseComponent) (bool, error) {
 	// Storage validation
@@ -927,9 +1405,176 @@ func Validate(ba common.BaseComponent) (bool, error) {
 		}
 	}
 
+	// Knative services are not backed by a StatefulSet
+	if ba.GetCreateKnativeService() != nil && *ba.GetCreateKnativeService() && ss != nil {
+		return false, createValidationError(conflictingFieldsMessage("spec.createKnativeService", "spec.statefulSet"))
+	}
+
+	if err := validateKnative(ba); err != nil {
+		return false, err
+	}
+
+	if err := validateMTLS(ba); err != nil {
+		return false, err
+	}
+
+	// Event-driven autoscaling validation
+	if eda := ba.GetEventDrivenAutoscaling(); eda != nil {
+		if ba.GetAutoscaling() != nil {
+			return false, createValidationError(conflictingFieldsMessage("spec.autoscaling", "spec.eventDrivenAutoscaling"))
+		}
+		if ba.GetCreateKnativeService() != nil && *ba.GetCreateKnativeService() {
+			return false, createValidationError(conflictingFieldsMessage("spec.createKnativeService", "spec.eventDrivenAutoscaling"))
+		}
+		if len(eda.GetTriggers()) == 0 {
+			return false, createValidationError(requiredFieldMessage("spec.eventDrivenAutoscaling.triggers"))
+		}
+		if eda.GetMinReplicas() != nil && eda.GetMaxReplicas() != nil && *eda.GetMinReplicas() > *eda.GetMaxReplicas() {
+			return false, createValidationError(fmt.Sprintf("spec.eventDrivenAutoscaling.maxReplicas (%d) must not be less than spec.eventDrivenAutoscaling.minReplicas (%d)", *eda.GetMaxReplicas(), *eda.GetMinReplicas()))
+		}
+	}
+
+	if ba.GetVerticalAutoscaling() != nil && ba.GetCreateKnativeService() != nil && *ba.GetCreateKnativeService() {
+		return false, createValidationError(conflictingFieldsMessage("spec.createKnativeService", "spec.verticalAutoscaling"))
+	}
+
+	// A VerticalPodAutoscaler that updates the pods must not change the resources used to scale them horizontally
+	if va := ba.GetVerticalAutoscaling(); va != nil && (va.GetUpdateMode() == nil || *va.GetUpdateMode() != "Off") {
+		controlledResources := va.GetControlledResources()
+		if len(controlledResources) == 0 {
+			controlledResources = []corev1.ResourceName{corev1.ResourceCPU, corev1.ResourceMemory}
+		}
+		scaledResources := getHorizontallyScaledResources(ba)
+		for _, name := range controlledResources {
+			if scaledResources[name] {
+				return false, createValidationError(fmt.Sprintf("spec.verticalAutoscaling cannot control the %s resource, which is used to scale the pods horizontally. Remove %s from spec.verticalAutoscaling.controlledResources or set spec.verticalAutoscaling.updateMode to Off", name, name))
+			}
+		}
+	}
+
+	if db := ba.GetDisruptionBudget(); db != nil && db.GetMinAvailable() != nil && db.GetMaxUnavailable() != nil {
+		return false, createValidationError(conflictingFieldsMessage("spec.disruptionBudget.minAvailable", "spec.disruptionBudget.maxUnavailable"))
+	}
+
+	// Autoscaling validation
+	if as := ba.GetAutoscaling(); as != nil {
+		if as.GetMaxReplicas() == 0 {
+			return false, createValidationError(requiredFieldMessage("spec.autoscaling.maxReplicas"))
+		}
+		if as.GetMinReplicas() != nil && *as.GetMinReplicas() > as.GetMaxReplicas() {
+			return false, createValidationError(fmt.Sprintf("spec.autoscaling.maxReplicas (%d) must not be less than spec.autoscaling.minReplicas (%d)", as.GetMaxReplicas(), *as.GetMinReplicas()))
+		}
+	}
+
+	// Service ports validation
+	if svc := ba.GetService(); svc != nil {
+		if err := validateServicePorts(svc); err != nil {
+			return false, err
+		}
+		if cert := svc.GetCertificate(); cert != nil {
+			if err := validateSvcCertificate(cert); err != nil {
+				return false, err
+			}
+		}
+	}
+
+	// Route/Ingress host validation
+	if rt := ba.GetRoute(); rt != nil && rt.GetHost() != "" {
+		if errs := validateHost(rt.GetHost()); len(errs) > 0 {
+			return false, createValidationError(fmt.Sprintf("invalid spec.route.host '%v': %v", rt.GetHost(), strings.Join(errs, ", ")))
+		}
+	}
+	if rt := ba.GetRoute(); rt != nil {
+		for _, h := range rt.GetAdditionalHosts() {
+			if errs := validateHost(h); len(errs) > 0 {
+				return false, createValidationError(fmt.Sprintf("invalid host '%v' in spec.route.additionalHosts: %v", h, strings.Join(errs, ", ")))
+			}
+		}
+		if rt.GetCertificateIssuerName() != "" && rt.GetCertificateSecretRef() != nil {
+			return false, createValidationError(conflictingFieldsMessage("spec.route.certificateIssuerRef", "spec.route.certificateSecretRef"))
+		}
+	}
+
 	return true, nil
 }
 
+// validateSvcCertificate checks the DNS names and the private key of the service certificate
+func validateSvcCertificate(cert common.BaseComponentServiceCertificate) error {
+	for _, name := range cert.GetAdditionalDNSNames() {
+		if errs := validateHost(name); len(errs) > 0 {
+			return createValidationError(fmt.Sprintf("invalid DNS name '%v' in spec.service.certificate.additionalDNSNames: %v", name, strings.Join(errs, ", ")))
+		}
+	}
+	size := cert.GetPrivateKeySize()
+	if size == 0 {
+		return nil
+	}
+	algorithm := cert.GetPrivateKeyAlgorithm()
+	if algorithm == "" {
+		algorithm = "RSA"
+	}
+	validSizes := map[string][]int{"RSA": {2048, 4096, 8192}, "ECDSA": {256, 384, 521}}
+	sizes, ok := validSizes[algorithm]
+	if !ok {
+		// The size of Ed25519 keys is ignored
+		return nil
+	}
+	for _, s := range sizes {
+		if s == size {
+			return nil
+		}
+	}
+	return createValidationError(fmt.Sprintf("spec.service.certificate.privateKey.size %d is not valid for the %s algorithm", size, algorithm))
+}
+
+// validateServicePorts checks that the ports exposed by the component's service do not collide
+func validateServicePorts(svc common.BaseComponentService) error {
+	portName := func(name string, port int32) string {
+		if name != "" {
+			return name
+		}
+		return strconv.Itoa(int(port)) + "-tcp"
+	}
+
+	// The same port number can be used with different protocols
+	type protocolPort struct {
+		port     int32
+		protocol corev1.Protocol
+	}
+	protocolOf := func(p corev1.Protocol) corev1.Protocol {
+		if p == "" {
+			return corev1.ProtocolTCP
+		}
+		return p
+	}
+
+	ports := map[protocolPort]string{{svc.GetPort(), corev1.ProtocolTCP}: "spec.service.port"}
+	names := map[string]string{portName(svc.GetPortName(), svc.GetPort()): "spec.service.portName"}
+	for i, p := range svc.GetPorts() {
+		field := fmt.Sprintf("spec.service.ports[%d]", i)
+		key := protocolPort{p.Port, protocolOf(p.Protocol)}
+		if other, ok := ports[key]; ok {
+			return createValidationError(fmt.Sprintf("%v.port %d/%v collides with %v", field, p.Port, key.protocol, other))
+		}
+		ports[key] = field + ".port"
+
+		name := portName(p.Name, p.Port)
+		if other, ok := names[name]; ok {
+			return createValidationError(fmt.Sprintf("%v.name '%v' collides with %v", field, name, other))
+		}
+		names[name] = field + ".name"
+	}
+	return nil
+}
+
+// validateHost checks that the host is a valid, optionally wildcard, DNS subdomain
+func validateHost(host string) []string {
+	if strings.HasPrefix(host, "*.") {
+		return validation.IsWildcardDNS1123Subdomain(host)
+	}
+	return validation.IsDNS1123Subdomain(host)
+}
+
 func createValidationError(msg string) error {
 	return fmt.Errorf("validation failed: " + msg)
 }
@@ -938,6 +1583,10 @@ func requiredFieldMessage(fieldPaths ...string) string {
 	return "must set the field(s): " + strings.Join(fieldPaths, ", ")
 }
 
+func conflictingFieldsMessage(fieldPaths ...string) string {
+	return "the field(s) cannot be set together: " + strings.Join(fieldPaths, ", ")
+}
+
 // CustomizeServiceMonitor ...
 func CustomizeServiceMonitor(sm *prometheusv1.ServiceMonitor, ba common.BaseComponent) {
 	obj := ba.(metav1.Object)
@@ -1038,7 +1687,7 @@ func CustomizeServiceMonitor(sm *prometheusv1.ServiceMonitor, ba common.BaseComp
 }
 
 // GetCondition ...
-func GetCondition(conditionType appstacksv1beta2.StatusConditionType, status *appstacksv1beta2.RuntimeComponentStatus) *appstacksv1beta2.StatusCondition {
+func GetCondition(conditionType appstacksv1.StatusConditionType, status *appstacksv1.RuntimeComponentStatus) *appstacksv1.StatusCondition {
 	for i := range status.Conditions {
 		if status.Conditions[i].Type == conditionType {
 			return &status.Conditions[i]
@@ -1049,7 +1698,7 @@ func GetCondition(conditionType appstacksv1beta2.StatusConditionType, status *ap
 }
 
 // SetCondition ...
-func SetCondition(condition appstacksv1beta2.StatusCondition, status *appstacksv1beta2.RuntimeComponentStatus) {
+func SetCondition(condition appstacksv1.StatusCondition, status *appstacksv1.RuntimeComponentStatus) {
 	for i := range status.Conditions {
 		if status.Conditions[i].Type == condition.Type {
 			status.Conditions[i] = condition
@@ -1196,72 +1845,72 @@ func GetAppContainer(containerList []corev1.Container) *corev1.Container {
 }
 
 // CustomizeIngress customizes ingress resource
-func CustomizeIngress(ing *networkingv1.Ingress, ba common.BaseComponent) {
+func CustomizeIngress(ing *networkingv1.Ingress, ba common.BaseComponent, defaultHostname string) {
 	obj := ba.(metav1.Object)
 	ing.Labels = ba.GetLabels()
 	servicePort := strconv.Itoa(int(ba.GetService().GetPort())) + "-tcp"
-	host := ""
 	path := ""
 	pathType := networkingv1.PathType("")
 
 	rt := ba.GetRoute()
 	if rt != nil {
-		host = rt.GetHost()
 		path = rt.GetPath()
 		pathType = rt.GetPathType()
 		ing.Annotations = MergeMaps(ing.Annotations, ba.GetAnnotations(), rt.GetAnnotations())
+		ing.Spec.IngressClassName = rt.GetIngressClassName()
 	} else {
 		ing.Annotations = MergeMaps(ing.Annotations, ba.GetAnnotations())
+		ing.Spec.IngressClassName = nil
 	}
 
 	if ba.GetService().GetPortName() != "" {
 		servicePort = ba.GetService().GetPortName()
 	}
 
-	if host == "" && common.Config[common.OpConfigDefaultHostname] != "" {
-		host = obj.GetName() + "-" + obj.GetNamespace() + "." + common.Config[common.OpConfigDefaultHostname]
-	}
-	if host == "" {
+	hosts := GetIngressHosts(ba, defaultHostname)
+	if hosts[0] == "" {
 		l := log.WithValues("Request.Namespace", obj.GetNamespace(), "Request.Name", obj.GetName())
 		l.Info("No Ingress hostname is provided. Ingress might not function correctly without hostname. It is recommended to set Ingress host or to provide default value through operator's config map.")
 	}
 
-	if pathType == "" {
-		pathType = networkingv1.PathTypeImplementationSpecific
+	backend := networkingv1.IngressBackend{
+		Service: &networkingv1.IngressServiceBackend{
+			Name: obj.GetName(),
+			Port: networkingv1.ServiceBackendPort{
+				Name: servicePort,
+			},
+		},
+	}
+	newPath := func(path string, pathType networkingv1.PathType) networkingv1.HTTPIngressPath {
+		if pathType == "" {
+			pathType = networkingv1.PathTypeImplementationSpecific
+		}
+		return networkingv1.HTTPIngressPath{Path: path, PathType: &pathType, Backend: backend}
+	}
+	paths := []networkingv1.HTTPIngressPath{newPath(path, pathType)}
+	if rt != nil {
+		for _, p := range rt.GetAdditionalPaths() {
+			paths = append(paths, newPath(p.GetPath(), p.GetPathType()))
+		}
 	}
 
-	ing.Spec.Rules = []networkingv1.IngressRule{
-		{
+	ing.Spec.Rules = []networkingv1.IngressRule{}
+	for _, host := range hosts {
+		ing.Spec.Rules = append(ing.Spec.Rules, networkingv1.IngressRule{
 			Host: host,
 			IngressRuleValue: networkingv1.IngressRuleValue{
 				HTTP: &networkingv1.HTTPIngressRuleValue{
-					Paths: []networkingv1.HTTPIngressPath{
-						{
-							Path:     path,
-							PathType: &pathType,
-							Backend: networkingv1.IngressBackend{
-								Service: &networkingv1.IngressServiceBackend{
-									Name: obj.GetName(),
-									Port: networkingv1.ServiceBackendPort{
-										Name: servicePort,
-									},
-								},
-							},
-						},
-					},
+					Paths: paths,
 				},
 			},
-		},
+		})
 	}
 
-	tlsSecretName := ""
-	if rt != nil && rt.GetCertificateSecretRef() != nil && *rt.GetCertificateSecretRef() != "" {
-		tlsSecretName = *rt.GetCertificateSecretRef()
-	}
-	if tlsSecretName != "" && host != "" {
+	tlsSecretName := GetIngressCertSecretName(ba)
+	if tlsSecretName != "" && hosts[0] != "" {
 		ing.Spec.TLS = []networkingv1.IngressTLS{
 			{
-				Hosts:      []string{host},
+				Hosts:      hosts,
 				SecretName: tlsSecretName,
 			},
 		}
@@ -1270,13 +1919,222 @@ func CustomizeIngress(ing *networkingv1.Ingress, ba common.BaseComponent) {
 	}
 }
 
+// GetIngressHosts returns the hosts of the Ingress of the component. The first host is the host of the route,
+// which defaults to a subdomain of the default hostname, followed by the additional hosts.
+func GetIngressHosts(ba common.BaseComponent, defaultHostname string) []string {
+	obj := ba.(metav1.Object)
+	host := ""
+	rt := ba.GetRoute()
+	if rt != nil {
+		host = rt.GetHost()
+	}
+	if host == "" && defaultHostname != "" {
+		host = obj.GetName() + "-" + obj.GetNamespace() + "." + defaultHostname
+	}
+	hosts := []string{host}
+	if rt != nil && host != "" {
+		for _, h := range rt.GetAdditionalHosts() {
+			if h != "" && h != host {
+				hosts = append(hosts, h)
+			}
+		}
+	}
+	return hosts
+}
+
+// GetSvcCertIssuerRef returns the cert-manager issuer that signs the service certificate of the component, which is
+// the issuer of spec.service.certificate, then the issuer of the operator configuration. Otherwise, it returns the CA
+// issuer that the operator creates in the namespace, and true.
+func GetSvcCertIssuerRef(ba common.BaseComponent, prefix string, config *common.OperatorConfig) (certmanagermetav1.ObjectReference, bool) {
+	if ba.GetService() != nil && ba.GetService().GetCertificate() != nil && ba.GetService().GetCertificate().GetIssuerName() != "" {
+		cert := ba.GetService().GetCertificate()
+		return certmanagermetav1.ObjectReference{Name: cert.GetIssuerName(), Kind: cert.GetIssuerKind(), Group: certmanagerv1.SchemeGroupVersion.Group}, false
+	}
+	if config.CertManagerIssuerName != "" {
+		return certmanagermetav1.ObjectReference{Name: config.CertManagerIssuerName, Kind: config.CertManagerIssuerKind, Group: certmanagerv1.SchemeGroupVersion.Group}, false
+	}
+	return certmanagermetav1.ObjectReference{Name: prefix + "-ca-issuer"}, true
+}
+
+// CustomizeSvcCertificate configures the cert-manager Certificate of the <name>-svc-tls-cm secret, which is issued
+// for the DNS names of the Service of the component
+func CustomizeSvcCertificate(svcCert *certmanagerv1.Certificate, ba common.BaseComponent, issuerRef certmanagermetav1.ObjectReference, config *common.OperatorConfig) {
+	obj := ba.(metav1.Object)
+	svcCert.Labels = ba.GetLabels()
+
+	svcCert.Spec.CommonName = obj.GetName() + "." + obj.GetNamespace() + ".svc"
+	svcCert.Spec.DNSNames = []string{
+		obj.GetName() + "." + obj.GetNamespace() + ".svc",
+		obj.GetName() + "." + obj.GetNamespace() + ".svc.cluster.local",
+	}
+	svcCert.Spec.IsCA = false
+	svcCert.Spec.IssuerRef = issuerRef
+	svcCert.Spec.SecretName = obj.GetName() + "-svc-tls-cm"
+	svcCert.Spec.Duration = &metav1.Duration{Duration: config.CertManagerCertDuration}
+	svcCert.Spec.RenewBefore = nil
+	svcCert.Spec.PrivateKey = nil
+
+	if ba.GetService() == nil || ba.GetService().GetCertificate() == nil {
+		return
+	}
+	cert := ba.GetService().GetCertificate()
+	for _, name := range cert.GetAdditionalDNSNames() {
+		if !ContainsString(svcCert.Spec.DNSNames, name) {
+			svcCert.Spec.DNSNames = append(svcCert.Spec.DNSNames, name)
+		}
+	}
+	svcCert.Spec.RenewBefore = cert.GetRenewBefore()
+	if cert.GetPrivateKeyAlgorithm() != "" || cert.GetPrivateKeySize() != 0 {
+		// The existing key is not reused, as it might not match the new algorithm or size
+		svcCert.Spec.PrivateKey = &certmanagerv1.CertificatePrivateKey{
+			Algorithm:      certmanagerv1.PrivateKeyAlgorithm(cert.GetPrivateKeyAlgorithm()),
+			Size:           cert.GetPrivateKeySize(),
+			RotationPolicy: certmanagerv1.RotationPolicyAlways,
+		}
+	}
+}
+
+// CustomizeIngressCertificate configures the cert-manager Certificate of the hosts of the Ingress of the component
+func CustomizeIngressCertificate(cert *certmanagerv1.Certificate, ba common.BaseComponent, defaultHostname string) {
+	obj := ba.(metav1.Object)
+	rt := ba.GetRoute()
+	cert.Labels = ba.GetLabels()
+	cert.Spec.SecretName = obj.GetName() + "-ingress-tls"
+	cert.Spec.DNSNames = GetIngressHosts(ba, defaultHostname)
+	cert.Spec.IssuerRef = certmanagermetav1.ObjectReference{
+		Name:  rt.GetCertificateIssuerName(),
+		Kind:  rt.GetCertificateIssuerKind(),
+		Group: certmanagerv1.SchemeGroupVersion.Group,
+	}
+}
+
+// HTTPRouteGVK is the kind of the Gateway API HTTPRoutes, which are handled as unstructured objects
+var HTTPRouteGVK = schema.GroupVersionKind{Group: "gateway.networking.k8s.io", Version: "v1", Kind: "HTTPRoute"}
+
+// GatewayGVK is the kind of the Gateway API Gateways that HTTPRoutes attach to
+var GatewayGVK = schema.GroupVersionKind{Group: "gateway.networking.k8s.io", Version: "v1", Kind: "Gateway"}
+
+// CustomizeHTTPRoute configures a Gateway API HTTPRoute that sends the requests of its hostnames and path to the Service of the component
+func CustomizeHTTPRoute(route *unstructured.Unstructured, ba common.BaseComponent, defaultHostname string) {
+	obj := ba.(metav1.Object)
+	gw := ba.GetGateway()
+	route.SetLabels(ba.GetLabels())
+	route.SetAnnotations(MergeMaps(route.GetAnnotations(), ba.GetAnnotations(), gw.GetAnnotations()))
+
+	parentRefs := []interface{}{}
+	for _, p := range gw.GetParentRefs() {
+		parentRef := map[string]interface{}{
+			"group": GatewayGVK.Group,
+			"kind":  GatewayGVK.Kind,
+			"name":  p.GetName(),
+		}
+		if p.GetNamespace() != "" {
+			parentRef["namespace"] = p.GetNamespace()
+		}
+		if p.GetSectionName() != "" {
+			parentRef["sectionName"] = p.GetSectionName()
+		}
+		parentRefs = append(parentRefs, parentRef)
+	}
+
+	hostnames := []interface{}{}
+	for _, h := range gw.GetHostnames() {
+		hostnames = append(hostnames, h)
+	}
+	if len(hostnames) == 0 && defaultHostname != "" {
+		hostnames = append(hostnames, obj.GetName()+"-"+obj.GetNamespace()+"."+defaultHostname)
+	}
+
+	path, pathMatchType := gw.GetPath(), gw.GetPathMatchType()
+	if path == "" {
+		path = "/"
+	}
+	if pathMatchType == "" {
+		pathMatchType = "PathPrefix"
+	}
+
+	spec := map[string]interface{}{
+		"parentRefs": parentRefs,
+		"rules": []interface{}{
+			map[string]interface{}{
+				"matches": []interface{}{
+					map[string]interface{}{
+						"path": map[string]interface{}{
+							"type":  pathMatchType,
+							"value": path,
+						},
+					},
+				},
+				"backendRefs": []interface{}{
+					map[string]interface{}{
+						"name": obj.GetName(),
+						"port": int64(ba.GetService().GetPort()),
+					},
+				},
+			},
+		},
+	}
+	if len(hostnames) > 0 {
+		spec["hostnames"] = hostnames
+	}
+	route.Object["spec"] = spec
+}
+
+// GetGatewayListenerInfo returns the hostname of the listeners of a Gateway that an HTTPRoute attaches to through the
+// section name, and whether one of them terminates TLS. The hostname defaults to the first address of the Gateway.
+func GetGatewayListenerInfo(gateway *unstructured.Unstructured, sectionName string) (hostname string, tls bool) {
+	listeners, _, _ := unstructured.NestedSlice(gateway.Object, "spec", "listeners")
+	for _, l := range listeners {
+		listener, ok := l.(map[string]interface{})
+		if !ok || (sectionName != "" && listener["name"] != sectionName) {
+			continue
+		}
+		if h, ok := listener["hostname"].(string); ok && hostname == "" && !strings.HasPrefix(h, "*") {
+			hostname = h
+		}
+		if listener["protocol"] == "HTTPS" {
+			tls = true
+		}
+	}
+	if hostname == "" {
+		addresses, _, _ := unstructured.NestedSlice(gateway.Object, "status", "addresses")
+		if len(addresses) > 0 {
+			if address, ok := addresses[0].(map[string]interface{}); ok {
+				hostname, _ = address["value"].(string)
+			}
+		}
+	}
+	return hostname, tls
+}
+
 // ExecuteCommandInContainer Execute command inside a container in a pod through API
 func ExecuteCommandInContainer(config *rest.Config, podName, podNamespace, containerName string, command []string) (string, error) {
+	output, err := RunCommandInContainer(context.Background(), config, podName, podNamespace, containerName, command)
+	if err != nil {
+		return "", err
+	}
+	if output.ExitCode != 0 {
+		return output.Stderr, fmt.Errorf("Encountered error while running command: %v ; Stderr: %v ; Error: command terminated with exit code %d", command, output.Stderr, output.ExitCode)
+	}
+	return output.Stderr, nil
+}
+
+// CommandOutput holds the standard output, standard error and exit code of a command run inside a container
+type CommandOutput struct {
+	Stdout   string
+	Stderr   string
+	ExitCode int
+}
+
+// RunCommandInContainer runs command inside a container in a pod through API. A command that runs but exits
+// with a non-zero code is not an error: the code is returned in CommandOutput.ExitCode. When ctx is done, the
+// connection to the container is closed and the error wraps ctx.Err().
+func RunCommandInContainer(ctx context.Context, config *rest.Config, podName, podNamespace, containerName string, command []string) (*CommandOutput, error) {
 
 	clientset, err := kubernetes.NewForConfig(config)
 	if err != nil {
 		log.Error(err, "Failed to create Clientset")
-		return "", fmt.Errorf("Failed to create Clientset: %v", err.Error())
+		return nil, fmt.Errorf("Failed to create Clientset: %v", err.Error())
 	}
 
 	req := clientset.CoreV1().RESTClient().Post().
@@ -1293,9 +2151,13 @@ func ExecuteCommandInContainer(config *rest.Config, podName, podNamespace, conta
 		TTY:       false,
 	}, scheme.ParameterCodec)
 
-	exec, err := remotecommand.NewSPDYExecutor(config, "POST", req.URL())
+	transport, upgrader, err := spdy.RoundTripperFor(config)
+	if err != nil {
+		return nil, fmt.Errorf("Encountered error while creating Executor: %v", err.Error())
+	}
+	exec, err := remotecommand.NewSPDYExecutorForTransports(transport, &contextUpgrader{Upgrader: upgrader, ctx: ctx}, "POST", req.URL())
 	if err != nil {
-		return "", fmt.Errorf("Encountered error while creating Executor: %v", err.Error())
+		return nil, fmt.Errorf("Encountered error while creating Executor: %v", err.Error())
 	}
 
 	var stdout, stderr bytes.Buffer
@@ -1305,11 +2167,53 @@ func ExecuteCommandInContainer(config *rest.Config, podName, podNamespace, conta
 		Tty:    false,
 	})
 
+	output := &CommandOutput{Stdout: stdout.String(), Stderr: stderr.String()}
+	if ctx.Err() != nil {
+		return output, fmt.Errorf("Stopped running command: %v ; Error: %w", command, ctx.Err())
+	}
 	if err != nil {
-		return stderr.String(), fmt.Errorf("Encountered error while running command: %v ; Stderr: %v ; Error: %v", command, stderr.String(), err.Error())
+		var exitErr utilexec.ExitError
+		if errors.As(err, &exitErr) && exitErr.Exited() {
+			output.ExitCode = exitErr.ExitStatus()
+			return output, nil
+		}
+		return output, fmt.Errorf("Encountered error while running command: %v ; Stderr: %v ; Error: %v", command, stderr.String(), err.Error())
 	}
 
-	return stderr.String(), nil
+	return output, nil
+}
+
+// contextUpgrader closes the upgraded connection of a command when its context is done
+type contextUpgrader struct {
+	spdy.Upgrader
+	ctx context.Context
+}
+
+func (u *contextUpgrader) NewConnection(resp *http.Response) (httpstream.Connection, error) {
+	conn, err := u.Upgrader.NewConnection(resp)
+	if err != nil {
+		return nil, err
+	}
+	go func() {
+		select {
+		case <-u.ctx.Done():
+			conn.Close()
+		case <-conn.CloseChan():
+		}
+	}()
+	return conn, nil
+}
+
+// TailOutput returns at most the last limit bytes of output, without splitting a UTF-8 character
+func TailOutput(output string, limit int) string {
+	if len(output) <= limit {
+		return output
+	}
+	start := len(output) - limit
+	for start < len(output) && !utf8.RuneStart(output[start]) {
+		start++
+	}
+	return output[start:]
 }
 
 // GetWatchNamespace returns the Namespace the operator should be watching for changes
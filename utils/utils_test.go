@@ -5,15 +5,22 @@ import (
 	"reflect"
 	"strconv"
 	"testing"
+	"time"
 
-	appstacksv1beta2 "github.com/application-stacks/runtime-component-operator/api/v1beta2"
+	appstacksv1 "github.com/application-stacks/runtime-component-operator/api/v1"
+	"github.com/application-stacks/runtime-component-operator/common"
+	certmanagerv1 "github.com/jetstack/cert-manager/pkg/apis/certmanager/v1"
 	routev1 "github.com/openshift/api/route/v1"
 	prometheusv1 "github.com/prometheus-operator/prometheus-operator/pkg/apis/monitoring/v1"
 	appsv1 "k8s.io/api/apps/v1"
 	autoscalingv1 "k8s.io/api/autoscaling/v1"
+	autoscalingv2beta2 "k8s.io/api/autoscaling/v2beta2"
 	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	policyv1 "k8s.io/api/policy/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	cruntime "k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/util/intstr"
 	servingv1 "knative.dev/serving/pkg/apis/serving/v1"
@@ -33,7 +40,7 @@ var (
 	targetCPUPer int32 = 30
 	targetPort   int32 = 3333
 	nodePort     int32 = 3011
-	autoscaling        = &appstacksv1beta2.RuntimeComponentAutoScaling{
+	autoscaling        = &appstacksv1.RuntimeComponentAutoScaling{
 		TargetCPUUtilizationPercentage: &targetCPUPer,
 		MinReplicas:                    &replicas,
 		MaxReplicas:                    3,
@@ -44,15 +51,15 @@ var (
 	pullSecret         = "mysecret"
 	serviceAccountName = "service-account"
 	serviceType        = corev1.ServiceTypeClusterIP
-	service            = &appstacksv1beta2.RuntimeComponentService{Type: &serviceType, Port: 8443}
+	service            = &appstacksv1.RuntimeComponentService{Type: &serviceType, Port: 8443}
 	deploymentAnnos    = map[string]string{"depAnno": "depAnno"}
-	deployment         = &appstacksv1beta2.RuntimeComponentDeployment{Annotations: deploymentAnnos}
+	deployment         = &appstacksv1.RuntimeComponentDeployment{Annotations: deploymentAnnos}
 	ssAnnos            = map[string]string{"setAnno": "setAnno"}
-	statefulSet        = &appstacksv1beta2.RuntimeComponentStatefulSet{Annotations: ssAnnos}
+	statefulSet        = &appstacksv1.RuntimeComponentStatefulSet{Annotations: ssAnnos}
 	volumeCT           = &corev1.PersistentVolumeClaim{
 		ObjectMeta: metav1.ObjectMeta{Name: "pvc", Namespace: namespace},
 		TypeMeta:   metav1.TypeMeta{Kind: "StatefulSet"}}
-	storage        = appstacksv1beta2.RuntimeComponentStorage{Size: "10Mi", MountPath: "/mnt/data", VolumeClaimTemplate: volumeCT}
+	storage        = appstacksv1.RuntimeComponentStorage{Size: "10Mi", MountPath: "/mnt/data", VolumeClaimTemplate: volumeCT}
 	arch           = []string{"ppc64le"}
 	readinessProbe = &corev1.Probe{
 		Handler: corev1.Handler{
@@ -72,7 +79,7 @@ var (
 			TCPSocket: &corev1.TCPSocketAction{},
 		},
 	}
-	probes = &appstacksv1beta2.RuntimeComponentProbes{
+	probes = &appstacksv1.RuntimeComponentProbes{
 		Readiness: readinessProbe,
 		Liveness:  livenessProbe,
 		Startup:   startupProbe}
@@ -111,10 +118,10 @@ type Test struct {
 func TestCustomizeRoute(t *testing.T) {
 	logger := zap.New()
 	logf.SetLogger(logger)
-	spec := appstacksv1beta2.RuntimeComponentSpec{Service: service}
+	spec := appstacksv1.RuntimeComponentSpec{Service: service}
 	route, runtime := &routev1.Route{}, createRuntimeComponent(name, namespace, spec)
 
-	CustomizeRoute(route, runtime, "", "", "", "")
+	CustomizeRoute(route, runtime, "", "", "", "", "")
 
 	//TestGetLabels
 	testCR := []Test{
@@ -128,11 +135,145 @@ func TestCustomizeRoute(t *testing.T) {
 	verifyTests(testCR, t)
 }
 
+func TestCustomizeHTTPRoute(t *testing.T) {
+	logger := zap.New()
+	logf.SetLogger(logger)
+
+	gateway := &appstacksv1.RuntimeComponentGateway{
+		ParentRefs: []appstacksv1.RuntimeComponentGatewayParentRef{{Name: "public", Namespace: "gateways", SectionName: "https"}},
+		Path:       "/api",
+	}
+	spec := appstacksv1.RuntimeComponentSpec{Service: service, Gateway: gateway}
+	route := &unstructured.Unstructured{Object: map[string]interface{}{}}
+	CustomizeHTTPRoute(route, createRuntimeComponent(name, namespace, spec), "apps.example.com")
+
+	parentRefs, _, _ := unstructured.NestedSlice(route.Object, "spec", "parentRefs")
+	parentRef := parentRefs[0].(map[string]interface{})
+	hostnames, _, _ := unstructured.NestedStringSlice(route.Object, "spec", "hostnames")
+	rules, _, _ := unstructured.NestedSlice(route.Object, "spec", "rules")
+	rule := rules[0].(map[string]interface{})
+	matches, _, _ := unstructured.NestedSlice(rule, "matches")
+	pathType, _, _ := unstructured.NestedString(matches[0].(map[string]interface{}), "path", "type")
+	backendRefs, _, _ := unstructured.NestedSlice(rule, "backendRefs")
+	backendRef := backendRefs[0].(map[string]interface{})
+
+	gw := &unstructured.Unstructured{Object: map[string]interface{}{
+		"spec": map[string]interface{}{"listeners": []interface{}{
+			map[string]interface{}{"name": "http", "protocol": "HTTP", "hostname": "*.example.com"},
+			map[string]interface{}{"name": "https", "protocol": "HTTPS", "hostname": "www.example.com"},
+		}},
+		"status": map[string]interface{}{"addresses": []interface{}{map[string]interface{}{"value": "10.0.0.1"}}},
+	}}
+	httpsHost, httpsTLS := GetGatewayListenerInfo(gw, "https")
+	httpHost, httpTLS := GetGatewayListenerInfo(gw, "http")
+
+	testCHR := []Test{
+		{"Parent Gateway", "public", parentRef["name"]},
+		{"Parent Gateway namespace", "gateways", parentRef["namespace"]},
+		{"Parent listener", "https", parentRef["sectionName"]},
+		{"Default hostname", []string{name + "-" + namespace + ".apps.example.com"}, hostnames},
+		{"Path type", "PathPrefix", pathType},
+		{"Backend Service", name, backendRef["name"]},
+		{"Backend port", int64(service.Port), backendRef["port"]},
+		{"HTTPS listener hostname", "www.example.com", httpsHost},
+		{"HTTPS listener terminates TLS", true, httpsTLS},
+		{"Wildcard listener defaults to the Gateway address", "10.0.0.1", httpHost},
+		{"HTTP listener does not terminate TLS", false, httpTLS},
+	}
+	verifyTests(testCHR, t)
+}
+
+func TestCustomizeIngress(t *testing.T) {
+	logger := zap.New()
+	logf.SetLogger(logger)
+
+	ingressClassName := "nginx"
+	route := &appstacksv1.RuntimeComponentRoute{
+		Host:             "my-app.example.com",
+		Path:             "/api",
+		IngressClassName: &ingressClassName,
+		AdditionalHosts:  []string{"www.example.com"},
+		AdditionalPaths:  []appstacksv1.RuntimeComponentIngressPath{{Path: "/health", PathType: networkingv1.PathTypeExact}},
+		CertificateIssuerRef: &appstacksv1.RuntimeComponentIssuerRef{
+			Name: "letsencrypt",
+		},
+	}
+	spec := appstacksv1.RuntimeComponentSpec{Service: service, Route: route}
+	runtime := createRuntimeComponent(name, namespace, spec)
+	ing, cert := &networkingv1.Ingress{}, &certmanagerv1.Certificate{}
+	CustomizeIngress(ing, runtime, "")
+	CustomizeIngressCertificate(cert, runtime, "")
+
+	// The ingress class name is cleared when it is removed from the route, or when the route is removed
+	noClassIng, noRouteIng := ing.DeepCopy(), ing.DeepCopy()
+	CustomizeIngress(noClassIng, createRuntimeComponent(name, namespace, appstacksv1.RuntimeComponentSpec{
+		Service: service, Route: &appstacksv1.RuntimeComponentRoute{Host: "my-app.example.com"}}), "")
+	CustomizeIngress(noRouteIng, createRuntimeComponent(name, namespace, appstacksv1.RuntimeComponentSpec{Service: service}), "apps.example.com")
+
+	hosts := []string{"my-app.example.com", "www.example.com"}
+	testCI := []Test{
+		{"Ingress class", ingressClassName, *ing.Spec.IngressClassName},
+		{"Removed ingress class", (*string)(nil), noClassIng.Spec.IngressClassName},
+		{"Ingress class without route", (*string)(nil), noRouteIng.Spec.IngressClassName},
+		{"Ingress rules", 2, len(ing.Spec.Rules)},
+		{"Additional host rule", "www.example.com", ing.Spec.Rules[1].Host},
+		{"Additional path", "/health", ing.Spec.Rules[1].HTTP.Paths[1].Path},
+		{"Additional path type", networkingv1.PathTypeExact, *ing.Spec.Rules[1].HTTP.Paths[1].PathType},
+		{"TLS hosts", hosts, ing.Spec.TLS[0].Hosts},
+		{"TLS secret", name + "-ingress-tls", ing.Spec.TLS[0].SecretName},
+		{"Certificate secret", name + "-ingress-tls", cert.Spec.SecretName},
+		{"Certificate DNS names", hosts, cert.Spec.DNSNames},
+		{"Certificate issuer", "letsencrypt", cert.Spec.IssuerRef.Name},
+		{"Certificate issuer kind", "ClusterIssuer", cert.Spec.IssuerRef.Kind},
+	}
+	verifyTests(testCI, t)
+}
+
+func TestCustomizeSvcCertificate(t *testing.T) {
+	logger := zap.New()
+	logf.SetLogger(logger)
+
+	config, _ := ParseOperatorConfig(map[string]string{common.OpConfigCMIssuerName: "cluster-ca"})
+	defaultConfig, _ := ParseOperatorConfig(nil)
+	svcCertificate := &appstacksv1.RuntimeComponentServiceCertificate{
+		IssuerRef:          &appstacksv1.RuntimeComponentIssuerRef{Name: "team-ca", Kind: "Issuer"},
+		AdditionalDNSNames: []string{"my-app.example.com"},
+		PrivateKey:         &appstacksv1.RuntimeComponentPrivateKey{Algorithm: "ECDSA", Size: 384},
+		RenewBefore:        &metav1.Duration{Duration: 240 * time.Hour},
+	}
+	runtime := createRuntimeComponent(name, namespace, appstacksv1.RuntimeComponentSpec{Service: service})
+	certRuntime := createRuntimeComponent(name, namespace, appstacksv1.RuntimeComponentSpec{
+		Service: &appstacksv1.RuntimeComponentService{Port: 8443, Certificate: svcCertificate}})
+
+	selfSignedRef, selfSigned := GetSvcCertIssuerRef(runtime, "rco", defaultConfig)
+	configRef, configSelfSigned := GetSvcCertIssuerRef(runtime, "rco", config)
+	specRef, _ := GetSvcCertIssuerRef(certRuntime, "rco", config)
+
+	cert := &certmanagerv1.Certificate{}
+	CustomizeSvcCertificate(cert, certRuntime, specRef, config)
+
+	testCSC := []Test{
+		{"Self-signed fallback", true, selfSigned},
+		{"Self-signed CA issuer", "rco-ca-issuer", selfSignedRef.Name},
+		{"Issuer of the operator configuration", false, configSelfSigned},
+		{"Issuer name of the operator configuration", "cluster-ca", configRef.Name},
+		{"Issuer kind of the operator configuration", "ClusterIssuer", configRef.Kind},
+		{"Issuer of the spec", "team-ca", specRef.Name},
+		{"Issuer kind of the spec", "Issuer", specRef.Kind},
+		{"Secret name", name + "-svc-tls-cm", cert.Spec.SecretName},
+		{"DNS names", []string{name + "." + namespace + ".svc", name + "." + namespace + ".svc.cluster.local", "my-app.example.com"}, cert.Spec.DNSNames},
+		{"Private key algorithm", certmanagerv1.ECDSAKeyAlgorithm, cert.Spec.PrivateKey.Algorithm},
+		{"Private key size", 384, cert.Spec.PrivateKey.Size},
+		{"Renew before", 240 * time.Hour, cert.Spec.RenewBefore.Duration},
+	}
+	verifyTests(testCSC, t)
+}
+
 func TestCustomizeService(t *testing.T) {
 	logger := zap.New()
 	logf.SetLogger(logger)
 
-	spec := appstacksv1beta2.RuntimeComponentSpec{Service: service}
+	spec := appstacksv1.RuntimeComponentSpec{Service: service}
 	svc, runtime := &corev1.Service{}, createRuntimeComponent(name, namespace, spec)
 
 	CustomizeService(svc, runtime)
@@ -153,7 +294,7 @@ func TestCustomizeService(t *testing.T) {
 }
 
 func optionalTargetPortFunctionalityTests() []Test {
-	spec := appstacksv1beta2.RuntimeComponentSpec{Service: service}
+	spec := appstacksv1.RuntimeComponentSpec{Service: service}
 	spec.Service.TargetPort = &targetPort
 	svc, runtime := &corev1.Service{}, createRuntimeComponent(name, namespace, spec)
 
@@ -170,8 +311,8 @@ func optionalTargetPortFunctionalityTests() []Test {
 
 func optionalNodePortFunctionalityTests() []Test {
 	serviceType := corev1.ServiceTypeNodePort
-	service := &appstacksv1beta2.RuntimeComponentService{Type: &serviceType, Port: 8443, NodePort: &nodePort}
-	spec := appstacksv1beta2.RuntimeComponentSpec{Service: service}
+	service := &appstacksv1.RuntimeComponentService{Type: &serviceType, Port: 8443, NodePort: &nodePort}
+	spec := appstacksv1.RuntimeComponentSpec{Service: service}
 	svc, runtime := &corev1.Service{}, createRuntimeComponent(name, namespace, spec)
 
 	CustomizeService(svc, runtime)
@@ -220,14 +361,14 @@ func partialTestCustomizeNodeAffinity(t *testing.T) {
 	labels := map[string]string{
 		"customNodeLabel": "label1, label2",
 	}
-	affinityConfig := appstacksv1beta2.RuntimeComponentAffinity{
+	affinityConfig := appstacksv1.RuntimeComponentAffinity{
 		NodeAffinity: &corev1.NodeAffinity{
 			RequiredDuringSchedulingIgnoredDuringExecution:  &rDSIDE,
 			PreferredDuringSchedulingIgnoredDuringExecution: pDSIDE,
 		},
 		NodeAffinityLabels: labels,
 	}
-	spec := appstacksv1beta2.RuntimeComponentSpec{
+	spec := appstacksv1.RuntimeComponentSpec{
 		ApplicationImage: appImage,
 		Affinity:         &affinityConfig,
 	}
@@ -269,7 +410,7 @@ func partialTestCustomizePodAffinity(t *testing.T) {
 			},
 		},
 	}
-	affinityConfig := appstacksv1beta2.RuntimeComponentAffinity{
+	affinityConfig := appstacksv1.RuntimeComponentAffinity{
 		PodAffinity: &corev1.PodAffinity{
 			RequiredDuringSchedulingIgnoredDuringExecution: rDSIDE,
 		},
@@ -277,7 +418,7 @@ func partialTestCustomizePodAffinity(t *testing.T) {
 			PreferredDuringSchedulingIgnoredDuringExecution: pDSIDE,
 		},
 	}
-	spec := appstacksv1beta2.RuntimeComponentSpec{
+	spec := appstacksv1.RuntimeComponentSpec{
 		ApplicationImage: appImage,
 		Affinity:         &affinityConfig,
 	}
@@ -300,11 +441,51 @@ func TestCustomizeAffinity(t *testing.T) {
 	partialTestCustomizePodAffinity(t)
 }
 
+func TestCustomizeTopologySpreadConstraints(t *testing.T) {
+	logger := zap.New()
+	logf.SetLogger(logger)
+
+	spec := appstacksv1.RuntimeComponentSpec{}
+	pts := &corev1.PodTemplateSpec{}
+	CustomizeTopologySpreadConstraints(pts, createRuntimeComponent(name, namespace, spec))
+	defaults := pts.Spec.TopologySpreadConstraints
+
+	spec = appstacksv1.RuntimeComponentSpec{TopologySpreadConstraints: &appstacksv1.RuntimeComponentTopologySpreadConstraints{
+		Constraints: []corev1.TopologySpreadConstraint{{MaxSkew: 2, TopologyKey: "topology.kubernetes.io/zone", WhenUnsatisfiable: corev1.DoNotSchedule}},
+	}}
+	CustomizeTopologySpreadConstraints(pts, createRuntimeComponent(name, namespace, spec))
+	overridden := pts.Spec.TopologySpreadConstraints
+
+	disable := true
+	customSelector := &metav1.LabelSelector{MatchLabels: map[string]string{"app": "other"}}
+	spec = appstacksv1.RuntimeComponentSpec{TopologySpreadConstraints: &appstacksv1.RuntimeComponentTopologySpreadConstraints{
+		DisableOperatorDefaults: &disable,
+		Constraints:             []corev1.TopologySpreadConstraint{{MaxSkew: 1, TopologyKey: "rack", LabelSelector: customSelector}},
+	}}
+	CustomizeTopologySpreadConstraints(pts, createRuntimeComponent(name, namespace, spec))
+	disabled := pts.Spec.TopologySpreadConstraints
+
+	testCTSC := []Test{
+		{"Number of default constraints", 2, len(defaults)},
+		{"Default zone constraint", "topology.kubernetes.io/zone", defaults[0].TopologyKey},
+		{"Default host constraint", "kubernetes.io/hostname", defaults[1].TopologyKey},
+		{"Default constraints are preferred", corev1.ScheduleAnyway, defaults[0].WhenUnsatisfiable},
+		{"Default selector", name, defaults[0].LabelSelector.MatchLabels["app.kubernetes.io/instance"]},
+		{"Number of constraints with an overridden default", 2, len(overridden)},
+		{"Remaining default constraint", "kubernetes.io/hostname", overridden[0].TopologyKey},
+		{"Overriding constraint", int32(2), overridden[1].MaxSkew},
+		{"Selector of a constraint without selector", name, overridden[1].LabelSelector.MatchLabels["app.kubernetes.io/instance"]},
+		{"Number of constraints with disabled defaults", 1, len(disabled)},
+		{"Custom selector", customSelector, disabled[0].LabelSelector},
+	}
+	verifyTests(testCTSC, t)
+}
+
 func TestCustomizePodSpecAnnotations(t *testing.T) {
 	logger := zap.New()
 	logf.SetLogger(logger)
 
-	spec := appstacksv1beta2.RuntimeComponentSpec{
+	spec := appstacksv1.RuntimeComponentSpec{
 		ApplicationImage: appImage,
 		Service:          service,
 		Resources:        resourceContraints,
@@ -368,7 +549,7 @@ func TestCustomizePodSpec(t *testing.T) {
 	logger := zap.New()
 	logf.SetLogger(logger)
 
-	spec := appstacksv1beta2.RuntimeComponentSpec{
+	spec := appstacksv1.RuntimeComponentSpec{
 		ApplicationImage: appImage,
 		Service:          service,
 		Resources:        resourceContraints,
@@ -385,13 +566,13 @@ func TestCustomizePodSpec(t *testing.T) {
 	noCont := len(pts.Spec.Containers)
 	noPorts := len(pts.Spec.Containers[0].Ports)
 	ptsSAN := pts.Spec.ServiceAccountName
-	affinityConfig := appstacksv1beta2.RuntimeComponentAffinity{
+	affinityConfig := appstacksv1.RuntimeComponentAffinity{
 		Architecture: arch,
 	}
 	// if cond
-	spec = appstacksv1beta2.RuntimeComponentSpec{
+	spec = appstacksv1.RuntimeComponentSpec{
 		ApplicationImage: appImage,
-		Service: &appstacksv1beta2.RuntimeComponentService{
+		Service: &appstacksv1.RuntimeComponentService{
 			Type:       &serviceType,
 			Port:       8443,
 			TargetPort: &targetPort,
@@ -437,8 +618,8 @@ func TestCustomizePersistence(t *testing.T) {
 	logger := zap.New()
 	logf.SetLogger(logger)
 
-	runtimeStatefulSet := &appstacksv1beta2.RuntimeComponentStatefulSet{Storage: &storage}
-	spec := appstacksv1beta2.RuntimeComponentSpec{StatefulSet: runtimeStatefulSet}
+	runtimeStatefulSet := &appstacksv1.RuntimeComponentStatefulSet{Storage: &storage}
+	spec := appstacksv1.RuntimeComponentSpec{StatefulSet: runtimeStatefulSet}
 	statefulSet, runtime := &appsv1.StatefulSet{}, createRuntimeComponent(name, namespace, spec)
 	statefulSet.Spec.Template.Spec.Containers = []corev1.Container{{}}
 	statefulSet.Spec.Template.Spec.Containers[0].VolumeMounts = []corev1.VolumeMount{}
@@ -448,9 +629,9 @@ func TestCustomizePersistence(t *testing.T) {
 	ssMountPath := statefulSet.Spec.Template.Spec.Containers[0].VolumeMounts[0].MountPath
 
 	//reset
-	storageNilVCT := appstacksv1beta2.RuntimeComponentStorage{Size: "10Mi", MountPath: "/mnt/data", VolumeClaimTemplate: nil}
-	runtimeStatefulSet = &appstacksv1beta2.RuntimeComponentStatefulSet{Storage: &storageNilVCT}
-	spec = appstacksv1beta2.RuntimeComponentSpec{StatefulSet: runtimeStatefulSet}
+	storageNilVCT := appstacksv1.RuntimeComponentStorage{Size: "10Mi", MountPath: "/mnt/data", VolumeClaimTemplate: nil}
+	runtimeStatefulSet = &appstacksv1.RuntimeComponentStatefulSet{Storage: &storageNilVCT}
+	spec = appstacksv1.RuntimeComponentSpec{StatefulSet: runtimeStatefulSet}
 	statefulSet, runtime = &appsv1.StatefulSet{}, createRuntimeComponent(name, namespace, spec)
 
 	statefulSet.Spec.Template.Spec.Containers = []corev1.Container{{}}
@@ -472,13 +653,13 @@ func TestCustomizeServiceAccount(t *testing.T) {
 	logger := zap.New()
 	logf.SetLogger(logger)
 
-	spec := appstacksv1beta2.RuntimeComponentSpec{PullSecret: &pullSecret}
+	spec := appstacksv1.RuntimeComponentSpec{PullSecret: &pullSecret}
 	sa, runtime := &corev1.ServiceAccount{}, createRuntimeComponent(name, namespace, spec)
 	CustomizeServiceAccount(sa, runtime, fcl)
 	emptySAIPS := sa.ImagePullSecrets[0].Name
 
 	newSecret := "my-new-secret"
-	spec = appstacksv1beta2.RuntimeComponentSpec{PullSecret: &newSecret}
+	spec = appstacksv1.RuntimeComponentSpec{PullSecret: &newSecret}
 	runtime = createRuntimeComponent(name, namespace, spec)
 	CustomizeServiceAccount(sa, runtime, fcl)
 
@@ -493,7 +674,7 @@ func TestCustomizeKnativeService(t *testing.T) {
 	logger := zap.New()
 	logf.SetLogger(logger)
 
-	spec := appstacksv1beta2.RuntimeComponentSpec{
+	spec := appstacksv1.RuntimeComponentSpec{
 		ApplicationImage: appImage,
 		Service:          service,
 		Probes:           probes,
@@ -503,10 +684,15 @@ func TestCustomizeKnativeService(t *testing.T) {
 		Volumes:          []corev1.Volume{volume},
 	}
 	ksvc, runtime := &servingv1.Service{}, createRuntimeComponent(name, namespace, spec)
+	initContainers := []corev1.Container{{Name: "init", Image: "busybox"}}
+	runtime.Spec.InitContainers = initContainers
+	runtime.Spec.SidecarContainers = []corev1.Container{{Name: "proxy", Image: "envoy"}}
 
 	CustomizeKnativeService(ksvc, runtime)
 	ksvcNumPorts := len(ksvc.Spec.Template.Spec.Containers[0].Ports)
 	ksvcSAN := ksvc.Spec.Template.Spec.ServiceAccountName
+	ksvcInitContainers := ksvc.Spec.Template.Spec.InitContainers
+	ksvcContainers := len(ksvc.Spec.Template.Spec.Containers)
 
 	ksvcLPPort := ksvc.Spec.Template.Spec.Containers[0].LivenessProbe.HTTPGet.Port
 	ksvcLPTCP := ksvc.Spec.Template.Spec.Containers[0].LivenessProbe.TCPSocket.Port
@@ -516,7 +702,7 @@ func TestCustomizeKnativeService(t *testing.T) {
 	ksvcSPTCP := ksvc.Spec.Template.Spec.Containers[0].StartupProbe.TCPSocket.Port
 	ksvcLabelNoExpose := ksvc.Labels["serving.knative.dev/visibility"]
 
-	spec = appstacksv1beta2.RuntimeComponentSpec{
+	spec = appstacksv1.RuntimeComponentSpec{
 		ApplicationImage:   appImage,
 		Service:            service,
 		PullPolicy:         &pullPolicy,
@@ -539,6 +725,9 @@ func TestCustomizeKnativeService(t *testing.T) {
 	testCKS := []Test{
 		{"ksvc container ports", 1, ksvcNumPorts},
 		{"ksvc ServiceAccountName is nil", name, ksvcSAN},
+		{"ksvc init containers", initContainers, ksvcInitContainers},
+		{"ksvc sidecar containers", 2, ksvcContainers},
+		{"ksvc init containers removed", ([]corev1.Container)(nil), ksvc.Spec.Template.Spec.InitContainers},
 		{"ksvc ServiceAccountName not nil", *runtime.Spec.ServiceAccountName, ksvc.Spec.Template.Spec.ServiceAccountName},
 		{"liveness probe port", intstr.IntOrString{}, ksvcLPPort},
 		{"liveness probe TCP socket port", intstr.IntOrString{}, ksvcLPTCP},
@@ -553,17 +742,58 @@ func TestCustomizeKnativeService(t *testing.T) {
 	verifyTests(testCKS, t)
 }
 
+func TestCustomizeKnativeServiceRevisions(t *testing.T) {
+	logger := zap.New()
+	logf.SetLogger(logger)
+
+	var minScale, targetUtilization int32 = 0, 70
+	var concurrency, stablePercent, latestPercent int64 = 10, 90, 10
+	knative := &appstacksv1.RuntimeComponentKnative{
+		MinScale:                      &minScale,
+		ContainerConcurrency:          &concurrency,
+		TargetUtilizationPercentage:   &targetUtilization,
+		ScaleToZeroPodRetentionPeriod: &metav1.Duration{Duration: 5 * time.Minute},
+		RevisionName:                  "v2",
+		Traffic: []appstacksv1.RuntimeComponentKnativeTraffic{
+			{RevisionName: "v1", Percent: &stablePercent, Tag: "stable"},
+			{Percent: &latestPercent, Tag: "latest"},
+		},
+	}
+	resources := &corev1.ResourceRequirements{Limits: corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("512Mi")}}
+	spec := appstacksv1.RuntimeComponentSpec{Service: service, PullPolicy: &pullPolicy, CreateKnativeService: &createKNS, Autoscaling: autoscaling,
+		Resources: resources, Knative: knative}
+	ksvc := &servingv1.Service{}
+	CustomizeKnativeService(ksvc, createRuntimeComponent(name, namespace, spec))
+
+	annotations := ksvc.Spec.Template.Annotations
+	testCKSR := []Test{
+		{"Min scale", "0", annotations["autoscaling.knative.dev/min-scale"]},
+		{"Max scale from autoscaling", strconv.Itoa(int(autoscaling.MaxReplicas)), annotations["autoscaling.knative.dev/max-scale"]},
+		{"Target utilization", "70", annotations["autoscaling.knative.dev/target-utilization-percentage"]},
+		{"Scale to zero retention", "5m0s", annotations["autoscaling.knative.dev/scale-to-zero-pod-retention-period"]},
+		{"Container concurrency", concurrency, *ksvc.Spec.Template.Spec.ContainerConcurrency},
+		{"Resources", *resources, ksvc.Spec.Template.Spec.Containers[0].Resources},
+		{"Privileged is not set", true, ksvc.Spec.Template.Spec.Containers[0].SecurityContext.Privileged == nil},
+		{"Revision name", name + "-v2", ksvc.Spec.Template.Name},
+		{"Stable revision", name + "-v1", ksvc.Spec.Traffic[0].RevisionName},
+		{"Stable tag", "stable", ksvc.Spec.Traffic[0].Tag},
+		{"Latest revision", true, *ksvc.Spec.Traffic[1].LatestRevision},
+		{"Latest percent", latestPercent, *ksvc.Spec.Traffic[1].Percent},
+	}
+	verifyTests(testCKSR, t)
+}
+
 func TestCustomizeHPA(t *testing.T) {
 	logger := zap.New()
 	logf.SetLogger(logger)
 
-	spec := appstacksv1beta2.RuntimeComponentSpec{Autoscaling: autoscaling}
+	spec := appstacksv1.RuntimeComponentSpec{Autoscaling: autoscaling}
 	hpa, runtime := &autoscalingv1.HorizontalPodAutoscaler{}, createRuntimeComponent(name, namespace, spec)
 	CustomizeHPA(hpa, runtime)
 	nilSTRKind := hpa.Spec.ScaleTargetRef.Kind
 
-	runtimeStatefulSet := &appstacksv1beta2.RuntimeComponentStatefulSet{Storage: &storage}
-	spec = appstacksv1beta2.RuntimeComponentSpec{Autoscaling: autoscaling, StatefulSet: runtimeStatefulSet}
+	runtimeStatefulSet := &appstacksv1.RuntimeComponentStatefulSet{Storage: &storage}
+	spec = appstacksv1.RuntimeComponentSpec{Autoscaling: autoscaling, StatefulSet: runtimeStatefulSet}
 	runtime = createRuntimeComponent(name, namespace, spec)
 	CustomizeHPA(hpa, runtime)
 	STRKind := hpa.Spec.ScaleTargetRef.Kind
@@ -580,11 +810,257 @@ func TestCustomizeHPA(t *testing.T) {
 	verifyTests(testCHPA, t)
 }
 
+func TestCustomizeHPAV2(t *testing.T) {
+	logger := zap.New()
+	logf.SetLogger(logger)
+
+	memoryUtilization := int32(70)
+	stabilizationWindow := int32(300)
+	autoscalingV2 := autoscaling.DeepCopy()
+	autoscalingV2.Metrics = []autoscalingv2beta2.MetricSpec{{
+		Type: autoscalingv2beta2.ResourceMetricSourceType,
+		Resource: &autoscalingv2beta2.ResourceMetricSource{
+			Name:   corev1.ResourceMemory,
+			Target: autoscalingv2beta2.MetricTarget{Type: autoscalingv2beta2.UtilizationMetricType, AverageUtilization: &memoryUtilization},
+		},
+	}}
+	autoscalingV2.Behavior = &autoscalingv2beta2.HorizontalPodAutoscalerBehavior{
+		ScaleDown: &autoscalingv2beta2.HPAScalingRules{StabilizationWindowSeconds: &stabilizationWindow},
+	}
+	spec := appstacksv1.RuntimeComponentSpec{Autoscaling: autoscalingV2}
+	hpa, runtime := &autoscalingv2beta2.HorizontalPodAutoscaler{}, createRuntimeComponent(name, namespace, spec)
+	CustomizeHPAV2(hpa, runtime)
+
+	spec = appstacksv1.RuntimeComponentSpec{Autoscaling: &appstacksv1.RuntimeComponentAutoScaling{MaxReplicas: 3}}
+	cpuUnsetHPA := &autoscalingv2beta2.HorizontalPodAutoscaler{}
+	CustomizeHPAV2(cpuUnsetHPA, createRuntimeComponent(name, namespace, spec))
+
+	testCHPA := []Test{
+		{"Max replicas", autoscaling.MaxReplicas, hpa.Spec.MaxReplicas},
+		{"Min replicas", *autoscaling.MinReplicas, *hpa.Spec.MinReplicas},
+		{"Number of metrics", 2, len(hpa.Spec.Metrics)},
+		{"CPU metric", corev1.ResourceCPU, hpa.Spec.Metrics[0].Resource.Name},
+		{"Target CPU utilization", *autoscaling.TargetCPUUtilizationPercentage, *hpa.Spec.Metrics[0].Resource.Target.AverageUtilization},
+		{"Memory metric", corev1.ResourceMemory, hpa.Spec.Metrics[1].Resource.Name},
+		{"Behavior", stabilizationWindow, *hpa.Spec.Behavior.ScaleDown.StabilizationWindowSeconds},
+		{"Metrics without target CPU utilization", 0, len(cpuUnsetHPA.Spec.Metrics)},
+		{"", name, hpa.Spec.ScaleTargetRef.Name},
+		{"Storage not enabled", "Deployment", hpa.Spec.ScaleTargetRef.Kind},
+	}
+	verifyTests(testCHPA, t)
+}
+
+func TestCustomizeScaledObject(t *testing.T) {
+	logger := zap.New()
+	logf.SetLogger(logger)
+
+	var minReplicas, maxReplicas int32 = 0, 20
+	eda := &appstacksv1.RuntimeComponentEventDrivenAutoScaling{
+		MinReplicas: &minReplicas,
+		MaxReplicas: &maxReplicas,
+		Triggers: []appstacksv1.RuntimeComponentScaleTrigger{{
+			Type:              "kafka",
+			Metadata:          map[string]string{"topic": "orders", "lagThreshold": "50"},
+			AuthenticationRef: &appstacksv1.RuntimeComponentScaleTriggerAuthenticationRef{Name: "kafka-auth"},
+		}},
+	}
+	spec := appstacksv1.RuntimeComponentSpec{EventDrivenAutoscaling: eda, StatefulSet: statefulSet}
+	so := &unstructured.Unstructured{Object: map[string]interface{}{}}
+	CustomizeScaledObject(so, createRuntimeComponent(name, namespace, spec))
+
+	targetKind, _, _ := unstructured.NestedString(so.Object, "spec", "scaleTargetRef", "kind")
+	targetName, _, _ := unstructured.NestedString(so.Object, "spec", "scaleTargetRef", "name")
+	minReplicaCount, _, _ := unstructured.NestedInt64(so.Object, "spec", "minReplicaCount")
+	maxReplicaCount, _, _ := unstructured.NestedInt64(so.Object, "spec", "maxReplicaCount")
+	_, pollingIntervalSet, _ := unstructured.NestedInt64(so.Object, "spec", "pollingInterval")
+	triggers, _, _ := unstructured.NestedSlice(so.Object, "spec", "triggers")
+	trigger := triggers[0].(map[string]interface{})
+	topic, _, _ := unstructured.NestedString(trigger, "metadata", "topic")
+	authKind, _, _ := unstructured.NestedString(trigger, "authenticationRef", "kind")
+
+	testCSO := []Test{
+		{"Scale target kind", "StatefulSet", targetKind},
+		{"Scale target name", name, targetName},
+		{"Min replica count", int64(0), minReplicaCount},
+		{"Max replica count", int64(20), maxReplicaCount},
+		{"Polling interval not set", false, pollingIntervalSet},
+		{"Trigger type", "kafka", trigger["type"]},
+		{"Trigger metadata", "orders", topic},
+		{"Trigger authentication kind", "TriggerAuthentication", authKind},
+	}
+	verifyTests(testCSO, t)
+}
+
+func TestCustomizeVPA(t *testing.T) {
+	logger := zap.New()
+	logf.SetLogger(logger)
+
+	updateMode := "Initial"
+	va := &appstacksv1.RuntimeComponentVerticalAutoScaling{
+		UpdateMode:          &updateMode,
+		MaxAllowed:          corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("2Gi")},
+		ControlledResources: []corev1.ResourceName{corev1.ResourceMemory},
+	}
+	spec := appstacksv1.RuntimeComponentSpec{VerticalAutoscaling: va}
+	vpa := &unstructured.Unstructured{Object: map[string]interface{}{}}
+	CustomizeVPA(vpa, createRuntimeComponent(name, namespace, spec))
+
+	targetKind, _, _ := unstructured.NestedString(vpa.Object, "spec", "targetRef", "kind")
+	mode, _, _ := unstructured.NestedString(vpa.Object, "spec", "updatePolicy", "updateMode")
+	policies, _, _ := unstructured.NestedSlice(vpa.Object, "spec", "resourcePolicy", "containerPolicies")
+	appPolicy := policies[0].(map[string]interface{})
+	maxMemory, _, _ := unstructured.NestedString(appPolicy, "maxAllowed", "memory")
+	_, minAllowedSet, _ := unstructured.NestedMap(appPolicy, "minAllowed")
+	controlledResources, _, _ := unstructured.NestedStringSlice(appPolicy, "controlledResources")
+
+	testCVPA := []Test{
+		{"Target kind", "Deployment", targetKind},
+		{"Update mode", "Initial", mode},
+		{"App container policy", "app", appPolicy["containerName"]},
+		{"Max allowed memory", "2Gi", maxMemory},
+		{"Min allowed not set", false, minAllowedSet},
+		{"Controlled resources", []string{"memory"}, controlledResources},
+		{"Other containers policy", "Off", policies[1].(map[string]interface{})["mode"]},
+	}
+	verifyTests(testCVPA, t)
+}
+
+func TestCustomizePDB(t *testing.T) {
+	logger := zap.New()
+	logf.SetLogger(logger)
+
+	var replicas, oneReplica int32 = 3, 1
+	spec := appstacksv1.RuntimeComponentSpec{Replicas: &replicas}
+	pdb, runtime := &policyv1.PodDisruptionBudget{}, createRuntimeComponent(name, namespace, spec)
+	CustomizePDB(pdb, runtime)
+	defaultEnabled := IsDisruptionBudgetEnabled(runtime)
+
+	minAvailable := intstr.FromString("50%")
+	spec = appstacksv1.RuntimeComponentSpec{Replicas: &replicas, DisruptionBudget: &appstacksv1.RuntimeComponentDisruptionBudget{MinAvailable: &minAvailable}}
+	minAvailablePDB := &policyv1.PodDisruptionBudget{}
+	CustomizePDB(minAvailablePDB, createRuntimeComponent(name, namespace, spec))
+
+	spec = appstacksv1.RuntimeComponentSpec{Replicas: &oneReplica}
+	singleReplicaEnabled := IsDisruptionBudgetEnabled(createRuntimeComponent(name, namespace, spec))
+
+	spec = appstacksv1.RuntimeComponentSpec{Autoscaling: autoscaling}
+	autoscalingEnabled := IsDisruptionBudgetEnabled(createRuntimeComponent(name, namespace, spec))
+
+	disabled := false
+	spec = appstacksv1.RuntimeComponentSpec{Replicas: &replicas, DisruptionBudget: &appstacksv1.RuntimeComponentDisruptionBudget{Enabled: &disabled}}
+	explicitlyDisabled := IsDisruptionBudgetEnabled(createRuntimeComponent(name, namespace, spec))
+
+	testCPDB := []Test{
+		{"Selector", name, pdb.Spec.Selector.MatchLabels["app.kubernetes.io/instance"]},
+		{"Default max unavailable", intstr.FromInt(1), *pdb.Spec.MaxUnavailable},
+		{"Min available", minAvailable, *minAvailablePDB.Spec.MinAvailable},
+		{"Max unavailable not set with min available", (*intstr.IntOrString)(nil), minAvailablePDB.Spec.MaxUnavailable},
+		{"Enabled with several replicas", true, defaultEnabled},
+		{"Enabled with a single replica", false, singleReplicaEnabled},
+		{"Enabled with autoscaling min replicas", *autoscaling.MinReplicas > 1, autoscalingEnabled},
+		{"Explicitly disabled", false, explicitlyDisabled},
+	}
+	verifyTests(testCPDB, t)
+}
+
+func TestValidate(t *testing.T) {
+	logger := zap.New()
+	logf.SetLogger(logger)
+
+	validSpec := appstacksv1.RuntimeComponentSpec{Service: service, Autoscaling: autoscaling}
+	valid, _ := Validate(createRuntimeComponent(name, namespace, validSpec))
+
+	knsStatefulSetSpec := appstacksv1.RuntimeComponentSpec{CreateKnativeService: &createKNS, StatefulSet: statefulSet}
+	knsStatefulSet, _ := Validate(createRuntimeComponent(name, namespace, knsStatefulSetSpec))
+
+	var minReplicas int32 = 5
+	badAutoscalingSpec := appstacksv1.RuntimeComponentSpec{Autoscaling: &appstacksv1.RuntimeComponentAutoScaling{MinReplicas: &minReplicas, MaxReplicas: 3}}
+	badAutoscaling, _ := Validate(createRuntimeComponent(name, namespace, badAutoscalingSpec))
+
+	badStorageSpec := appstacksv1.RuntimeComponentSpec{StatefulSet: &appstacksv1.RuntimeComponentStatefulSet{Storage: &appstacksv1.RuntimeComponentStorage{Size: "ten"}}}
+	badStorage, _ := Validate(createRuntimeComponent(name, namespace, badStorageSpec))
+
+	portCollisionSpec := appstacksv1.RuntimeComponentSpec{Service: &appstacksv1.RuntimeComponentService{Type: &serviceType, Port: 8443,
+		Ports: []corev1.ServicePort{{Port: 9443}, {Port: 8443}}}}
+	portCollision, _ := Validate(createRuntimeComponent(name, namespace, portCollisionSpec))
+
+	udpPortSpec := appstacksv1.RuntimeComponentSpec{Service: &appstacksv1.RuntimeComponentService{Type: &serviceType, Port: 53,
+		Ports: []corev1.ServicePort{{Port: 53, Name: "dns-udp", Protocol: corev1.ProtocolUDP}}}}
+	udpPort, _ := Validate(createRuntimeComponent(name, namespace, udpPortSpec))
+
+	portNameCollisionSpec := appstacksv1.RuntimeComponentSpec{Service: &appstacksv1.RuntimeComponentService{Type: &serviceType, Port: 8443, PortName: "https",
+		Ports: []corev1.ServicePort{{Port: 9443, Name: "https"}}}}
+	portNameCollision, _ := Validate(createRuntimeComponent(name, namespace, portNameCollisionSpec))
+
+	wildcardHostSpec := appstacksv1.RuntimeComponentSpec{Route: &appstacksv1.RuntimeComponentRoute{Host: "*.apps.example.com"}}
+	wildcardHost, _ := Validate(createRuntimeComponent(name, namespace, wildcardHostSpec))
+
+	badHostSpec := appstacksv1.RuntimeComponentSpec{Route: &appstacksv1.RuntimeComponentRoute{Host: "My_App.example.com"}}
+	badHost, _ := Validate(createRuntimeComponent(name, namespace, badHostSpec))
+
+	scaleTriggers := []appstacksv1.RuntimeComponentScaleTrigger{{Type: "kafka", Metadata: map[string]string{"topic": "orders"}}}
+	hpaAndKedaSpec := appstacksv1.RuntimeComponentSpec{Autoscaling: autoscaling,
+		EventDrivenAutoscaling: &appstacksv1.RuntimeComponentEventDrivenAutoScaling{Triggers: scaleTriggers}}
+	hpaAndKeda, _ := Validate(createRuntimeComponent(name, namespace, hpaAndKedaSpec))
+
+	noTriggersSpec := appstacksv1.RuntimeComponentSpec{EventDrivenAutoscaling: &appstacksv1.RuntimeComponentEventDrivenAutoScaling{}}
+	noTriggers, _ := Validate(createRuntimeComponent(name, namespace, noTriggersSpec))
+
+	vpaAndCPUHPASpec := appstacksv1.RuntimeComponentSpec{Autoscaling: autoscaling, VerticalAutoscaling: &appstacksv1.RuntimeComponentVerticalAutoScaling{}}
+	vpaAndCPUHPA, _ := Validate(createRuntimeComponent(name, namespace, vpaAndCPUHPASpec))
+
+	defaultHPASpec := appstacksv1.RuntimeComponentSpec{Autoscaling: &appstacksv1.RuntimeComponentAutoScaling{MaxReplicas: 3},
+		VerticalAutoscaling: &appstacksv1.RuntimeComponentVerticalAutoScaling{ControlledResources: []corev1.ResourceName{corev1.ResourceCPU}}}
+	vpaAndDefaultHPA, _ := Validate(createRuntimeComponent(name, namespace, defaultHPASpec))
+
+	vpaMemoryAndCPUHPASpec := appstacksv1.RuntimeComponentSpec{Autoscaling: autoscaling,
+		VerticalAutoscaling: &appstacksv1.RuntimeComponentVerticalAutoScaling{ControlledResources: []corev1.ResourceName{corev1.ResourceMemory}}}
+	vpaMemoryAndCPUHPA, _ := Validate(createRuntimeComponent(name, namespace, vpaMemoryAndCPUHPASpec))
+
+	percent := func(p int64) *int64 { return &p }
+	knativeTraffic := []appstacksv1.RuntimeComponentKnativeTraffic{{RevisionName: "v1", Percent: percent(80)}, {Percent: percent(10), Tag: "latest"}}
+	badTrafficSpec := appstacksv1.RuntimeComponentSpec{CreateKnativeService: &createKNS, Knative: &appstacksv1.RuntimeComponentKnative{Traffic: knativeTraffic}}
+	badTraffic, _ := Validate(createRuntimeComponent(name, namespace, badTrafficSpec))
+
+	sidecarPortSpec := appstacksv1.RuntimeComponentSpec{CreateKnativeService: &createKNS,
+		SidecarContainers: []corev1.Container{{Name: "proxy", Ports: []corev1.ContainerPort{{ContainerPort: 15001}}}}}
+	sidecarPort, _ := Validate(createRuntimeComponent(name, namespace, sidecarPortSpec))
+
+	knativeWithoutKNSSpec := appstacksv1.RuntimeComponentSpec{Knative: &appstacksv1.RuntimeComponentKnative{}}
+	knativeWithoutKNS, _ := Validate(createRuntimeComponent(name, namespace, knativeWithoutKNSSpec))
+
+	badKeySizeSpec := appstacksv1.RuntimeComponentSpec{Service: &appstacksv1.RuntimeComponentService{Port: 8443,
+		Certificate: &appstacksv1.RuntimeComponentServiceCertificate{PrivateKey: &appstacksv1.RuntimeComponentPrivateKey{Algorithm: "ECDSA", Size: 2048}}}}
+	badKeySize, _ := Validate(createRuntimeComponent(name, namespace, badKeySizeSpec))
+
+	testValidate := []Test{
+		{"Valid spec", true, valid},
+		{"ECDSA key with an RSA size", false, badKeySize},
+		{"Knative traffic that does not add up to 100", false, badTraffic},
+		{"Knative settings without a Knative service", false, knativeWithoutKNS},
+		{"Knative sidecar with ports", false, sidecarPort},
+		{"Vertical autoscaling of the CPU with a CPU-based autoscaling", false, vpaAndCPUHPA},
+		{"Vertical autoscaling of the CPU with a default autoscaling", false, vpaAndDefaultHPA},
+		{"Vertical autoscaling of the memory with a CPU-based autoscaling", true, vpaMemoryAndCPUHPA},
+		{"Autoscaling with event-driven autoscaling", false, hpaAndKeda},
+		{"Event-driven autoscaling without triggers", false, noTriggers},
+		{"Knative service with StatefulSet", false, knsStatefulSet},
+		{"Max replicas below min replicas", false, badAutoscaling},
+		{"Unparsable storage size", false, badStorage},
+		{"Colliding service ports", false, portCollision},
+		{"Colliding service port names", false, portNameCollision},
+		{"Same service port with different protocols", true, udpPort},
+		{"Wildcard route host", true, wildcardHost},
+		{"Invalid route host", false, badHost},
+	}
+	verifyTests(testValidate, t)
+}
+
 func TestCustomizeServiceMonitor(t *testing.T) {
 
 	logger := zap.New()
 	logf.SetLogger(logger)
-	spec := appstacksv1beta2.RuntimeComponentSpec{Service: service}
+	spec := appstacksv1.RuntimeComponentSpec{Service: service}
 
 	params := map[string][]string{
 		"params": {"param1", "param2"},
@@ -613,7 +1089,7 @@ func TestCustomizeServiceMonitor(t *testing.T) {
 	smspec := &prometheusv1.ServiceMonitorSpec{Endpoints: endpointsSM, Selector: *selector}
 
 	sm, runtime := &prometheusv1.ServiceMonitor{Spec: *smspec}, createRuntimeComponent(name, namespace, spec)
-	runtime.Spec.Monitoring = &appstacksv1beta2.RuntimeComponentMonitoring{Labels: labelMap, Endpoints: endpointsApp}
+	runtime.Spec.Monitoring = &appstacksv1.RuntimeComponentMonitoring{Labels: labelMap, Endpoints: endpointsApp}
 
 	CustomizeServiceMonitor(sm, runtime)
 
@@ -659,15 +1135,15 @@ func TestCustomizeServiceMonitor(t *testing.T) {
 func TestGetCondition(t *testing.T) {
 	logger := zap.New()
 	logf.SetLogger(logger)
-	status := &appstacksv1beta2.RuntimeComponentStatus{
-		Conditions: []appstacksv1beta2.StatusCondition{
+	status := &appstacksv1.RuntimeComponentStatus{
+		Conditions: []appstacksv1.StatusCondition{
 			{
 				Status: corev1.ConditionTrue,
-				Type:   appstacksv1beta2.StatusConditionTypeReconciled,
+				Type:   appstacksv1.StatusConditionTypeReconciled,
 			},
 		},
 	}
-	conditionType := appstacksv1beta2.StatusConditionTypeReconciled
+	conditionType := appstacksv1.StatusConditionTypeReconciled
 	cond := GetCondition(conditionType, status)
 	testGC := []Test{{"Set status condition", status.Conditions[0].Status, cond.Status}}
 	verifyTests(testGC, t)
@@ -676,14 +1152,14 @@ func TestGetCondition(t *testing.T) {
 func TestSetCondition(t *testing.T) {
 	logger := zap.New()
 	logf.SetLogger(logger)
-	status := &appstacksv1beta2.RuntimeComponentStatus{
-		Conditions: []appstacksv1beta2.StatusCondition{
-			{Type: appstacksv1beta2.StatusConditionTypeReconciled},
+	status := &appstacksv1.RuntimeComponentStatus{
+		Conditions: []appstacksv1.StatusCondition{
+			{Type: appstacksv1.StatusConditionTypeReconciled},
 		},
 	}
-	condition := appstacksv1beta2.StatusCondition{
+	condition := appstacksv1.StatusCondition{
 		Status: corev1.ConditionTrue,
-		Type:   appstacksv1beta2.StatusConditionTypeReconciled,
+		Type:   appstacksv1.StatusConditionTypeReconciled,
 	}
 	SetCondition(condition, status)
 	testSC := []Test{{"Set status condition", condition.Status, status.Conditions[0].Status}}
@@ -728,9 +1204,22 @@ func TestGetWatchNamespaces(t *testing.T) {
 	verifyTests(configMapConstTests, t)
 }
 
+func TestTailOutput(t *testing.T) {
+	logger := zap.New()
+	logf.SetLogger(logger)
+
+	testTO := []Test{
+		{"Output within limit", "thread dump", TailOutput("thread dump", 20)},
+		{"Output over limit", "dump", TailOutput("thread dump", 4)},
+		{"Multi-byte character at cut", "b", TailOutput("aéb", 2)},
+		{"Empty output", "", TailOutput("", 4)},
+	}
+	verifyTests(testTO, t)
+}
+
 // Helper Functions
 // Unconditionally set the proper tags for an enabled runtime omponent
-func createAppDefinitionTags(app *appstacksv1beta2.RuntimeComponent) (map[string]string, map[string]string) {
+func createAppDefinitionTags(app *appstacksv1.RuntimeComponent) (map[string]string, map[string]string) {
 	// The purpose of this function demands all fields configured
 	if app.Spec.ApplicationVersion == "" {
 		app.Spec.ApplicationVersion = "v1alpha"
@@ -748,8 +1237,8 @@ func createAppDefinitionTags(app *appstacksv1beta2.RuntimeComponent) (map[string
 	}
 	return label, annotations
 }
-func createRuntimeComponent(n, ns string, spec appstacksv1beta2.RuntimeComponentSpec) *appstacksv1beta2.RuntimeComponent {
-	app := &appstacksv1beta2.RuntimeComponent{
+func createRuntimeComponent(n, ns string, spec appstacksv1.RuntimeComponentSpec) *appstacksv1.RuntimeComponent {
+	app := &appstacksv1.RuntimeComponent{
 		ObjectMeta: metav1.ObjectMeta{Name: n, Namespace: ns},
 		Spec:       spec,
 	}